@@ -1,18 +1,81 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"ninedragons/server"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 )
 
+// 게임 기록 저장 위치 기본값 (docker-compose에서 마운트하는 경로)
+const defaultWebdataDir = "/usr/local/main/webdata"
+
+// 종료 신호를 받은 뒤 진행 중인 HTTP 요청을 기다리는 최대 시간
+const shutdownTimeout = 5 * time.Second
+
 func main() {
+	// 종료된 게임 기록 저장소 (WEBDATA_DIR로 경로 변경 가능)
+	webdataDir := os.Getenv("WEBDATA_DIR")
+	if webdataDir == "" {
+		webdataDir = defaultWebdataDir
+	}
+	records, err := server.NewRecordStore(webdataDir)
+	if err != nil {
+		log.Printf("Game records disabled: %v", err)
+	}
+
+	// 플레이어 레이팅 저장소
+	players, err := server.NewPlayerStore(webdataDir)
+	if err != nil {
+		log.Printf("Ratings disabled: %v", err)
+	}
+
+	// 연결이 끊긴 플레이어 처리 (RECONNECT_GRACE_SECONDS 동안 돌아오지 않으면 기권패,
+	// ABANDON_LIMIT번 쌓이면 ABANDON_COOLDOWN_MINUTES 동안 대기열 참가 제한, 0이면 제한 없음)
+	disconnect := server.DefaultDisconnectPolicy()
+	if seconds, err := strconv.Atoi(os.Getenv("RECONNECT_GRACE_SECONDS")); err == nil && seconds > 0 {
+		disconnect.Grace = time.Duration(seconds) * time.Second
+	}
+	if limit, err := strconv.Atoi(os.Getenv("ABANDON_LIMIT")); err == nil && limit >= 0 {
+		disconnect.AbandonLimit = limit
+	}
+	if minutes, err := strconv.Atoi(os.Getenv("ABANDON_COOLDOWN_MINUTES")); err == nil && minutes > 0 {
+		disconnect.AbandonCooldown = time.Duration(minutes) * time.Minute
+	}
+
 	// 구룡투 게임 허브
 	hub := server.NewHub()
+	hub.SetDisconnectPolicy(disconnect)
+	if records != nil {
+		hub.SetRecordStore(records)
+	}
+	if players != nil {
+		hub.SetPlayerStore(players)
+	}
+	// 대기열에서 BOT_FALLBACK_SECONDS 동안 상대가 없으면 AI와 대전 (BOT_FALLBACK_LEVEL: random, greedy, strategic)
+	if seconds, err := strconv.Atoi(os.Getenv("BOT_FALLBACK_SECONDS")); err == nil && seconds > 0 {
+		level := server.BotLevel(os.Getenv("BOT_FALLBACK_LEVEL"))
+		if err := hub.SetBotFallback(time.Duration(seconds)*time.Second, level); err != nil {
+			log.Printf("Invalid BOT_FALLBACK_LEVEL: %v", err)
+		}
+	}
 	go hub.Run()
 
 	// 넘버체인지 게임 허브
 	ncHub := server.NewNCHub()
+	ncHub.SetDisconnectPolicy(disconnect)
+	if records != nil {
+		ncHub.SetRecordStore(records)
+	}
+	if players != nil {
+		ncHub.SetPlayerStore(players)
+	}
 	go ncHub.Run()
 
 	// 구룡투 WebSocket 엔드포인트
@@ -25,10 +88,60 @@ func main() {
 		server.ServeNCWs(ncHub, w, r)
 	})
 
+	// 종료된 게임 기록 조회
+	if records != nil {
+		http.HandleFunc("GET /api/games/ninedragons/{id}", func(w http.ResponseWriter, r *http.Request) {
+			server.ServeGameRecord(records, w, r)
+		})
+		http.HandleFunc("GET /api/games/numberchange/{id}", func(w http.ResponseWriter, r *http.Request) {
+			server.ServeNCGameRecord(records, w, r)
+		})
+		http.HandleFunc("GET /api/series/{id}", func(w http.ResponseWriter, r *http.Request) {
+			server.ServeSeriesRecord(records, w, r)
+		})
+		http.HandleFunc("GET /api/stats/ninedragons/tiles", func(w http.ResponseWriter, r *http.Request) {
+			server.ServeTileStats(records, w, r)
+		})
+	}
+
+	// 리더보드, 플레이어 통계 조회
+	if players != nil {
+		http.HandleFunc("GET /api/leaderboard/{game}", func(w http.ResponseWriter, r *http.Request) {
+			server.ServeLeaderboard(players, w, r)
+		})
+		http.HandleFunc("GET /api/players/{id}", func(w http.ResponseWriter, r *http.Request) {
+			server.ServePlayerStats(players, w, r)
+		})
+	}
+
 	log.Println("Server starting on :8003")
 	log.Println("  - Nine Dragons: /ws")
 	log.Println("  - Number Change: /ws/numberchange")
-	if err := http.ListenAndServe(":8003", nil); err != nil {
-		log.Fatal("ListenAndServe: ", err)
+	log.Println("  - Game records: /api/games/{ninedragons|numberchange}/{id}, /api/series/{id}")
+	log.Println("  - Stats: /api/leaderboard/{game}, /api/players/{id}, /api/stats/ninedragons/tiles")
+
+	// SIGTERM(docker stop), SIGINT를 받으면 새 연결을 막고 대기 중인 기록과 프로필을 디스크에 쓴 뒤 종료
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: ":8003"}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("ListenAndServe: ", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP shutdown: %v", err)
+	}
+	if records != nil {
+		records.Close()
+	}
+	if players != nil {
+		players.Close()
 	}
 }
@@ -0,0 +1,78 @@
+package server
+
+import "sync"
+
+// gameActor 게임 하나의 상태를 맡아 우편함의 일을 차례대로 처리하는 고루틴
+//
+// 게임이 시작되면 그 게임의 상태(좌석, 라운드, 타이머, 관전자, 세션)는 게임 고루틴만 다룬다.
+// 허브 루프는 연결, 대기열, 방, 재대결 같은 허브 상태와 메시지 라우팅만 맡고
+// 게임 메시지는 우편함에 넣기만 할 뿐 처리를 기다리지 않으므로 한 게임이 느려도 다른 게임은 멈추지 않는다.
+// 게임 고루틴이 허브 상태를 다뤄야 할 때는 hubRuntime.call로 허브 루프에서 실행한다.
+// 클라이언트의 GameID, Color 같은 라우팅 필드는 허브 루프만 쓰고, 게임 고루틴은 좌석에 앉은 동안 읽기만 한다.
+// 게임을 닫는 일(stop)도 허브 루프에서만 하므로 허브의 게임 목록에 있는 게임의 우편함은 항상 열려 있다.
+type gameActor struct {
+	mu      sync.Mutex
+	mailbox []func()
+	wake    chan struct{}
+	stopped bool
+}
+
+// startGameActor 게임 고루틴 시작
+func startGameActor() *gameActor {
+	a := &gameActor{
+		wake: make(chan struct{}, 1),
+	}
+	go a.run()
+	return a
+}
+
+// post 우편함에 일을 넣음 (기다리지 않음, 이미 끝난 게임이면 버림)
+func (a *gameActor) post(task func()) {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return
+	}
+	a.mailbox = append(a.mailbox, task)
+	a.mu.Unlock()
+
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// stop 처리 중인 일을 마치면 고루틴 종료 (남은 일은 버림)
+func (a *gameActor) stop() {
+	a.mu.Lock()
+	a.stopped = true
+	a.mailbox = nil
+	a.mu.Unlock()
+
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (a *gameActor) run() {
+	for range a.wake {
+		for {
+			a.mu.Lock()
+			if a.stopped {
+				a.mu.Unlock()
+				return
+			}
+			if len(a.mailbox) == 0 {
+				a.mu.Unlock()
+				break
+			}
+			task := a.mailbox[0]
+			a.mailbox[0] = nil
+			a.mailbox = a.mailbox[1:]
+			a.mu.Unlock()
+
+			task()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// BenchmarkGameActors 게임 N개를 동시에 시작해 각 게임 고루틴이 허브를 거쳐 받은 수를 처리하는 속도
+// go test -run '^$' -bench GameActors ./server/
+func BenchmarkGameActors(b *testing.B) {
+	for _, games := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("games=%d", games), func(b *testing.B) {
+			benchmarkGameActors(b, games)
+		})
+	}
+}
+
+func benchmarkGameActors(b *testing.B, games int) {
+	// 허브가 수마다 남기는 로그는 측정에서 뺌
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	h := NewHub()
+	go h.Run()
+
+	var moves atomic.Int64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var wg sync.WaitGroup
+		for g := 0; g < games; g++ {
+			// 같은 레이팅의 두 플레이어가 연달아 대기열에 서면 바로 한 게임으로 매칭됨
+			for _, name := range []string{"blue", "red"} {
+				client := &Client{ID: fmt.Sprintf("%d-%d-%s", i, g, name), Send: make(chan []byte, 64)}
+				h.register <- client
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					playBenchGame(h, client, &moves)
+					h.unregister <- client
+				}()
+
+				h.gameMessage <- GameMessage{
+					Client:  client,
+					Message: Message{Type: MsgJoinGame, Payload: JoinGamePayload{PlayerName: name}},
+				}
+			}
+		}
+		wg.Wait()
+	}
+	// 허브 루프가 마지막 연결 해제까지 처리할 때까지 기다림
+	h.call(func() {})
+
+	elapsed := b.Elapsed().Seconds()
+	b.ReportMetric(float64(moves.Load())/elapsed, "moves/s")
+	b.ReportMetric(float64(b.N*games)/elapsed, "games/s")
+}
+
+// playBenchGame 게임이 끝날 때까지 차례가 오면 남은 타일 중 가장 작은 것을 냄
+// 서버가 받아들인 자기 타일(tile_played)만 수로 센다
+func playBenchGame(h *Hub, client *Client, moves *atomic.Int64) {
+	var me PlayerColor
+	var tiles []int
+	play := func() {
+		if len(tiles) == 0 {
+			return
+		}
+		tile := tiles[0]
+		tiles = tiles[1:]
+		h.gameMessage <- GameMessage{
+			Client:  client,
+			Message: Message{Type: MsgPlayTile, Payload: PlayTilePayload{Tile: tile}},
+		}
+	}
+
+	for data := range client.Send {
+		var msg struct {
+			Type    MessageType     `json:"type"`
+			Payload json.RawMessage `json:"payload"`
+		}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case MsgGameStart:
+			var start GameStartPayload
+			json.Unmarshal(msg.Payload, &start)
+			me = start.YourColor
+			for tile := start.Rules.MinTile; tile <= start.Rules.MaxTile; tile++ {
+				tiles = append(tiles, tile)
+			}
+			if start.FirstPlayer == me {
+				play()
+			}
+
+		case MsgTilePlayed:
+			var played TilePlayedPayload
+			json.Unmarshal(msg.Payload, &played)
+			if played.Color == me {
+				moves.Add(1)
+			} else if played.WaitingFor == me {
+				play()
+			}
+
+		case MsgRoundResult:
+			var result RoundResultPayload
+			json.Unmarshal(msg.Payload, &result)
+			if result.NextPlayer == me {
+				play()
+			}
+
+		case MsgGameOver:
+			return
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// AI 설정
+const (
+	botMinThink       = 700 * time.Millisecond // 사람처럼 보이도록 두는 최소 고민 시간
+	botMaxThink       = 1500 * time.Millisecond
+	botEquilibriumRun = 2000 // 혼합 전략 계산 반복 횟수
+	botRollouts       = 40   // 조합마다 남은 라운드를 무작위로 진행해 보는 횟수
+
+	defaultBotFallbackLevel = BotStrategic // 대기열에서 상대를 못 찾았을 때 붙이는 AI 기본 난이도
+)
+
+// botNames 난이도별 표시 이름
+var botNames = map[BotLevel]string{
+	BotRandom:    "AI (랜덤)",
+	BotGreedy:    "AI (욕심쟁이)",
+	BotStrategic: "AI (전략가)",
+}
+
+// NewBot 난이도에 맞는 AI 플레이어 생성
+func NewBot(color PlayerColor, level BotLevel) (*Bot, error) {
+	name, ok := botNames[level]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 AI 난이도입니다: %s", level)
+	}
+	return &Bot{Seat: color, Level: level, Name: name}, nil
+}
+
+// ChooseTile 현재 게임 상황에서 낼 타일 선택
+// 상대가 이번 라운드에 낸 타일은 사람 플레이어와 같은 공개 범위만큼만 참고한다
+func (b *Bot) ChooseTile(game *Game) int {
+	own := remainingTiles(game, b.Seat)
+	if len(own) == 0 {
+		return 0
+	}
+	opponent := opponentCandidates(game, b.Seat)
+	if len(opponent) == 0 {
+		return own[rand.Intn(len(own))]
+	}
+
+	switch b.Level {
+	case BotGreedy:
+		return greedyTile(game.Rules, own, opponent)
+	case BotStrategic:
+		return strategicTile(game, b.Seat, own, opponent)
+	default:
+		return own[rand.Intn(len(own))]
+	}
+}
+
+// remainingTiles 아직 내지 않은 타일 목록
+func remainingTiles(game *Game, color PlayerColor) []int {
+	used := make(map[int]bool)
+	for _, tile := range game.UsedTiles[color] {
+		used[tile] = true
+	}
+
+	tiles := []int{}
+	for tile := game.Rules.MinTile; tile <= game.Rules.MaxTile; tile++ {
+		if !used[tile] {
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles
+}
+
+// opponentCandidates 상대가 이번 라운드에 낼 수 있는(또는 이미 냈을 수 있는) 타일 목록
+func opponentCandidates(game *Game, color PlayerColor) []int {
+	opponent := opponentColor(color)
+	played := game.RoundTiles[opponent]
+	if played == nil {
+		return remainingTiles(game, opponent)
+	}
+
+	switch game.TileReveal {
+	case RevealOpen:
+		return []int{*played}
+	case RevealColor:
+		// 이미 낸 타일은 사용 목록에 있으므로 되돌린 뒤 같은 색만 남김
+		candidates := []int{}
+		for _, tile := range append(remainingTiles(game, opponent), *played) {
+			if TileBand(tile) == TileBand(*played) {
+				candidates = append(candidates, tile)
+			}
+		}
+		return candidates
+	default:
+		return append(remainingTiles(game, opponent), *played)
+	}
+}
+
+// tileOutcome 규칙에 따른 라운드 결과 (이기면 1, 비기면 0, 지면 -1)
+func tileOutcome(rules RuleSet, own, opponent int) int {
+	switch {
+	case own == opponent:
+		return 0
+	case rules.IsUpset(own, opponent):
+		return 1
+	case rules.IsUpset(opponent, own):
+		return -1
+	case own > opponent:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// greedyTile 이번 라운드 기대 결과가 가장 좋은 타일 (같으면 더 작은 타일)
+func greedyTile(rules RuleSet, own, opponent []int) int {
+	best, bestScore := own[0], -len(opponent)-1
+	for _, tile := range own {
+		score := 0
+		for _, opp := range opponent {
+			score += tileOutcome(rules, tile, opp)
+		}
+		if score > bestScore {
+			best, bestScore = tile, score
+		}
+	}
+	return best
+}
+
+// strategicTile 이번 라운드를 영합 게임으로 보고 구한 균형 혼합 전략에서 타일 추출
+// 각 조합의 이득은 그 라운드 이후 남은 타일로 무작위 진행했을 때의 기대 승패로 평가한다
+func strategicTile(game *Game, color PlayerColor, own, opponent []int) int {
+	ownWins, oppWins := game.BlueWins, game.RedWins
+	if color == Red {
+		ownWins, oppWins = oppWins, ownWins
+	}
+	oppRemaining := remainingTiles(game, opponentColor(color))
+	if played := game.RoundTiles[opponentColor(color)]; played != nil {
+		oppRemaining = append(oppRemaining, *played)
+	}
+
+	payoff := make([][]float64, len(own))
+	for i, tile := range own {
+		payoff[i] = make([]float64, len(opponent))
+		for j, opp := range opponent {
+			w, l := ownWins, oppWins
+			switch tileOutcome(game.Rules, tile, opp) {
+			case 1:
+				w++
+			case -1:
+				l++
+			}
+			payoff[i][j] = rolloutValue(game.Rules, without(own, tile), without(oppRemaining, opp), w, l, game.CurrentRound+1)
+		}
+	}
+
+	mix := equilibriumMix(payoff)
+	pick := rand.Float64()
+	for i, p := range mix {
+		pick -= p
+		if pick <= 0 {
+			return own[i]
+		}
+	}
+	return own[len(own)-1]
+}
+
+// rolloutValue 남은 라운드를 무작위로 진행했을 때의 기대 결과 (승리 1, 패배 -1)
+func rolloutValue(rules RuleSet, own, opponent []int, ownWins, oppWins, round int) float64 {
+	total := 0.0
+	ownOrder := make([]int, len(own))
+	oppOrder := make([]int, len(opponent))
+	for n := 0; n < botRollouts; n++ {
+		copy(ownOrder, own)
+		copy(oppOrder, opponent)
+		rand.Shuffle(len(ownOrder), func(i, j int) { ownOrder[i], ownOrder[j] = ownOrder[j], ownOrder[i] })
+		rand.Shuffle(len(oppOrder), func(i, j int) { oppOrder[i], oppOrder[j] = oppOrder[j], oppOrder[i] })
+
+		w, l := ownWins, oppWins
+		for r, k := round, 0; r <= rules.MaxRounds && w < rules.WinsNeeded && l < rules.WinsNeeded && k < len(ownOrder) && k < len(oppOrder); r, k = r+1, k+1 {
+			switch tileOutcome(rules, ownOrder[k], oppOrder[k]) {
+			case 1:
+				w++
+			case -1:
+				l++
+			}
+		}
+		if w > l {
+			total++
+		} else if l > w {
+			total--
+		}
+	}
+	return total / botRollouts
+}
+
+// without tile 하나를 뺀 새 목록
+func without(tiles []int, tile int) []int {
+	rest := make([]int, 0, len(tiles))
+	removed := false
+	for _, t := range tiles {
+		if t == tile && !removed {
+			removed = true
+			continue
+		}
+		rest = append(rest, t)
+	}
+	return rest
+}
+
+// equilibriumMix 가상 대전(fictitious play)으로 구한 행 플레이어의 혼합 전략
+func equilibriumMix(payoff [][]float64) []float64 {
+	rows, cols := len(payoff), len(payoff[0])
+	rowCounts := make([]float64, rows)
+	rowTotals := make([]float64, rows) // 상대의 지금까지 선택에 대한 각 행의 누적 이득
+	colTotals := make([]float64, cols) // 지금까지 행 선택에 대한 각 열의 누적 손실
+
+	for iter := 0; iter < botEquilibriumRun; iter++ {
+		row := argmax(rowTotals)
+		rowCounts[row]++
+		for j := range colTotals {
+			colTotals[j] += payoff[row][j]
+		}
+
+		col := argmin(colTotals)
+		for i := range rowTotals {
+			rowTotals[i] += payoff[i][col]
+		}
+	}
+
+	mix := make([]float64, rows)
+	for i, count := range rowCounts {
+		mix[i] = count / botEquilibriumRun
+	}
+	return mix
+}
+
+func argmax(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v > values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+func argmin(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v < values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// ==================== Hub Integration ====================
+
+// SetBotFallback 대기열에서 wait 동안 상대가 없으면 level AI와 대전 (0이면 사용 안 함, 다전제 대기는 제외)
+// 대기열을 떠난 뒤에 에러를 받지 않도록 알 수 없는 난이도는 기본 난이도로 바꾸고 에러 반환
+func (h *gameHub[G]) SetBotFallback(wait time.Duration, level BotLevel) error {
+	h.botFallbackWait = wait
+	h.botFallbackLevel = h.hooks.defaultBotLevel()
+	if level == "" {
+		return nil
+	}
+	if _, err := h.hooks.newBot(level); err != nil {
+		return fmt.Errorf("알 수 없는 AI 난이도입니다: %s (%s 사용)", level, h.botFallbackLevel)
+	}
+	h.botFallbackLevel = level
+	return nil
+}
+
+// handleBotGame 대기열 없이 바로 AI와 대전 시작
+func (h *gameHub[G]) handleBotGame(client *Client, level BotLevel, setup gameSetup) {
+	bot, err := h.hooks.newBot(level)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+
+	game := h.hooks.newGame(setup.Settings)
+	h.addGame(game)
+	gameID := game.table().ID
+	log.Printf("%sCreated bot game %s (%s)", h.logPrefix, gameID, bot.Level)
+
+	seat, err := h.seatPlayer(game, client, setup.Seat)
+	if err != nil {
+		delete(h.games, gameID)
+		h.sendError(client, err.Error())
+		return
+	}
+	bot.Seat = h.opponent(seat)
+	if err := game.AddBot(bot); err != nil {
+		log.Printf("%sError adding bot to game %s: %v", h.logPrefix, gameID, err)
+		h.abortGame(game, err.Error())
+		return
+	}
+
+	h.startGame(game)
+}
+
+// scheduleBotMove 다음 차례가 AI면 고민 시간 후 수를 두도록 예약
+func (h *Hub) scheduleBotMove(game *Game) {
+	bot := game.Bots[game.GetNextPlayer()]
+	if bot == nil {
+		return
+	}
+
+	seq := game.turnSeq
+	think := botMinThink + time.Duration(rand.Int63n(int64(botMaxThink-botMinThink)))
+	time.AfterFunc(think, func() {
+		game.actor.post(func() { h.handleBotMove(game, seq) })
+	})
+}
+
+// handleBotMove 예약된 AI 차례 실행 (차례 타이머와 같은 순번으로 오래된 예약 무시)
+func (h *Hub) handleBotMove(game *Game, seq int) {
+	if !game.Ready || game.turnSeq != seq {
+		return
+	}
+
+	bot := game.Bots[game.GetNextPlayer()]
+	if bot == nil {
+		return
+	}
+
+	tile := bot.ChooseTile(game)
+	if err := h.playTile(game, bot.Seat, tile, false); err != nil {
+		log.Printf("Error playing bot tile %d for %s in game %s: %v", tile, bot.Seat, game.ID, err)
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewBot(t *testing.T) {
+	for level := range botNames {
+		bot, err := NewBot(Red, level)
+		if err != nil || bot.Seat != Red || bot.Name != botNames[level] {
+			t.Errorf("NewBot(%s) = %+v, %v", level, bot, err)
+		}
+	}
+	if _, err := NewBot(Red, "grandmaster"); err == nil {
+		t.Error("unknown level was accepted")
+	}
+}
+
+// 알 수 없는 대기열 AI 난이도는 시작할 때 걸러 기본 난이도로 대전
+func TestSetBotFallback(t *testing.T) {
+	tests := []struct {
+		level   BotLevel
+		want    BotLevel
+		wantErr bool
+	}{
+		{level: "", want: defaultBotFallbackLevel},
+		{level: BotGreedy, want: BotGreedy},
+		{level: "grandmaster", want: defaultBotFallbackLevel, wantErr: true},
+	}
+	for _, tt := range tests {
+		h := NewHub()
+		err := h.SetBotFallback(time.Minute, tt.level)
+		if (err != nil) != tt.wantErr || h.botFallbackLevel != tt.want || h.botFallbackWait != time.Minute {
+			t.Errorf("SetBotFallback(%q) = %v, level %s; want %s, err %v", tt.level, err, h.botFallbackLevel, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// AI끼리 끝까지 두면서 고른 타일이 언제나 남은 타일이고 게임이 받아들이는지 확인
+func TestBotPlaysLegalTiles(t *testing.T) {
+	for _, level := range []BotLevel{BotRandom, BotGreedy, BotStrategic} {
+		for _, rules := range []RuleSet{ClassicRules(), ShortRules(), ExtendedRules()} {
+			for _, reveal := range []TileReveal{RevealBlind, RevealColor, RevealOpen} {
+				t.Run(fmt.Sprintf("%s/%s/%s", level, rules.Name, reveal), func(t *testing.T) {
+					playBotGame(t, rules, reveal, level)
+				})
+			}
+		}
+	}
+}
+
+func playBotGame(t *testing.T, rules RuleSet, reveal TileReveal, level BotLevel) {
+	t.Helper()
+	game := NewGame(rules)
+	game.TileReveal = reveal
+	for _, color := range []PlayerColor{Blue, Red} {
+		bot, _ := NewBot(color, level)
+		if err := game.AddBot(bot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	game.Start()
+
+	for moves := 0; ; moves++ {
+		if moves > 2*rules.TileCount() {
+			t.Fatal("game did not end after every tile was played")
+		}
+		color := game.GetNextPlayer()
+		remaining := remainingTiles(game, color)
+		tile := game.Bots[color].ChooseTile(game)
+		if !containsInt(remaining, tile) {
+			t.Fatalf("round %d: %s chose %d, remaining %v", game.CurrentRound, color, tile, remaining)
+		}
+		if err := game.PlayTile(color, tile); err != nil {
+			t.Fatalf("round %d: %s played %d: %v", game.CurrentRound, color, tile, err)
+		}
+
+		if !game.RoundComplete() {
+			continue
+		}
+		game.ProcessRound()
+		if over, _ := game.IsGameOver(); over {
+			return
+		}
+	}
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// 상대 타일이 모두 보이면 욕심쟁이는 이길 수 있는 가장 작은 타일을 낸다
+func TestGreedyBeatsOpenTile(t *testing.T) {
+	game := NewGame(ClassicRules())
+	game.TileReveal = RevealOpen
+	game.Start()
+	if err := game.PlayTile(Blue, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	bot, _ := NewBot(Red, BotGreedy)
+	if tile := bot.ChooseTile(game); tile != 6 {
+		t.Errorf("greedy bot chose %d against an open 5, want 6", tile)
+	}
+}
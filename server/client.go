@@ -3,120 +3,55 @@ package server
 import (
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
-const (
-	writeWait      = 10 * time.Second
-	pongWait       = 60 * time.Second
-	pingPeriod     = (pongWait * 9) / 10
-	maxMessageSize = 512
-)
+func (c *Client) connection() *websocket.Conn { return c.Conn }
+func (c *Client) outbox() chan []byte         { return c.Send }
+func (c *Client) clientID() string            { return c.ID }
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true // 개발 환경에서는 모든 origin 허용
-	},
+func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
+	hub.serveWs(w, r)
 }
 
-func (c *Client) readPump() {
-	defer func() {
-		c.Hub.unregister <- c
-		c.Conn.Close()
-	}()
-
-	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
-	c.Conn.SetPongHandler(func(string) error {
-		c.Conn.SetReadDeadline(time.Now().Add(pongWait))
-		return nil
-	})
-
-	for {
-		_, message, err := c.Conn.ReadMessage()
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("error: %v", err)
-			}
-			break
-		}
-
-		var msg Message
-		if err := json.Unmarshal(message, &msg); err != nil {
-			log.Printf("Error unmarshaling message: %v", err)
-			continue
-		}
-
-		c.Hub.gameMessage <- GameMessage{
-			Client:  c,
-			Message: msg,
-		}
-	}
+func ServeNCWs(hub *NCHub, w http.ResponseWriter, r *http.Request) {
+	hub.serveWs(w, r)
 }
 
-func (c *Client) writePump() {
-	ticker := time.NewTicker(pingPeriod)
-	defer func() {
-		ticker.Stop()
-		c.Conn.Close()
-	}()
-
-	for {
-		select {
-		case message, ok := <-c.Send:
-			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if !ok {
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := c.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			// 대기 중인 메시지 추가
-			n := len(c.Send)
-			for i := 0; i < n; i++ {
-				w.Write([]byte{'\n'})
-				w.Write(<-c.Send)
-			}
-
-			if err := w.Close(); err != nil {
-				return
-			}
-
-		case <-ticker.C:
-			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
+// serveWs 웹소켓 연결을 받아 이 허브의 클라이언트로 등록
+func (h *gameHub[G]) serveWs(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(conn *websocket.Conn) *Client {
+		return &Client{
+			ID:   uuid.New().String(),
+			Conn: conn,
+			Send: make(chan []byte, 256),
+			Addr: remoteHost(r),
 		}
-	}
+	}, h.decodeGameMessage)
 }
 
-func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+// remoteHost 요청을 보낸 주소에서 포트를 뺀 호스트
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		log.Println(err)
-		return
+		return r.RemoteAddr
 	}
+	return host
+}
 
-	client := &Client{
-		ID:   uuid.New().String(),
-		Hub:  hub,
-		Conn: conn,
-		Send: make(chan []byte, 256),
+// decodeGameMessage 클라이언트가 보낸 메시지를 허브 이벤트로 변환
+func (h *gameHub[G]) decodeGameMessage(c *Client, data []byte) (GameMessage, bool) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("%sError unmarshaling message: %v", h.logPrefix, err)
+		return GameMessage{}, false
 	}
-
-	client.Hub.register <- client
-
-	go client.writePump()
-	go client.readPump()
+	return GameMessage{
+		Client:  c,
+		Message: msg,
+	}, true
 }
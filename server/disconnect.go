@@ -0,0 +1,204 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// 연결 끊김 처리 기본값
+const (
+	reconnectGracePeriod   = 60 * time.Second // 재접속을 기다리는 시간
+	defaultAbandonLimit    = 3                // 기간 안에 이만큼 기권패가 쌓이면 대기열 참가 제한
+	defaultAbandonWindow   = 24 * time.Hour   // 기권패를 세는 기간
+	defaultAbandonCooldown = 15 * time.Minute // 대기열 참가 제한 시간
+)
+
+// DisconnectPolicy 진행 중인 게임에서 연결이 끊긴 플레이어 처리 규칙
+type DisconnectPolicy struct {
+	Grace           time.Duration // 재접속을 기다리는 시간 (지나면 기권패)
+	AbandonLimit    int           // AbandonWindow 안에 이만큼 기권패가 쌓이면 대기열 참가 제한 (0이면 제한 없음)
+	AbandonWindow   time.Duration
+	AbandonCooldown time.Duration // 대기열 참가 제한 시간
+}
+
+// DefaultDisconnectPolicy 기본 연결 끊김 처리 규칙
+func DefaultDisconnectPolicy() DisconnectPolicy {
+	return DisconnectPolicy{
+		Grace:           reconnectGracePeriod,
+		AbandonLimit:    defaultAbandonLimit,
+		AbandonWindow:   defaultAbandonWindow,
+		AbandonCooldown: defaultAbandonCooldown,
+	}
+}
+
+// abandonRecord 한 연결 주소나 프로필의 기권패 기록
+type abandonRecord struct {
+	At          []time.Time `json:"at,omitempty"` // 기간 안의 기권패 시각
+	BannedUntil time.Time   `json:"bannedUntil"`
+}
+
+// add 기권패를 기록하고 이번 기록으로 대기열 참가 제한이 걸렸으면 해제 시각 반환
+func (r *abandonRecord) add(now time.Time, policy DisconnectPolicy) (time.Time, bool) {
+	r.prune(now, policy.AbandonWindow)
+	r.At = append(r.At, now)
+
+	if policy.AbandonLimit <= 0 || len(r.At) < policy.AbandonLimit {
+		return time.Time{}, false
+	}
+
+	// 제한이 풀리면 다시 처음부터 셈
+	r.BannedUntil = now.Add(policy.AbandonCooldown)
+	r.At = nil
+	return r.BannedUntil, true
+}
+
+// bannedUntil 대기열 참가 제한 해제 시각 (기록이 없거나 제한 중이 아니면 false)
+func (r *abandonRecord) bannedUntil(now time.Time) (time.Time, bool) {
+	if r == nil || !now.Before(r.BannedUntil) {
+		return time.Time{}, false
+	}
+	return r.BannedUntil, true
+}
+
+// prune 기간이 지난 기권패 삭제
+func (r *abandonRecord) prune(now time.Time, window time.Duration) {
+	cutoff := now.Add(-window)
+	kept := r.At[:0]
+	for _, at := range r.At {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	r.At = kept
+}
+
+// AbandonTracker 연결 주소별 기권패 기록 (허브 루프와 게임 고루틴에서 함께 사용)
+// 프로필 없이 연결마다 새로 들어와도 같은 주소면 함께 세고, 서버가 꺼지면 사라진다
+// 프로필이 있는 플레이어는 PlayerStore에도 기록해 재시작 뒤에도 제한이 이어진다
+type AbandonTracker struct {
+	mu      sync.Mutex
+	players map[string]*abandonRecord
+}
+
+// NewAbandonTracker 빈 기권패 기록 생성
+func NewAbandonTracker() *AbandonTracker {
+	return &AbandonTracker{
+		players: make(map[string]*abandonRecord),
+	}
+}
+
+// Record 기권패를 기록하고 이번 기록으로 대기열 참가 제한이 걸렸으면 해제 시각 반환 (주소를 모르면 세지 않음)
+func (t *AbandonTracker) Record(addr string, now time.Time, policy DisconnectPolicy) (time.Time, bool) {
+	if addr == "" {
+		return time.Time{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record := t.players[addr]
+	if record == nil {
+		record = &abandonRecord{}
+		t.players[addr] = record
+	}
+	return record.add(now, policy)
+}
+
+// BannedUntil 대기열 참가 제한 해제 시각 (제한 중이 아니면 false)
+func (t *AbandonTracker) BannedUntil(addr string, now time.Time) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.players[addr].bannedUntil(now)
+}
+
+// RecordAbandon 프로필에 기권패를 기록하고 이번 기록으로 대기열 참가 제한이 걸렸으면 해제 시각 반환
+func (s *PlayerStore) RecordAbandon(playerID string, now time.Time, policy DisconnectPolicy) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.byID[playerID]
+	if p == nil {
+		return time.Time{}, false
+	}
+	if p.Abandons == nil {
+		p.Abandons = &abandonRecord{}
+	}
+	until, banned := p.Abandons.add(now, policy)
+	s.markDirty()
+	return until, banned
+}
+
+// QueueBannedUntil 프로필의 대기열 참가 제한 해제 시각 (제한 중이 아니면 false)
+func (s *PlayerStore) QueueBannedUntil(playerID string, now time.Time) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.byID[playerID]
+	if p == nil {
+		return time.Time{}, false
+	}
+	return p.Abandons.bannedUntil(now)
+}
+
+// queueBanMessage 대기열 참가 제한 안내
+func queueBanMessage(until time.Time) string {
+	minutes := int(math.Ceil(time.Until(until).Minutes()))
+	return fmt.Sprintf("연결을 끊고 나간 게임이 많아 %d분 동안 대기열에 참가할 수 없습니다", minutes)
+}
+
+// ==================== Hub Integration ====================
+
+// SetDisconnectPolicy 연결 끊김 처리 규칙 지정
+func (h *gameHub[G]) SetDisconnectPolicy(policy DisconnectPolicy) {
+	h.disconnect = policy
+}
+
+// abandonGame 유예 시간 안에 돌아오지 않은 seat의 기권패로 게임 종료
+func (h *gameHub[G]) abandonGame(game G, seat Seat) {
+	t := game.table()
+	session := t.Sessions[seat]
+	if until, banned := h.recordAbandon(session, time.Now()); banned {
+		log.Printf("%sPlayer %s (%s) blocked from queueing until %s", h.logPrefix, session.Name, session.Addr, until.Format(time.RFC3339))
+	}
+
+	log.Printf("%sPlayer %s abandoned game %s", h.logPrefix, seat, t.ID)
+	h.endGame(game, h.opponent(seat), reasonAbandoned)
+}
+
+// recordAbandon 기권패를 연결 주소에, 프로필이 있으면 프로필에도 기록
+// 프로필 없이 다시 들어와도 같은 주소의 제한은 이어진다 (둘 중 하나라도 제한이 걸리면 해제 시각 반환)
+func (h *gameHub[G]) recordAbandon(session *Session, now time.Time) (time.Time, bool) {
+	until, banned := h.abandons.Record(session.Addr, now, h.disconnect)
+	if h.players != nil && session.PlayerID != "" {
+		if profileUntil, profileBanned := h.players.RecordAbandon(session.PlayerID, now, h.disconnect); profileBanned {
+			until, banned = laterOf(until, profileUntil), true
+		}
+	}
+	return until, banned
+}
+
+// allowQueue 기권패가 잦아 연결 주소나 프로필의 대기열 참가가 제한된 플레이어면 에러 전송 후 false
+func (h *gameHub[G]) allowQueue(client *Client) bool {
+	now := time.Now()
+	until, banned := h.abandons.BannedUntil(client.Addr, now)
+	if h.players != nil && client.PlayerID != "" {
+		if profileUntil, profileBanned := h.players.QueueBannedUntil(client.PlayerID, now); profileBanned {
+			until, banned = laterOf(until, profileUntil), true
+		}
+	}
+	if banned {
+		h.sendError(client, queueBanMessage(until))
+	}
+	return !banned
+}
+
+// laterOf 두 시각 중 늦은 시각
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
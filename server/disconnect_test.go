@@ -0,0 +1,190 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAbandonTracker(t *testing.T) {
+	policy := DisconnectPolicy{AbandonLimit: 3, AbandonWindow: 24 * time.Hour, AbandonCooldown: 15 * time.Minute}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		policy     DisconnectPolicy
+		addr       string
+		abandons   []time.Duration // start 기준 기권패 시각
+		wantBanned bool
+	}{
+		{name: "제한 미만", policy: policy, addr: "10.0.0.1", abandons: []time.Duration{0, time.Hour}},
+		{name: "기간 안에 제한 도달", policy: policy, addr: "10.0.0.1", abandons: []time.Duration{0, time.Hour, 2 * time.Hour}, wantBanned: true},
+		{name: "기간이 지난 기권패는 세지 않음", policy: policy, addr: "10.0.0.1", abandons: []time.Duration{0, 13 * time.Hour, 26 * time.Hour}},
+		{name: "주소를 모르는 연결", policy: policy, abandons: []time.Duration{0, time.Hour, 2 * time.Hour}},
+		{name: "제한 없음", policy: DisconnectPolicy{AbandonWindow: 24 * time.Hour}, addr: "10.0.0.1", abandons: []time.Duration{0, time.Hour, 2 * time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewAbandonTracker()
+			var until time.Time
+			var banned bool
+			for i, offset := range tt.abandons {
+				until, banned = tracker.Record(tt.addr, start.Add(offset), tt.policy)
+				if banned && i < len(tt.abandons)-1 {
+					t.Fatalf("banned after %d abandons", i+1)
+				}
+			}
+			if banned != tt.wantBanned {
+				t.Fatalf("banned = %v, want %v", banned, tt.wantBanned)
+			}
+
+			last := start.Add(tt.abandons[len(tt.abandons)-1])
+			gotUntil, gotBanned := tracker.BannedUntil(tt.addr, last)
+			if gotBanned != tt.wantBanned || !gotUntil.Equal(until) {
+				t.Errorf("BannedUntil = %v, %v; want %v, %v", gotUntil, gotBanned, until, tt.wantBanned)
+			}
+			if banned && !until.Equal(last.Add(tt.policy.AbandonCooldown)) {
+				t.Errorf("banned until %v, want %v", until, last.Add(tt.policy.AbandonCooldown))
+			}
+		})
+	}
+}
+
+// 제한이 풀리면 기권패를 처음부터 다시 셈
+func TestAbandonTrackerCooldown(t *testing.T) {
+	policy := DisconnectPolicy{AbandonLimit: 2, AbandonWindow: time.Hour, AbandonCooldown: 15 * time.Minute}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewAbandonTracker()
+
+	tracker.Record("p", start, policy)
+	until, banned := tracker.Record("p", start.Add(time.Minute), policy)
+	if !banned {
+		t.Fatal("second abandon did not ban")
+	}
+	if _, banned := tracker.BannedUntil("p", until.Add(-time.Second)); !banned {
+		t.Error("ban lifted early")
+	}
+	if _, banned := tracker.BannedUntil("p", until); banned {
+		t.Error("ban not lifted at the end of the cooldown")
+	}
+	if _, banned := tracker.Record("p", until.Add(time.Minute), policy); banned {
+		t.Error("first abandon after the cooldown banned again")
+	}
+}
+
+// 유예 시간이 지나면 돌아오지 않은 플레이어의 기권패, 둘 다 없으면 취소
+func TestSessionExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		bothAway   bool
+		resumed    bool // 유예 시간 안에 돌아옴
+		wantOver   bool
+		wantClosed bool
+	}{
+		{name: "파랑이 돌아오지 않음", wantOver: true, wantClosed: true},
+		{name: "둘 다 돌아오지 않음", bothAway: true, wantClosed: true},
+		{name: "파랑이 돌아옴", resumed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, game, blue, red := testGame(t)
+			session := h.newSession(game, blue)
+			session.Addr = "10.0.0.1"
+			redSession := h.newSession(game, red)
+
+			disconnectedAt := time.Now()
+			delete(game.Players, Blue)
+			session.Disconnected, session.DisconnectedAt = true, disconnectedAt
+			if tt.bothAway {
+				delete(game.Players, Red)
+				redSession.Disconnected, redSession.DisconnectedAt = true, disconnectedAt
+			}
+			if tt.resumed {
+				session.Disconnected = false
+				game.Players[Blue] = blue
+			}
+
+			h.handleSessionExpiry(game, session, disconnectedAt)
+
+			over := gameOver(t, red)
+			if (over != nil) != tt.wantOver {
+				t.Fatalf("red game over = %+v, want %v", over, tt.wantOver)
+			}
+			if over != nil && (over.Winner != Red || over.Reason != reasonAbandoned) {
+				t.Errorf("game over = %q %q, want %q %q", over.Winner, over.Reason, Red, reasonAbandoned)
+			}
+			if closed := h.games[game.ID] == nil; closed != tt.wantClosed {
+				t.Errorf("closed = %v, want %v", closed, tt.wantClosed)
+			}
+			// 기권패만 대기열 제한 기록에 남음
+			tracker := h.abandons.players["10.0.0.1"]
+			if counted := tracker != nil && len(tracker.At) == 1; counted != tt.wantOver {
+				t.Errorf("abandon counted = %v, want %v", counted, tt.wantOver)
+			}
+		})
+	}
+}
+
+// 프로필 없이 다시 접속해도 같은 주소의 기권패가 쌓여 대기열 참가가 막힌다
+func TestAbandonBanByAddress(t *testing.T) {
+	h, game, blue, _ := testGame(t)
+	h.SetDisconnectPolicy(DisconnectPolicy{AbandonLimit: 1, AbandonWindow: time.Hour, AbandonCooldown: 15 * time.Minute})
+	blue.Addr = "10.0.0.1"
+	h.newSession(game, blue)
+
+	h.abandonGame(game, Blue)
+
+	again := testClient("blue-again", 8)
+	again.Addr = "10.0.0.1"
+	h.addClient(again)
+	if h.allowQueue(again) {
+		t.Fatal("new connection from the same address was allowed to queue")
+	}
+	if got := lastError(t, again); got == "" {
+		t.Error("no ban message sent")
+	}
+
+	other := testClient("other", 8)
+	other.Addr = "10.0.0.2"
+	if !h.allowQueue(other) {
+		t.Error("connection from another address was blocked")
+	}
+}
+
+// 프로필에 남긴 기권패와 제한은 저장소를 다시 열어도 이어진다
+func TestAbandonBanPersistedOnProfile(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewPlayerStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := store.Identify("", "alice")
+
+	h, game, blue, _ := testGame(t)
+	h.SetPlayerStore(store)
+	h.SetDisconnectPolicy(DisconnectPolicy{AbandonLimit: 1, AbandonWindow: time.Hour, AbandonCooldown: 15 * time.Minute})
+	blue.PlayerID = alice.ID
+	h.newSession(game, blue)
+
+	h.abandonGame(game, Blue)
+	store.Close()
+
+	reopened, err := NewPlayerStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if _, banned := reopened.QueueBannedUntil(alice.ID, time.Now()); !banned {
+		t.Fatal("ban lost after reopening the player store")
+	}
+
+	// 재시작한 서버의 다른 게임 허브에서도 같은 프로필은 막힌다
+	nc := NewNCHub()
+	nc.SetPlayerStore(reopened)
+	client := testClient("alice", 8)
+	client.PlayerID = alice.ID
+	if nc.allowQueue(client) {
+		t.Error("identified player was allowed to queue after a restart")
+	}
+}
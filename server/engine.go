@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Seat 게임 좌석 (구룡투는 PlayerColor, 넘버체인지는 TeamColor 값을 그대로 쓴다)
+type Seat string
+
+// Move 좌석이 둔 수
+type Move struct {
+	Kind    string          // 클라이언트 메시지 종류 (한 게임에 수가 여러 종류일 때 구분)
+	Payload json.RawMessage // 클라이언트 메시지 payload 그대로
+	Auto    bool            // 시간 초과로 서버가 대신 둔 수
+}
+
+// GameEngine 한 게임 종류의 규칙
+// 허브는 연결, 좌석, 타이머, 메시지 전송을 맡고 게임 진행은 이 인터페이스로만 다룬다
+// 새 게임은 GameEngine과 좌석 상태(Table)를 갖춘 게임 타입을 만들고 허브 쪽에서 gameHooks만 구현하면 된다
+type GameEngine interface {
+	// Seats 좌석 목록 (순서대로 배정)
+	Seats() []Seat
+
+	// AssignSeat 새 참가자의 좌석 결정 (원하는 좌석이 차 있으면 남은 좌석)
+	AssignSeat(preferred Seat) (Seat, error)
+
+	// ApplyMove 좌석이 둔 수 적용
+	ApplyMove(seat Seat, move Move) error
+
+	// RoundComplete 이번 라운드의 수가 모두 모여 결과를 처리할 수 있는지
+	RoundComplete() bool
+
+	// ResolveRound 모인 수로 라운드를 처리하고 클라이언트에 보낼 결과 반환
+	ResolveRound() (interface{}, error)
+
+	// Outcome 게임 종료 여부, 승자 (무승부면 빈 값), 종료 사유
+	Outcome() (over bool, winner Seat, reason string)
+
+	// View 좌석 시점의 상태 (빈 값이면 관전자 시점, 상대에게 숨긴 정보는 빠진다)
+	View(seat Seat) interface{}
+}
+
+// ErrNoSeat 남은 좌석이 없음
+var ErrNoSeat = errors.New("남은 좌석이 없습니다")
+
+// assignSeat 원하는 좌석이 비어있으면 그 좌석, 아니면 순서대로 첫 빈 좌석
+func assignSeat(seats []Seat, preferred Seat, taken func(Seat) bool) (Seat, error) {
+	for _, seat := range seats {
+		if seat == preferred && !taken(seat) {
+			return seat, nil
+		}
+	}
+	for _, seat := range seats {
+		if !taken(seat) {
+			return seat, nil
+		}
+	}
+	return "", ErrNoSeat
+}
+
+// engineEvents 엔진을 진행하면서 허브가 게임 종류에 맞게 알리고 처리할 일
+type engineEvents struct {
+	// moved 수를 적용한 직후 (라운드 결과를 처리하기 전)
+	moved func(seat Seat, move Move)
+
+	// resolved 라운드 결과 처리 후 (over: 이 라운드로 게임이 끝났는지)
+	resolved func(result interface{}, over bool)
+
+	// over 게임 종료
+	over func(winner Seat, reason string)
+
+	// failed 라운드를 처리할 수 없음
+	failed func(err error)
+}
+
+// playMove seat의 수를 엔진에 적용하고 라운드가 끝났으면 결과 처리 (게임 고루틴에서 호출)
+func playMove(engine GameEngine, seat Seat, move Move, events engineEvents) error {
+	if err := engine.ApplyMove(seat, move); err != nil {
+		return err
+	}
+	if events.moved != nil {
+		events.moved(seat, move)
+	}
+	advanceEngine(engine, events)
+	return nil
+}
+
+// advanceEngine 라운드의 수가 모두 모였으면 결과를 처리하고 게임이 끝났는지 확인
+func advanceEngine(engine GameEngine, events engineEvents) {
+	if !engine.RoundComplete() {
+		return
+	}
+
+	result, err := engine.ResolveRound()
+	if err != nil {
+		events.failed(err)
+		return
+	}
+
+	over, winner, reason := engine.Outcome()
+	events.resolved(result, over)
+	if over {
+		events.over(winner, reason)
+	}
+}
+
+// ==================== Nine Dragons ====================
+
+var _ GameEngine = (*Game)(nil)
+
+// Seats 파랑, 빨강 순서
+func (g *Game) Seats() []Seat {
+	return []Seat{Seat(Blue), Seat(Red)}
+}
+
+// AssignSeat 원하는 색이 비어있으면 그 색, 아니면 남은 색 (AI 좌석 포함)
+func (g *Game) AssignSeat(preferred Seat) (Seat, error) {
+	return assignSeat(g.Seats(), preferred, func(seat Seat) bool {
+		color := PlayerColor(seat)
+		return g.Players[color] != nil || g.Bots[color] != nil
+	})
+}
+
+// ApplyMove 타일 플레이 (payload는 PlayTilePayload, 형식이 틀린 값은 타일 검사에서 걸러짐)
+func (g *Game) ApplyMove(seat Seat, move Move) error {
+	var payload PlayTilePayload
+	json.Unmarshal(move.Payload, &payload)
+	return g.playTile(PlayerColor(seat), payload.Tile, move.Auto)
+}
+
+// RoundComplete 두 플레이어가 모두 타일을 냈는지
+func (g *Game) RoundComplete() bool {
+	return g.RoundTiles[Blue] != nil && g.RoundTiles[Red] != nil
+}
+
+// ResolveRound 라운드를 처리하고 RoundResultPayload 반환 (다음 차례 마감 시각은 허브가 채운다)
+func (g *Game) ResolveRound() (interface{}, error) {
+	if !g.RoundComplete() {
+		return nil, errors.New("아직 두 플레이어가 모두 타일을 내지 않았습니다")
+	}
+	round := g.CurrentRound
+	blueTile := *g.RoundTiles[Blue]
+	redTile := *g.RoundTiles[Red]
+	winner, _ := g.ProcessRound()
+	return g.roundResult(round, winner, blueTile, redTile), nil
+}
+
+// Outcome 필요 승수 달성 또는 마지막 라운드 완료로 끝났는지
+func (g *Game) Outcome() (bool, Seat, string) {
+	over, winner := g.IsGameOver()
+	if !over {
+		return false, "", ""
+	}
+	return true, Seat(winner), g.EndReason()
+}
+
+// View 좌석 시점의 GameStatePayload
+func (g *Game) View(seat Seat) interface{} {
+	return g.State(PlayerColor(seat))
+}
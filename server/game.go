@@ -2,49 +2,92 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // NewGame 새 게임 생성
-func NewGame() *Game {
+func NewGame(rules RuleSet) *Game {
 	return &Game{
-		ID:            uuid.New().String(),
-		Players:       make(map[PlayerColor]*Client),
+		Table:         newTable(uuid.New().String()),
 		CurrentRound:  1,
 		BlueWins:      0,
 		RedWins:       0,
 		UsedTiles:     make(map[PlayerColor][]int),
 		RoundTiles:    make(map[PlayerColor]*int),
 		CurrentPlayer: Blue, // 기본 선공
-		Ready:         false,
+		TurnLimit:     defaultTurnLimit,
+		TimeoutPolicy: TimeoutAutoPlay,
+		TileReveal:    RevealBlind,
+		Rules:         rules,
+		RoundWinners:  []PlayerColor{},
+		Moves:         []MoveRecord{},
+		Rounds:        []RoundRecord{},
 	}
 }
 
-// AddPlayer 플레이어 추가
-func (g *Game) AddPlayer(client *Client, color PlayerColor) error {
-	if g.Players[color] != nil {
-		return errors.New("이미 해당 색상의 플레이어가 존재합니다")
+// AddPlayer 플레이어 추가 (원하는 색이 차 있으면 남은 색에 배정)
+func (g *Game) AddPlayer(client *Client, preferred Seat) (Seat, error) {
+	color, err := g.AssignSeat(preferred)
+	if err != nil {
+		return "", err
 	}
 
 	g.Players[color] = client
-	client.Color = color
+	client.Seat = color
+	g.checkReady()
+
+	return color, nil
+}
+
+// AddBot AI 플레이어 추가
+func (g *Game) AddBot(bot *Bot) error {
+	if g.Players[bot.Seat] != nil || g.Bots[bot.Seat] != nil {
+		return errors.New("이미 해당 색상의 플레이어가 존재합니다")
+	}
 
-	// 두 플레이어가 모두 접속하면 게임 시작
-	if len(g.Players) == 2 {
+	g.Bots[bot.Seat] = bot
+	g.checkReady()
+
+	return nil
+}
+
+// checkReady 두 좌석이 모두 차면 게임 시작 준비
+func (g *Game) checkReady() {
+	if len(g.Players)+len(g.Bots) == 2 {
 		g.Ready = true
 		g.UsedTiles[Blue] = []int{}
 		g.UsedTiles[Red] = []int{}
 	}
+}
 
+// Start 게임 시작 (첫 라운드 선공과 시작 시각 기록, 이미 시작한 게임이면 에러)
+func (g *Game) Start() error {
+	if !g.StartedAt.IsZero() {
+		return errors.New("이미 시작한 게임입니다")
+	}
+	g.Ready = true
+	g.FirstPlayer = g.CurrentPlayer
+	g.StartedAt = time.Now()
 	return nil
 }
 
 // PlayTile 타일 플레이
 func (g *Game) PlayTile(color PlayerColor, tile int) error {
+	return g.playTile(color, tile, false)
+}
+
+// AutoPlayTile 시간 초과 등으로 서버가 대신 타일 플레이
+func (g *Game) AutoPlayTile(color PlayerColor, tile int) error {
+	return g.playTile(color, tile, true)
+}
+
+func (g *Game) playTile(color PlayerColor, tile int, auto bool) error {
 	// 유효성 검증
-	if tile < 1 || tile > 9 {
-		return errors.New("타일은 1-9 사이여야 합니다")
+	if tile < g.Rules.MinTile || tile > g.Rules.MaxTile {
+		return fmt.Errorf("타일은 %d-%d 사이여야 합니다", g.Rules.MinTile, g.Rules.MaxTile)
 	}
 
 	// 이미 사용한 타일인지 확인
@@ -60,23 +103,30 @@ func (g *Game) PlayTile(color PlayerColor, tile int) error {
 	}
 
 	// 차례 확인 - 첫 번째 플레이어이거나, 상대방이 이미 타일을 낸 경우
-	opponentColor := Blue
-	if color == Blue {
-		opponentColor = Red
-	}
-
 	// 자신의 차례가 아니고, 상대방도 아직 타일을 내지 않았으면 에러
-	if g.CurrentPlayer != color && g.RoundTiles[opponentColor] == nil {
+	if g.CurrentPlayer != color && g.RoundTiles[opponentColor(color)] == nil {
 		return errors.New("당신의 차례가 아닙니다")
 	}
 
 	// 타일 저장
 	g.RoundTiles[color] = &tile
 	g.UsedTiles[color] = append(g.UsedTiles[color], tile)
+	g.recordMove(color, tile, auto)
 
 	return nil
 }
 
+// recordMove 기보에 한 수 기록
+func (g *Game) recordMove(color PlayerColor, tile int, auto bool) {
+	g.Moves = append(g.Moves, MoveRecord{
+		Round: g.CurrentRound,
+		Color: color,
+		Tile:  tile,
+		At:    time.Now(),
+		Auto:  auto,
+	})
+}
+
 // DetermineWinner 라운드 승자 결정
 func (g *Game) DetermineWinner() PlayerColor {
 	blueTile := *g.RoundTiles[Blue]
@@ -87,11 +137,11 @@ func (g *Game) DetermineWinner() PlayerColor {
 		return ""
 	}
 
-	// 특수 규칙: 1이 9를 이김
-	if blueTile == 1 && redTile == 9 {
+	// 특수 규칙: 작은 타일이 큰 타일을 이기는 조합 (기본: 1이 9를 이김)
+	if g.Rules.IsUpset(blueTile, redTile) {
 		return Blue
 	}
-	if redTile == 1 && blueTile == 9 {
+	if g.Rules.IsUpset(redTile, blueTile) {
 		return Red
 	}
 
@@ -111,7 +161,38 @@ func (g *Game) ProcessRound() (PlayerColor, bool) {
 
 	// 승자 결정
 	winner := g.DetermineWinner()
+	g.awardRound(winner)
+
+	return winner, true
+}
 
+// ForfeitRound 라운드를 포기한 플레이어의 상대에게 승리 부여
+// 포기한 플레이어는 아직 타일을 내지 않았다면 가장 작은 타일을 소모한다
+func (g *Game) ForfeitRound(color PlayerColor) (winner PlayerColor, blueTile, redTile int) {
+	if g.RoundTiles[color] == nil {
+		if tile := g.LowestUnusedTile(color); tile != 0 {
+			g.RoundTiles[color] = &tile
+			g.UsedTiles[color] = append(g.UsedTiles[color], tile)
+			g.recordMove(color, tile, true)
+		}
+	}
+
+	if t := g.RoundTiles[Blue]; t != nil {
+		blueTile = *t
+	}
+	if t := g.RoundTiles[Red]; t != nil {
+		redTile = *t
+	}
+
+	winner = opponentColor(color)
+	g.awardRound(winner)
+	g.Rounds[len(g.Rounds)-1].Forfeit = true
+
+	return winner, blueTile, redTile
+}
+
+// awardRound 승수 반영 후 다음 라운드 준비
+func (g *Game) awardRound(winner PlayerColor) {
 	// 승수 업데이트
 	if winner == Blue {
 		g.BlueWins++
@@ -119,42 +200,87 @@ func (g *Game) ProcessRound() (PlayerColor, bool) {
 		g.RedWins++
 	}
 
-	// 다음 라운드 준비
+	g.RoundWinners = append(g.RoundWinners, winner)
+
+	record := RoundRecord{Round: g.CurrentRound, Winner: winner}
+	if t := g.RoundTiles[Blue]; t != nil {
+		record.BlueTile = *t
+	}
+	if t := g.RoundTiles[Red]; t != nil {
+		record.RedTile = *t
+	}
+	g.Rounds = append(g.Rounds, record)
+
+	// 다음 라운드 준비 (무승부 제안은 제안한 라운드 동안만 유효)
 	g.CurrentRound++
 	g.RoundTiles = make(map[PlayerColor]*int)
+	g.DrawOffer = ""
 
-	// 승자가 다음 선공
+	// 승자가 다음 선공, 무승부면 규칙에 따라 결정
 	if winner != "" {
 		g.CurrentPlayer = winner
+	} else if g.Rules.DrawLeader == DrawLeaderSwap {
+		g.CurrentPlayer = opponentColor(g.CurrentPlayer)
 	}
+}
 
-	return winner, true
+// roundResult 끝난 라운드 결과 (다음 차례 마감 시각은 허브가 채운다)
+func (g *Game) roundResult(round int, winner PlayerColor, blueTile, redTile int) RoundResultPayload {
+	return RoundResultPayload{
+		Round:      round,
+		BlueTile:   blueTile,
+		RedTile:    redTile,
+		Winner:     winner,
+		BlueWins:   g.BlueWins,
+		RedWins:    g.RedWins,
+		NextPlayer: g.CurrentPlayer,
+	}
 }
 
 // IsGameOver 게임 종료 확인
 func (g *Game) IsGameOver() (bool, PlayerColor) {
-	// 5승 먼저 하면 승리
-	if g.BlueWins >= 5 {
+	// 필요 승수를 먼저 채우면 승리
+	if g.BlueWins >= g.Rules.WinsNeeded {
 		return true, Blue
 	}
-	if g.RedWins >= 5 {
+	if g.RedWins >= g.Rules.WinsNeeded {
 		return true, Red
 	}
 
-	// 9라운드 종료
-	if g.CurrentRound > 9 {
+	// 마지막 라운드 종료
+	if g.CurrentRound > g.Rules.MaxRounds {
 		if g.BlueWins > g.RedWins {
 			return true, Blue
 		} else if g.RedWins > g.BlueWins {
 			return true, Red
 		}
-		// 동점인 경우 무승부
-		return true, ""
+		// 동점인 경우 규칙에 따라 처리 (기본: 무승부)
+		return true, g.tieBreakWinner()
 	}
 
 	return false, ""
 }
 
+// EndReason 끝난 게임의 종료 사유 (필요 승수 달성 또는 마지막 라운드 완료)
+func (g *Game) EndReason() string {
+	if g.BlueWins >= g.Rules.WinsNeeded || g.RedWins >= g.Rules.WinsNeeded {
+		return reasonScoreLimit
+	}
+	return reasonRoundsComplete
+}
+
+// tieBreakWinner 동점일 때 규칙에 따른 승자 (무승부면 빈 값)
+func (g *Game) tieBreakWinner() PlayerColor {
+	if g.Rules.TieBreak == TieBreakLastWinner {
+		for i := len(g.RoundWinners) - 1; i >= 0; i-- {
+			if g.RoundWinners[i] != "" {
+				return g.RoundWinners[i]
+			}
+		}
+	}
+	return ""
+}
+
 // GetNextPlayer 다음 플레이어 가져오기
 func (g *Game) GetNextPlayer() PlayerColor {
 	if g.RoundTiles[g.CurrentPlayer] != nil {
@@ -166,3 +292,25 @@ func (g *Game) GetNextPlayer() PlayerColor {
 	}
 	return g.CurrentPlayer
 }
+
+// LowestUnusedTile 아직 사용하지 않은 가장 작은 타일 (없으면 0)
+func (g *Game) LowestUnusedTile(color PlayerColor) int {
+	used := make(map[int]bool)
+	for _, tile := range g.UsedTiles[color] {
+		used[tile] = true
+	}
+	for tile := g.Rules.MinTile; tile <= g.Rules.MaxTile; tile++ {
+		if !used[tile] {
+			return tile
+		}
+	}
+	return 0
+}
+
+// opponentColor 상대 색상 반환
+func opponentColor(color PlayerColor) PlayerColor {
+	if color == Blue {
+		return Red
+	}
+	return Blue
+}
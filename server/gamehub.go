@@ -0,0 +1,559 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// opponentLeft 시작 전이나 두 플레이어 모두 나가 게임을 취소할 때 남은 플레이어에게 보내는 사유
+const opponentLeft = "상대방이 연결을 종료했습니다"
+
+// hubGame 허브가 좌석 단위로 다루는 게임 (규칙은 GameEngine, 좌석 상태는 Table)
+type hubGame interface {
+	GameEngine
+
+	// AddPlayer 원하는 좌석(차 있으면 남은 좌석)에 플레이어를 앉히고 좌석 반환
+	AddPlayer(client *Client, preferred Seat) (Seat, error)
+
+	// AddBot 비어있는 좌석에 AI 추가
+	AddBot(bot *Bot) error
+
+	// OfferDraw 무승부 제안 (상대가 먼저 제안해 둔 상태면 합의로 true)
+	OfferDraw(seat Seat) (bool, error)
+
+	// Start 게임 시작 (이미 시작한 게임이면 에러)
+	Start() error
+
+	table() *Table
+}
+
+// gameSetup 참가 요청이나 방에서 정한 게임 설정
+type gameSetup struct {
+	Seat     Seat        // 원하는 좌석 (없으면 빈 값)
+	RuleSet  string      // 규칙 이름 (같은 규칙끼리 매칭)
+	Key      string      // 규칙 밖에서 같아야 매칭되는 설정 (차례 제한 시간 등, 없으면 빈 값)
+	Settings interface{} // 게임 종류별 설정 (gameHooks.newGame에 전달)
+}
+
+// joinPayload 게임 종류와 상관없는 참가 요청 항목
+type joinPayload struct {
+	PlayerName string   `json:"playerName"`
+	Bot        BotLevel `json:"bot,omitempty"`
+	Casual     bool     `json:"casual,omitempty"`
+	BestOf     int      `json:"bestOf,omitempty"`
+}
+
+// gameHooks 게임 종류마다 다른 설정 해석, 게임 생성, 알림 형식
+// 방, 세션, 재대결, 다전제, 대기열, 다시보기 처리는 gameHub가 맡는다
+type gameHooks[G hubGame] interface {
+	// joinSetup 참가 요청(join_game)의 게임 설정 (프리셋 규칙만 허용)
+	joinSetup(data []byte) (gameSetup, error)
+
+	// roomSetup 방 만들기 요청의 게임 설정 (직접 지정한 규칙 허용)
+	roomSetup(data []byte) (gameSetup, error)
+
+	// configureRoom 방 설정 변경 요청을 settings에 반영한 새 설정
+	configureRoom(settings interface{}, data []byte) (interface{}, error)
+
+	// roomPayload 참가자 시점의 방 상태
+	roomPayload(room *Room, viewer Seat) interface{}
+
+	// newGame 설정으로 새 게임 생성
+	newGame(settings interface{}) G
+
+	// rematchGame 끝난 게임과 같은 설정, 같은 좌석 선공으로 새 게임 생성
+	rematchGame(prev G) G
+
+	// newBot 난이도에 맞는 AI 생성 (좌석은 허브가 정함)
+	newBot(level BotLevel) (*Bot, error)
+
+	// defaultBotLevel 대기열에서 상대를 못 찾았을 때 붙이는 AI 기본 난이도
+	defaultBotLevel() BotLevel
+
+	// playerJoined 좌석에 앉은 플레이어에게 보내는 좌석, 게임, 세션 알림
+	playerJoined(seat Seat, gameID, sessionToken string) interface{}
+
+	// begin 시작한 게임의 첫 타이머를 켜고 두 플레이어에게 시작 알림 (게임 고루틴에서 호출)
+	begin(game G)
+
+	// finish 끝나거나 취소된 게임의 진행을 멈춤 (타이머 중지 등)
+	finish(game G)
+
+	// gameOver 게임 종료 알림
+	gameOver(game G, winner Seat, reason string, ratings map[Seat]*RatingChange) interface{}
+
+	// drawOffer 무승부를 제안하거나 거절한 좌석 알림
+	drawOffer(seat Seat) interface{}
+
+	// opponentStatus 연결이 끊기거나(grace: 기다리는 초) 다시 연결된 좌석 알림
+	opponentStatus(seat Seat, grace int) interface{}
+
+	// rematchNotice 재대결을 제안하거나(expiresAt: 만료 unix ms) 거절한(reason) 좌석 알림
+	rematchNotice(seat Seat, reason string, expiresAt int64) interface{}
+
+	// storeRecord 끝난 게임 기록 저장
+	storeRecord(store *RecordStore, game G, winner Seat, reason string, ratings map[Seat]*RatingChange)
+
+	// loadReplay 저장된 기록을 읽어 다시보기로 재구성
+	loadReplay(store *RecordStore, gameID string) (*Replay, error)
+
+	// handleMessage 공통 메시지가 아닌 게임 진행 메시지 처리
+	handleMessage(client *Client, msg Message)
+}
+
+// gameHub 게임 종류와 상관없는 허브 처리 (연결, 좌석, 방, 세션, 재대결, 다전제, 대기열, 다시보기)
+type gameHub[G hubGame] struct {
+	// 연결 등록/해제와 메시지 전송
+	hubRuntime[*Client, GameMessage]
+
+	// 게임 종류별 처리
+	hooks gameHooks[G]
+
+	// 게임 종류 (레이팅, 다전제 기록)
+	kind GameKind
+
+	// 좌석 목록 (순서대로 배정, 다전제 자리 번호)
+	seats []Seat
+
+	// 메시지 타입 앞에 붙는 게임 종류 접두어 (넘버체인지는 nc_)
+	msgPrefix string
+
+	// 게임 목록
+	games map[string]G
+
+	// 빠른 대전 매칭 대기열
+	queue *Matchmaker
+
+	// 다음 매칭 시도
+	queueTick chan struct{}
+
+	// 다음 매칭 시도가 이미 예약되었는지
+	queueTickScheduled bool
+
+	// 재접속 세션 (토큰 -> 세션)
+	sessions map[string]*Session
+
+	// 연결이 끊긴 플레이어 처리 규칙과 플레이어별 기권패 기록
+	disconnect DisconnectPolicy
+	abandons   *AbandonTracker
+
+	// 비공개 방 (코드 -> 방)
+	rooms map[string]*Room
+
+	// 방 만료
+	roomExpired chan string
+
+	// 대기열에서 AI를 투입하기까지 기다리는 시간 (0이면 사용 안 함)
+	botFallbackWait time.Duration
+
+	// 대기열에 투입할 AI 난이도
+	botFallbackLevel BotLevel
+
+	// 종료된 게임 기록 저장소 (nil이면 저장 안 함)
+	records *RecordStore
+
+	// 레이팅 프로필 저장소 (nil이면 레이팅 사용 안 함)
+	players *PlayerStore
+
+	// 진행 중인 다시보기 (재생 상태 -> 시청 클라이언트)
+	replays map[*Replay]*Client
+
+	// 다시보기 기록 읽기 완료
+	replayLoaded chan replayLoad
+
+	// 다시보기 다음 프레임 전송
+	replayTick chan replayTick
+
+	// 끝난 게임의 재대결 제안 (게임 ID -> 제안)
+	rematches map[string]*Rematch
+
+	// 재대결 제안 만료 (게임 ID)
+	rematchExpired chan string
+
+	// 다전제 다음 게임 시작 (끝난 게임 ID)
+	seriesNext chan string
+}
+
+type GameMessage struct {
+	Client  *Client
+	Message Message
+}
+
+// newGameHub 게임 종류 하나를 맡는 허브 생성 (seats는 좌석 순서, msgPrefix는 메시지 타입 접두어)
+func newGameHub[G hubGame](hooks gameHooks[G], kind GameKind, seats []Seat, msgPrefix, logPrefix string) *gameHub[G] {
+	return &gameHub[G]{
+		hubRuntime: newHubRuntime[*Client, GameMessage](logPrefix),
+		hooks:      hooks,
+		kind:       kind,
+		seats:      seats,
+		msgPrefix:  msgPrefix,
+		games:      make(map[string]G),
+		queue:      NewMatchmaker(string(seats[0]), string(seats[1])),
+		queueTick:  make(chan struct{}),
+
+		sessions:       make(map[string]*Session),
+		disconnect:     DefaultDisconnectPolicy(),
+		abandons:       NewAbandonTracker(),
+		rooms:          make(map[string]*Room),
+		roomExpired:    make(chan string),
+		replays:        make(map[*Replay]*Client),
+		replayLoaded:   make(chan replayLoad),
+		replayTick:     make(chan replayTick),
+		rematches:      make(map[string]*Rematch),
+		rematchExpired: make(chan string),
+		seriesNext:     make(chan string),
+	}
+}
+
+// msg 이 허브의 게임 종류에 맞는 메시지 타입 (공통 메시지 타입에 접두어를 붙임)
+func (h *gameHub[G]) msg(t MessageType) MessageType {
+	return MessageType(h.msgPrefix) + t
+}
+
+// opponent seat의 상대 좌석
+func (h *gameHub[G]) opponent(seat Seat) Seat {
+	if seat == h.seats[0] {
+		return h.seats[1]
+	}
+	return h.seats[0]
+}
+
+// game ID로 찾은 게임 (없으면 false)
+func (h *gameHub[G]) game(gameID string) (G, bool) {
+	game, ok := h.games[gameID]
+	return game, ok
+}
+
+func (h *gameHub[G]) Run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.addClient(client)
+
+		case client := <-h.unregister:
+			if h.removeClient(client) {
+				h.handleDisconnect(client)
+				log.Printf("%sClient unregistered: %s", h.logPrefix, client.ID)
+			}
+
+		case message := <-h.gameMessage:
+			h.handleGameMessage(message)
+
+		case f := <-h.calls:
+			f()
+
+		case code := <-h.roomExpired:
+			h.handleRoomExpiry(code)
+
+		case <-h.queueTick:
+			h.handleQueueTick()
+
+		case load := <-h.replayLoaded:
+			h.handleReplayLoaded(load)
+
+		case tick := <-h.replayTick:
+			h.handleReplayTick(tick)
+
+		case gameID := <-h.rematchExpired:
+			h.handleRematchExpiry(gameID)
+
+		case gameID := <-h.seriesNext:
+			h.handleSeriesNext(gameID)
+		}
+	}
+}
+
+func (h *gameHub[G]) handleDisconnect(client *Client) {
+	h.stopReplay(client)
+	h.queue.Cancel(client.ID)
+	h.leaveRematch(client)
+
+	// 시작 전인 방에서 나가기
+	if client.Room != nil {
+		h.leaveRoom(client)
+		return
+	}
+
+	h.removeSpectator(client)
+
+	if game, ok := h.game(client.GameID); ok {
+		h.onGame(game, func() { h.leaveGame(game, client) })
+	}
+}
+
+// leaveGame 연결이 끊긴 플레이어를 게임에서 내보냄
+func (h *gameHub[G]) leaveGame(game G, client *Client) {
+	t := game.table()
+
+	// 이미 다른 연결이 좌석을 넘겨받았으면 무시
+	if t.Players[client.Seat] != client {
+		return
+	}
+
+	// 진행 중인 게임은 재접속을 기다림
+	if t.Ready {
+		h.suspendPlayer(game, client)
+		return
+	}
+
+	delete(t.Players, client.Seat)
+	h.abortGame(game, opponentLeft)
+}
+
+// onGame 게임 상태를 다루는 task를 게임 고루틴에 넘김 (시작 전 게임은 허브 루프에서 바로 실행)
+func (h *gameHub[G]) onGame(game G, task func()) {
+	actor := game.table().actor
+	if actor == nil {
+		task()
+		return
+	}
+	actor.post(task)
+}
+
+// onHub 게임 쪽에서 허브 상태를 다루는 f를 허브 루프에서 실행 (시작 전 게임은 이미 허브 루프에 있음)
+func (h *gameHub[G]) onHub(game G, f func()) {
+	if game.table().actor == nil {
+		f()
+		return
+	}
+	h.call(f)
+}
+
+// routeToGame client가 참가 중인 게임의 고루틴에서 handle 실행 (게임이 없으면 missing 에러 전송)
+func (h *gameHub[G]) routeToGame(client *Client, missing string, handle func(game G)) {
+	game, ok := h.game(client.GameID)
+	if !ok {
+		h.sendError(client, missing)
+		return
+	}
+	h.onGame(game, func() { handle(game) })
+}
+
+// abortGame 남은 플레이어에게 취소 사유(message)를 알리고 게임 삭제
+func (h *gameHub[G]) abortGame(game G, message string) {
+	t := game.table()
+	h.hooks.finish(game)
+	if t.Series.bestOf() {
+		h.endSeries(game, "", seriesAborted)
+	}
+
+	// 남은 플레이어에게 알림
+	for seat, player := range t.Players {
+		h.sendError(player, message)
+		// 상대방도 게임에서 제거
+		delete(t.Players, seat)
+	}
+
+	h.broadcastToSpectators(game, Message{
+		Type: h.msg(MsgError),
+		Payload: ErrorPayload{
+			Message: "게임이 종료되었습니다",
+		},
+	})
+
+	h.saveRecord(game, "", recordReasonAborted, nil)
+	h.onHub(game, func() { h.closeGame(game) })
+}
+
+// closeGame 게임과 관련 세션, 관전자, 게임 고루틴 정리 (허브 루프에서 호출)
+func (h *gameHub[G]) closeGame(game G) {
+	t := game.table()
+	for spectator := range t.Spectators {
+		if spectator.Spectating == t.ID {
+			spectator.Spectating = ""
+		}
+	}
+
+	for _, session := range t.Sessions {
+		delete(h.sessions, session.Token)
+	}
+
+	// 게임 삭제
+	delete(h.games, t.ID)
+	if t.actor != nil {
+		t.actor.stop()
+	}
+}
+
+func (h *gameHub[G]) handleGameMessage(gm GameMessage) {
+	client, msg := gm.Client, gm.Message
+	switch msg.Type {
+	case h.msg(MsgJoinGame):
+		h.handleJoinGame(client, msg)
+	case h.msg(MsgResume):
+		h.handleResume(client, msg)
+	case h.msg(MsgCreateRoom):
+		h.handleCreateRoom(client, msg)
+	case h.msg(MsgJoinRoom):
+		h.handleJoinRoom(client, msg)
+	case h.msg(MsgStartRoom):
+		h.handleStartRoom(client)
+	case h.msg(MsgCancelRoom):
+		h.handleCancelRoom(client)
+	case h.msg(MsgConfigureRoom):
+		h.handleConfigureRoom(client, msg)
+	case h.msg(MsgSpectate):
+		h.handleSpectate(client, msg)
+	case h.msg(MsgStopSpectating):
+		h.handleStopSpectating(client)
+	case h.msg(MsgReplay):
+		h.handleReplay(client, msg)
+	case h.msg(MsgReplayControl):
+		h.handleReplayControl(client, msg)
+	case h.msg(MsgStopReplay):
+		h.stopReplay(client)
+	case h.msg(MsgIdentify):
+		h.handleIdentify(client, msg)
+	case h.msg(MsgCancelQueue):
+		h.handleCancelQueue(client)
+	case h.msg(MsgRematchOffer):
+		h.handleRematchOffer(client)
+	case h.msg(MsgRematchAccept):
+		h.handleRematchAccept(client)
+	case h.msg(MsgRematchDecline):
+		h.handleRematchDecline(client)
+	case h.msg(MsgResign):
+		h.routeToGame(client, noGameInProgress, func(game G) { h.handleResign(game, client) })
+	case h.msg(MsgOfferDraw):
+		h.routeToGame(client, noGameInProgress, func(game G) { h.handleOfferDraw(game, client) })
+	case h.msg(MsgAcceptDraw):
+		h.routeToGame(client, noGameInProgress, func(game G) { h.handleAcceptDraw(game, client) })
+	case h.msg(MsgDeclineDraw):
+		h.routeToGame(client, noGameInProgress, func(game G) { h.handleDeclineDraw(game, client) })
+	default:
+		h.hooks.handleMessage(client, msg)
+	}
+}
+
+func (h *gameHub[G]) handleJoinGame(client *Client, msg Message) {
+	data, _ := json.Marshal(msg.Payload)
+	var payload joinPayload
+	json.Unmarshal(data, &payload)
+
+	log.Printf("%sPlayer %s (%s) joining", h.logPrefix, client.ID, payload.PlayerName)
+
+	if _, playing := h.game(client.GameID); client.Room != nil || playing {
+		h.sendError(client, "이미 다른 게임에 참가 중입니다")
+		return
+	}
+	if h.queue.Contains(client.ID) {
+		h.sendError(client, ErrAlreadyQueued.Error())
+		return
+	}
+	h.leaveRematch(client)
+
+	// 플레이어 이름 저장
+	client.Name = payload.PlayerName
+
+	// AI 대전 요청
+	if payload.Bot != "" {
+		setup, err := h.hooks.joinSetup(data)
+		if err != nil {
+			h.sendError(client, err.Error())
+			return
+		}
+		h.handleBotGame(client, payload.Bot, setup)
+		return
+	}
+
+	// 레이팅이 비슷한 상대를 찾을 때까지 대기열에서 기다림
+	h.enqueue(client, payload, data)
+}
+
+// addGame 새 게임을 목록에 추가
+func (h *gameHub[G]) addGame(game G) {
+	h.games[game.table().ID] = game
+}
+
+// seatPlayer 플레이어를 원하는 좌석(차 있으면 남은 좌석)에 앉히고 세션 발급
+func (h *gameHub[G]) seatPlayer(game G, client *Client, preferred Seat) (Seat, error) {
+	seat, err := game.AddPlayer(client, preferred)
+	if err != nil {
+		return "", err
+	}
+	h.removeSpectator(client)
+	h.stopReplay(client)
+	client.GameID = game.table().ID
+
+	log.Printf("%sPlayer %s (%s) joined as %s. Total players: %d", h.logPrefix, client.ID, client.Name, seat, len(game.table().Players))
+
+	session := h.newSession(game, client)
+
+	// 플레이어에게 자신의 좌석 알림
+	h.sendToClient(client, Message{
+		Type:    h.msg(MsgPlayerJoined),
+		Payload: h.hooks.playerJoined(seat, session.GameID, session.Token),
+	})
+
+	return seat, nil
+}
+
+// seatPlayers 좌석별 플레이어를 앉힘 (한 명이라도 앉지 못하면 로그를 남기고 게임을 취소한 뒤 false)
+func (h *gameHub[G]) seatPlayers(game G, players map[Seat]*Client) bool {
+	for _, seat := range h.seats {
+		if _, err := h.seatPlayer(game, players[seat], seat); err != nil {
+			log.Printf("%sError seating %s in game %s: %v", h.logPrefix, players[seat].ID, game.table().ID, err)
+			h.abortGame(game, opponentLeft)
+			return false
+		}
+	}
+	return true
+}
+
+// startGame 두 플레이어에게 게임 시작 알림 후 첫 라운드 시작
+func (h *gameHub[G]) startGame(game G) {
+	t := game.table()
+	if err := game.Start(); err != nil {
+		log.Printf("%sError starting game %s: %v", h.logPrefix, t.ID, err)
+		return
+	}
+	log.Printf("%sGame %s is ready! Starting game with %d players", h.logPrefix, t.ID, len(t.Players))
+	t.Rated = h.isRatedGame(game)
+
+	// 이제부터 게임 상태는 게임 고루틴이 맡음
+	t.actor = startGameActor()
+	t.actor.post(func() { h.hooks.begin(game) })
+}
+
+// endGame 게임 종료 알림 후 정리 (재대결, 다전제, 게임 삭제는 허브 루프에서 처리)
+func (h *gameHub[G]) endGame(game G, winner Seat, reason string) {
+	t := game.table()
+	h.hooks.finish(game)
+	ratings := h.rateGame(game, winner)
+	t.recordSeries(winner)
+	h.broadcastPublic(game, Message{
+		Type:    h.msg(MsgGameOver),
+		Payload: h.hooks.gameOver(game, winner, reason, ratings),
+	})
+
+	h.saveRecord(game, winner, reason, ratings)
+	h.onHub(game, func() {
+		if t.Series.bestOf() {
+			h.advanceSeries(game, winner)
+		} else {
+			h.openRematch(game, winner)
+		}
+
+		// 게임 종료 처리
+		h.closeGame(game)
+	})
+	log.Printf("%sGame %s ended. Winner: %s, Reason: %s", h.logPrefix, t.ID, winner, reason)
+}
+
+func (h *gameHub[G]) sendToClient(client *Client, message Message) {
+	h.sendJSON(client, message)
+}
+
+func (h *gameHub[G]) sendError(client *Client, message string) {
+	h.sendToClient(client, Message{
+		Type: h.msg(MsgError),
+		Payload: ErrorPayload{
+			Message: message,
+		},
+	})
+}
+
+func (h *gameHub[G]) broadcastToGame(game G, message Message) {
+	sendSeats(&h.hubRuntime, game.table().Players, message)
+}
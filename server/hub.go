@@ -2,340 +2,327 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"time"
 )
 
+// Hub 구룡투 허브 (공통 처리는 gameHub, 여기에는 구룡투 설정과 알림 형식만 둔다)
 type Hub struct {
-	// 등록된 클라이언트
-	clients map[*Client]bool
-
-	// 게임 목록
-	games map[string]*Game
+	*gameHub[*Game]
+}
 
-	// 대기 중인 게임
-	waitingGame *Game
+// gameSettings 대기열 참가 요청이나 방에서 정한 구룡투 게임 설정
+type gameSettings struct {
+	Rules         RuleSet
+	TurnSeconds   int
+	TimeoutPolicy TimeoutPolicy
+	TileReveal    TileReveal
+}
 
-	// 클라이언트로부터 받은 메시지
-	broadcast chan []byte
+func NewHub() *Hub {
+	h := &Hub{}
+	h.gameHub = newGameHub[*Game](h, GameNineDragons, []Seat{Blue, Red}, "", "")
+	return h
+}
 
-	// 클라이언트 등록
-	register chan *Client
+// ==================== Game Hooks ====================
 
-	// 클라이언트 등록 해제
-	unregister chan *Client
+// joinSetup 참가 요청의 프리셋 규칙과 차례 설정 (차례 설정과 공개 범위가 같은 플레이어끼리만 매칭)
+func (h *Hub) joinSetup(data []byte) (gameSetup, error) {
+	var payload JoinGamePayload
+	json.Unmarshal(data, &payload)
 
-	// 게임 메시지
-	gameMessage chan GameMessage
+	rules, err := RuleSetByName(payload.RuleSet)
+	if err != nil {
+		return gameSetup{}, err
+	}
+	settings := gameSettings{Rules: rules, TileReveal: tileRevealOrDefault(payload.TileReveal)}
+	settings.TurnSeconds, settings.TimeoutPolicy = turnSettings(payload.TurnSeconds, payload.TimeoutPolicy)
+
+	return gameSetup{
+		Seat:     payload.Color,
+		RuleSet:  rules.Name,
+		Key:      fmt.Sprintf("%ds/%s/%s", settings.TurnSeconds, settings.TimeoutPolicy, settings.TileReveal),
+		Settings: settings,
+	}, nil
 }
 
-type GameMessage struct {
-	Client  *Client
-	Message Message
-}
+// roomSetup 방 만들기 요청의 규칙과 차례 설정 (직접 지정한 규칙 허용)
+func (h *Hub) roomSetup(data []byte) (gameSetup, error) {
+	var payload CreateRoomPayload
+	json.Unmarshal(data, &payload)
 
-func NewHub() *Hub {
-	return &Hub{
-		broadcast:   make(chan []byte),
-		register:    make(chan *Client),
-		unregister:  make(chan *Client),
-		clients:     make(map[*Client]bool),
-		games:       make(map[string]*Game),
-		gameMessage: make(chan GameMessage),
+	rules, err := resolveRuleSet(payload.RuleSet, payload.Rules)
+	if err != nil {
+		return gameSetup{}, err
 	}
-}
+	settings := gameSettings{Rules: rules, TileReveal: tileRevealOrDefault(payload.TileReveal)}
+	settings.TurnSeconds, settings.TimeoutPolicy = turnSettings(payload.TurnSeconds, payload.TimeoutPolicy)
 
-func (h *Hub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.clients[client] = true
-			log.Printf("Client registered: %s", client.ID)
-
-		case client := <-h.unregister:
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.Send)
-				h.handleDisconnect(client)
-				log.Printf("Client unregistered: %s", client.ID)
-			}
-
-		case message := <-h.gameMessage:
-			h.handleGameMessage(message)
-		}
-	}
+	return gameSetup{Seat: payload.Color, RuleSet: rules.Name, Settings: settings}, nil
 }
 
-func (h *Hub) handleDisconnect(client *Client) {
-	if client.GameID != "" {
-		game := h.games[client.GameID]
-		if game != nil {
-			// 상대방에게 알림
-			for color, player := range game.Players {
-				if player != nil && player.ID != client.ID {
-					h.sendToClient(player, Message{
-						Type: MsgError,
-						Payload: ErrorPayload{
-							Message: "상대방이 연결을 종료했습니다",
-						},
-					})
-					// 상대방도 게임에서 제거
-					delete(game.Players, color)
-				}
-			}
-			// 게임 삭제
-			delete(h.games, client.GameID)
-
-			// 대기 중인 게임이었다면 초기화
-			if h.waitingGame != nil && h.waitingGame.ID == client.GameID {
-				h.waitingGame = nil
-			}
+// configureRoom 방 설정 변경 요청의 규칙, 차례 설정, 공개 범위 반영
+func (h *Hub) configureRoom(current interface{}, data []byte) (interface{}, error) {
+	var payload ConfigureRoomPayload
+	json.Unmarshal(data, &payload)
+
+	settings := current.(gameSettings)
+	if payload.RuleSet != "" || payload.Rules != nil {
+		rules, err := resolveRuleSet(payload.RuleSet, payload.Rules)
+		if err != nil {
+			return nil, err
 		}
+		settings.Rules = rules
+	}
+	if payload.TurnSeconds != 0 {
+		settings.TurnSeconds, _ = turnSettings(payload.TurnSeconds, settings.TimeoutPolicy)
+	}
+	if payload.TimeoutPolicy != "" {
+		_, settings.TimeoutPolicy = turnSettings(settings.TurnSeconds, payload.TimeoutPolicy)
+	}
+	if payload.TileReveal != "" {
+		settings.TileReveal = tileRevealOrDefault(payload.TileReveal)
 	}
+	return settings, nil
 }
 
-func (h *Hub) handleGameMessage(gm GameMessage) {
-	switch gm.Message.Type {
-	case MsgJoinGame:
-		h.handleJoinGame(gm.Client, gm.Message)
-	case MsgPlayTile:
-		h.handlePlayTile(gm.Client, gm.Message)
+// roomPayload 참가자 시점의 방 상태
+func (h *Hub) roomPayload(room *Room, viewer Seat) interface{} {
+	settings := room.Settings.(gameSettings)
+	owner, names := room.seatNames()
+	return RoomPayload{
+		Code:          room.Code,
+		YourColor:     viewer,
+		OwnerColor:    owner,
+		BlueName:      names[Blue],
+		RedName:       names[Red],
+		HasPassword:   room.Password != "",
+		TurnSeconds:   settings.TurnSeconds,
+		TimeoutPolicy: settings.TimeoutPolicy,
+		TileReveal:    settings.TileReveal,
+		Rules:         settings.Rules,
+		BestOf:        room.BestOf,
+		ExpiresAt:     room.ExpiresAt.UnixMilli(),
 	}
 }
 
-func (h *Hub) handleJoinGame(client *Client, msg Message) {
-	payloadBytes, _ := json.Marshal(msg.Payload)
-	var payload JoinGamePayload
-	json.Unmarshal(payloadBytes, &payload)
-
-	log.Printf("Player %s (%s) joining with color preference: %s", client.ID, payload.PlayerName, payload.Color)
-
-	// 플레이어 이름 저장
-	client.Name = payload.PlayerName
-
-	var game *Game
+// newGame 설정으로 새 게임 생성
+func (h *Hub) newGame(settings interface{}) *Game {
+	s := settings.(gameSettings)
+	game := NewGame(s.Rules)
+	configureTurnTimer(game, s.TurnSeconds, s.TimeoutPolicy)
+	game.TileReveal = s.TileReveal
+	return game
+}
 
-	// 대기 중인 게임이 있으면 참가, 없으면 새로 생성
-	if h.waitingGame == nil {
-		game = NewGame()
-		h.waitingGame = game
-		h.games[game.ID] = game
-		log.Printf("Created new game %s", game.ID)
-	} else {
-		game = h.waitingGame
-		h.waitingGame = nil // 게임이 가득 찼으므로 대기 게임 초기화
-		log.Printf("Joining existing game %s", game.ID)
-	}
+// rematchGame 끝난 게임과 같은 규칙, 차례 설정, 공개 범위로 새 게임 생성 (선공 색은 그대로)
+func (h *Hub) rematchGame(prev *Game) *Game {
+	game := NewGame(prev.Rules)
+	game.TurnLimit = prev.TurnLimit
+	game.TimeoutPolicy = prev.TimeoutPolicy
+	game.TileReveal = prev.TileReveal
+	game.CurrentPlayer = prev.FirstPlayer
+	return game
+}
 
-	client.GameID = game.ID
+func (h *Hub) newBot(level BotLevel) (*Bot, error) {
+	return NewBot("", level)
+}
 
-	// 플레이어 색상 결정
-	var color PlayerColor
+func (h *Hub) defaultBotLevel() BotLevel {
+	return defaultBotFallbackLevel
+}
 
-	// 첫 번째 플레이어인 경우
-	if len(game.Players) == 0 {
-		// 색상을 선택했으면 그 색상, 아니면 파랑
-		if payload.Color == Blue || payload.Color == Red {
-			color = payload.Color
-		} else {
-			color = Blue
-		}
-	} else {
-		// 두 번째 플레이어인 경우 남은 색상 자동 배정
-		if game.Players[Blue] == nil {
-			color = Blue
-		} else if game.Players[Red] == nil {
-			color = Red
-		} else {
-			h.sendToClient(client, Message{
-				Type: MsgError,
-				Payload: ErrorPayload{
-					Message: "게임이 가득 찼습니다",
-				},
-			})
-			return
-		}
+func (h *Hub) playerJoined(seat Seat, gameID, sessionToken string) interface{} {
+	return map[string]interface{}{
+		"yourColor":    seat,
+		"gameId":       gameID,
+		"sessionToken": sessionToken,
 	}
+}
 
-	// 플레이어 추가
-	if err := game.AddPlayer(client, color); err != nil {
-		log.Printf("Error adding player: %v", err)
-		h.sendToClient(client, Message{
-			Type: MsgError,
-			Payload: ErrorPayload{
-				Message: err.Error(),
+// begin 첫 차례 타이머를 켜고 두 플레이어에게 게임 시작 알림
+func (h *Hub) begin(game *Game) {
+	h.startTurnTimer(game)
+
+	for playerColor, player := range game.Players {
+		h.sendToClient(player, Message{
+			Type: MsgGameStart,
+			Payload: GameStartPayload{
+				FirstPlayer:   game.CurrentPlayer,
+				YourColor:     playerColor,
+				BlueName:      game.PlayerName(Blue),
+				RedName:       game.PlayerName(Red),
+				TurnSeconds:   int(game.TurnLimit / time.Second),
+				TimeoutPolicy: game.TimeoutPolicy,
+				TileReveal:    game.TileReveal,
+				Rules:         game.Rules,
+				Deadline:      deadlineMillis(game.TurnDeadline),
+				Rated:         game.Rated,
+				Series:        game.seriesScore(),
 			},
 		})
-		return
 	}
+}
 
-	log.Printf("Player %s joined as %s. Total players: %d", client.ID, color, len(game.Players))
+func (h *Hub) finish(game *Game) {
+	h.stopTurnTimer(game)
+}
 
-	// 플레이어에게 자신의 색상 알림
-	h.sendToClient(client, Message{
-		Type: MsgPlayerJoined,
-		Payload: map[string]interface{}{
-			"yourColor": color,
-			"gameId":    game.ID,
-		},
-	})
+func (h *Hub) gameOver(game *Game, winner Seat, reason string, ratings map[Seat]*RatingChange) interface{} {
+	return GameOverPayload{
+		Winner:   winner,
+		BlueWins: game.BlueWins,
+		RedWins:  game.RedWins,
+		Reason:   reason,
+		Ratings:  ratings,
+		Series:   game.seriesScore(),
+	}
+}
 
-	// 게임 시작 확인
-	if game.Ready {
-		log.Printf("Game %s is ready! Starting game with %d players", game.ID, len(game.Players))
+func (h *Hub) drawOffer(seat Seat) interface{} {
+	return DrawOfferPayload{Color: seat}
+}
 
-		// 플레이어 이름 가져오기
-		blueName := ""
-		redName := ""
-		if p := game.Players[Blue]; p != nil {
-			blueName = p.Name
-		}
-		if p := game.Players[Red]; p != nil {
-			redName = p.Name
-		}
+func (h *Hub) opponentStatus(seat Seat, grace int) interface{} {
+	return OpponentStatusPayload{Color: seat, GraceSeconds: grace}
+}
 
-		// 두 플레이어 모두에게 게임 시작 알림
-		for playerColor, player := range game.Players {
-			h.sendToClient(player, Message{
-				Type: MsgGameStart,
-				Payload: GameStartPayload{
-					FirstPlayer: game.CurrentPlayer,
-					YourColor:   playerColor,
-					BlueName:    blueName,
-					RedName:     redName,
-				},
-			})
-		}
-	} else {
-		log.Printf("Game %s waiting for more players. Current: %d", game.ID, len(game.Players))
-		// 대기 중 메시지
-		h.sendToClient(client, Message{
-			Type: MsgWaitingPlayer,
-			Payload: map[string]string{
-				"message": "상대방을 기다리는 중...",
-			},
-		})
+func (h *Hub) rematchNotice(seat Seat, reason string, expiresAt int64) interface{} {
+	return RematchPayload{Color: seat, Reason: reason, ExpiresAt: expiresAt}
+}
+
+func (h *Hub) storeRecord(store *RecordStore, game *Game, winner Seat, reason string, ratings map[Seat]*RatingChange) {
+	store.SaveGame(newGameRecord(game, winner, reason, ratings))
+}
+
+func (h *Hub) loadReplay(store *RecordStore, gameID string) (*Replay, error) {
+	record, err := store.LoadGame(gameID)
+	if err != nil {
+		return nil, err
 	}
+	return h.buildReplay(record), nil
 }
 
-func (h *Hub) handlePlayTile(client *Client, msg Message) {
-	game := h.games[client.GameID]
-	if game == nil {
-		h.sendToClient(client, Message{
-			Type: MsgError,
-			Payload: ErrorPayload{
-				Message: "게임을 찾을 수 없습니다",
-			},
-		})
-		return
+func (h *Hub) handleMessage(client *Client, msg Message) {
+	switch msg.Type {
+	case MsgPlayTile:
+		h.routeToGame(client, "게임을 찾을 수 없습니다", func(game *Game) { h.handlePlayTile(game, client, msg) })
 	}
+}
 
+// ==================== Game Progress ====================
+
+func (h *Hub) handlePlayTile(game *Game, client *Client, msg Message) {
 	payloadBytes, _ := json.Marshal(msg.Payload)
-	var payload PlayTilePayload
-	json.Unmarshal(payloadBytes, &payload)
 
 	// 타일 플레이
-	if err := game.PlayTile(client.Color, payload.Tile); err != nil {
-		h.sendToClient(client, Message{
-			Type: MsgError,
-			Payload: ErrorPayload{
-				Message: err.Error(),
-			},
-		})
+	move := Move{Kind: string(MsgPlayTile), Payload: payloadBytes}
+	if err := playMove(game, client.Seat, move, h.roundEvents(game)); err != nil {
+		h.sendError(client, err.Error())
 		return
 	}
+}
 
-	log.Printf("Player %s (%s) played tile %d", client.ID, client.Color, payload.Tile)
-
-	// 다음 플레이어 결정
-	nextPlayer := game.GetNextPlayer()
+// playTile 서버가 정한 타일을 내고 두 플레이어가 모두 냈으면 라운드 처리 (auto: 시간 초과로 대신 낸 타일)
+func (h *Hub) playTile(game *Game, color PlayerColor, tile int, auto bool) error {
+	payload, _ := json.Marshal(PlayTilePayload{Tile: tile})
+	move := Move{Kind: string(MsgPlayTile), Payload: payload, Auto: auto}
+	return playMove(game, color, move, h.roundEvents(game))
+}
 
-	// 모든 플레이어에게 타일이 플레이되었음을 알림
-	h.broadcastToGame(game, Message{
-		Type: MsgTilePlayed,
-		Payload: TilePlayedPayload{
-			Color:          client.Color,
-			Tile:           payload.Tile,
-			Round:          game.CurrentRound,
-			NextPlayer:     nextPlayer,
-			WaitingFor:     nextPlayer,
-			BlueTilePlayed: game.RoundTiles[Blue] != nil,
-			RedTilePlayed:  game.RoundTiles[Red] != nil,
+// roundEvents 구룡투 엔진 진행을 플레이어와 관전자에게 알리는 방법
+func (h *Hub) roundEvents(game *Game) engineEvents {
+	return engineEvents{
+		moved: func(seat Seat, move Move) {
+			h.announceTile(game, seat)
 		},
-	})
+		resolved: func(result interface{}, over bool) {
+			h.announceRound(game, result.(RoundResultPayload), over)
+		},
+		over: func(winner Seat, reason string) {
+			h.endGame(game, winner, reason)
+		},
+		failed: func(err error) {
+			log.Printf("Error resolving round in game %s: %v", game.ID, err)
+		},
+	}
+}
 
-	// 라운드 처리
-	log.Printf("Checking if round is complete. Blue tile: %v, Red tile: %v", game.RoundTiles[Blue], game.RoundTiles[Red])
-
-	// ProcessRound를 호출하기 전에 현재 라운드 정보 저장
-	completedRound := game.CurrentRound
-	winner, complete := game.ProcessRound()
-	log.Printf("Round complete: %v, Winner: %s, Completed round: %d, New current round: %d", complete, winner, completedRound, game.CurrentRound)
-
-	if complete {
-		// 라운드 결과 전송
-		result := RoundResultPayload{
-			Round:      completedRound,
-			BlueTile:   0,
-			RedTile:    0,
-			Winner:     winner,
-			BlueWins:   game.BlueWins,
-			RedWins:    game.RedWins,
-			NextPlayer: game.CurrentPlayer,
-		}
+// announceTile color가 이번 라운드에 낸 타일을 알리고 다음 차례 타이머 시작
+func (h *Hub) announceTile(game *Game, color PlayerColor) {
+	tile := *game.RoundTiles[color]
+	log.Printf("Player %s played tile %d in game %s", color, tile, game.ID)
 
-		// 타일 값 설정 (이전 라운드 타일)
-		if len(game.UsedTiles[Blue]) > 0 {
-			result.BlueTile = game.UsedTiles[Blue][len(game.UsedTiles[Blue])-1]
-		}
-		if len(game.UsedTiles[Red]) > 0 {
-			result.RedTile = game.UsedTiles[Red][len(game.UsedTiles[Red])-1]
-		}
+	// 다음 플레이어 결정
+	nextPlayer := game.GetNextPlayer()
+	roundDone := game.RoundComplete()
 
-		log.Printf("Broadcasting round_result: Round %d, Blue: %d, Red: %d, Winner: %s, Next player: %s",
-			result.Round, result.BlueTile, result.RedTile, result.Winner, result.NextPlayer)
+	// 상대방 차례 타이머 시작 (라운드가 끝났으면 결과 처리 후 다시 시작)
+	if roundDone {
+		h.stopTurnTimer(game)
+	} else {
+		h.startTurnTimer(game)
+	}
 
-		h.broadcastToGame(game, Message{
-			Type:    MsgRoundResult,
-			Payload: result,
-		})
+	// 모든 플레이어에게 타일이 플레이되었음을 알림
+	played := TilePlayedPayload{
+		Color:          color,
+		Tile:           tile,
+		TileColor:      TileBand(tile),
+		Round:          game.CurrentRound,
+		NextPlayer:     nextPlayer,
+		WaitingFor:     nextPlayer,
+		BlueTilePlayed: game.RoundTiles[Blue] != nil,
+		RedTilePlayed:  game.RoundTiles[Red] != nil,
+		Deadline:       deadlineMillis(game.TurnDeadline),
+	}
 
-		// 게임 종료 확인
-		isOver, finalWinner := game.IsGameOver()
-		if isOver {
-			h.broadcastToGame(game, Message{
-				Type: MsgGameOver,
-				Payload: GameOverPayload{
-					Winner:   finalWinner,
-					BlueWins: game.BlueWins,
-					RedWins:  game.RedWins,
-				},
-			})
-
-			// 게임 종료 처리
-			delete(h.games, game.ID)
+	// 라운드가 끝나기 전에는 상대와 관전자에게 공개 범위만큼만 알림
+	for playerColor, player := range game.Players {
+		view := played
+		if playerColor != color && !roundDone {
+			view = concealTile(played, game.TileReveal)
 		}
+		h.sendToClient(player, Message{
+			Type:    MsgTilePlayed,
+			Payload: view,
+		})
 	}
-}
 
-func (h *Hub) sendToClient(client *Client, message Message) {
-	data, err := json.Marshal(message)
-	if err != nil {
-		log.Printf("Error marshaling message: %v", err)
-		return
+	spectatorView := played
+	if !roundDone {
+		spectatorView = concealTile(played, spectatorReveal(game.TileReveal))
 	}
+	h.broadcastToSpectators(game, Message{
+		Type:    MsgTilePlayed,
+		Payload: spectatorView,
+	})
+}
 
-	select {
-	case client.Send <- data:
-	default:
-		close(client.Send)
-		delete(h.clients, client)
+// finishRound 엔진 밖에서 끝낸 라운드(시간 초과 몰수)의 결과 전송 및 게임 종료 확인
+func (h *Hub) finishRound(game *Game, round int, winner PlayerColor, blueTile, redTile int) {
+	over, finalWinner, reason := game.Outcome()
+	h.announceRound(game, game.roundResult(round, winner, blueTile, redTile), over)
+	if over {
+		h.endGame(game, finalWinner, reason)
 	}
 }
 
-func (h *Hub) broadcastToGame(game *Game, message Message) {
-	for _, player := range game.Players {
-		if player != nil {
-			h.sendToClient(player, message)
-		}
+// announceRound 라운드 결과 전송 (게임이 이어지면 다음 라운드 타이머 시작)
+func (h *Hub) announceRound(game *Game, result RoundResultPayload, over bool) {
+	if !over {
+		h.startTurnTimer(game)
 	}
+	result.Deadline = deadlineMillis(game.TurnDeadline)
+
+	log.Printf("Broadcasting round_result: Round %d, Blue: %d, Red: %d, Winner: %s, Next player: %s",
+		result.Round, result.BlueTile, result.RedTile, result.Winner, result.NextPlayer)
+
+	h.broadcastPublic(game, Message{
+		Type:    MsgRoundResult,
+		Payload: result,
+	})
 }
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testGame 두 플레이어가 앉아 시작한 구룡투 게임 (허브 루프 없이 처리 함수를 바로 호출)
+func testGame(t *testing.T) (*Hub, *Game, *Client, *Client) {
+	t.Helper()
+	h := NewHub()
+	rules, _ := RuleSetByName(RuleSetClassic)
+	game := NewGame(rules)
+
+	blue := testClient("blue", 64)
+	red := testClient("red", 64)
+	for color, c := range map[PlayerColor]*Client{Blue: blue, Red: red} {
+		h.addClient(c)
+		c.GameID = game.ID
+		if _, err := game.AddPlayer(c, color); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.games[game.ID] = game
+	game.Start()
+	return h, game, blue, red
+}
+
+// sentND 클라이언트가 받은 메시지 (payload는 원본 그대로)
+type sentND struct {
+	Type    MessageType     `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// sent 지금까지 client에게 보낸 메시지
+func sent(t *testing.T, client *Client) []sentND {
+	t.Helper()
+	var msgs []sentND
+	for {
+		select {
+		case data := <-client.Send:
+			var msg sentND
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("unmarshal %s: %v", data, err)
+			}
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+// lastError client가 마지막으로 받은 에러 메시지 (없으면 빈 값)
+func lastError(t *testing.T, client *Client) string {
+	t.Helper()
+	message := ""
+	for _, msg := range sent(t, client) {
+		if msg.Type == MsgError {
+			var payload ErrorPayload
+			json.Unmarshal(msg.Payload, &payload)
+			message = payload.Message
+		}
+	}
+	return message
+}
+
+// 다른 게임을 관전하거나 다시보기를 보던 연결이 좌석으로 돌아오면 둘 다 그만둔다
+func TestResumeStopsSpectatingAndReplay(t *testing.T) {
+	h, game, _, _ := testGame(t)
+	session := h.newSession(game, game.Players[Blue])
+	delete(game.Players, Blue)
+	session.Disconnected = true
+
+	rules, _ := RuleSetByName(RuleSetClassic)
+	watched := NewGame(rules)
+	h.games[watched.ID] = watched
+
+	client := testClient("returning", 64)
+	h.addClient(client)
+	client.Spectating = watched.ID
+	watched.Spectators[client] = true
+	client.Replay = newReplay("old")
+	h.replays[client.Replay] = client
+	replay := client.Replay
+
+	h.handleResume(client, Message{Type: MsgResume, Payload: ResumePayload{SessionToken: session.Token}})
+
+	if client.Spectating != "" || watched.Spectators[client] {
+		t.Error("resumed client is still spectating the other game")
+	}
+	if client.Replay != nil || h.replays[replay] != nil {
+		t.Error("resumed client is still watching a replay")
+	}
+	if game.Players[Blue] != client {
+		t.Error("resumed client did not take the seat")
+	}
+}
+
+// 재접속한 플레이어는 레이팅 프로필을 그대로 이어받아 다음 게임도 레이팅에 반영된다
+func TestResumeKeepsPlayerID(t *testing.T) {
+	h, game, blue, red := testGame(t)
+	h.players = testPlayerStore()
+	alice, _ := h.players.Identify("", "alice")
+	bob, _ := h.players.Identify("", "bob")
+	blue.PlayerID, red.PlayerID = alice.ID, bob.ID
+	session := h.newSession(game, blue)
+	h.newSession(game, red)
+	delete(game.Players, Blue)
+	session.Disconnected = true
+
+	client := testClient("returning", 64)
+	h.addClient(client)
+	h.handleResume(client, Message{Type: MsgResume, Payload: ResumePayload{SessionToken: session.Token}})
+	if client.PlayerID != alice.ID {
+		t.Fatalf("resumed PlayerID = %q, want %q", client.PlayerID, alice.ID)
+	}
+
+	// 재접속한 연결로 재대결하면 레이팅 게임
+	game.BlueWins = game.Rules.WinsNeeded
+	h.openRematch(game, Blue)
+	h.handleRematchOffer(client)
+	h.handleRematchAccept(red)
+	rematch := h.games[client.GameID]
+	if rematch == nil || rematch == game {
+		t.Fatal("rematch did not start a new game")
+	}
+	stopStartedGame(t, h, rematch)
+	if !rematch.Rated {
+		t.Error("rematch after resume is not rated")
+	}
+}
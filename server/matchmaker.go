@@ -0,0 +1,395 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// 매칭 설정
+const (
+	queueBaseWindow   = 100.0 // 처음 찾는 상대의 레이팅 범위 (±)
+	queueWindowGrowth = 10.0  // 기다린 1초마다 넓어지는 범위
+	queueMaxWindow    = 800.0 // 이만큼 넓어지면 사실상 누구와도 매칭
+	queueWaitHistory  = 20    // 예상 대기 시간 계산에 쓰는 최근 매칭 수
+
+	queueTickInterval = 2 * time.Second // 범위를 넓혀 다시 찾고 상태를 알리는 간격
+)
+
+// ErrAlreadyQueued 이미 대기열에 있음
+var ErrAlreadyQueued = errors.New("이미 대기열에 있습니다")
+
+// Matchmaker 규칙별 대기열에서 레이팅이 가까운 플레이어끼리 짝지음
+// 웹소켓과 무관한 순수 자료구조로, 허브 이벤트 루프에서만 호출한다
+type Matchmaker struct {
+	sides   [2]string              // 첫 번째/두 번째 색(팀) 이름
+	entries []*QueueEntry          // 들어온 순서
+	byID    map[string]*QueueEntry // 클라이언트 ID -> 대기 항목
+	waits   map[string][]time.Duration
+}
+
+// NewMatchmaker first, second 두 색(팀)을 배정하는 매칭 대기열 생성
+func NewMatchmaker(first, second string) *Matchmaker {
+	return &Matchmaker{
+		sides: [2]string{first, second},
+		byID:  make(map[string]*QueueEntry),
+		waits: make(map[string][]time.Duration),
+	}
+}
+
+// queueKey 같은 규칙, 같은 레이팅 여부, 같은 다전제 판 수, 같은 추가 설정끼리만 매칭
+func (e *QueueEntry) queueKey() string {
+	key := e.RuleSet + "/rated"
+	if e.Casual {
+		key = e.RuleSet + "/casual"
+	}
+	if e.BestOf > 0 {
+		key += fmt.Sprintf("/bo%d", e.BestOf)
+	}
+	if e.Settings != "" {
+		key += "/" + e.Settings
+	}
+	return key
+}
+
+// Enqueue 대기열에 추가
+func (m *Matchmaker) Enqueue(entry *QueueEntry) error {
+	if m.byID[entry.ID] != nil {
+		return ErrAlreadyQueued
+	}
+	m.entries = append(m.entries, entry)
+	m.byID[entry.ID] = entry
+	return nil
+}
+
+// Cancel 대기열에서 빼고 빠진 항목 반환 (없으면 nil)
+func (m *Matchmaker) Cancel(id string) *QueueEntry {
+	entry := m.byID[id]
+	if entry == nil {
+		return nil
+	}
+	m.remove(map[*QueueEntry]bool{entry: true})
+	return entry
+}
+
+// Contains 대기열에 있는지 확인
+func (m *Matchmaker) Contains(id string) bool {
+	return m.byID[id] != nil
+}
+
+// Len 전체 대기 인원
+func (m *Matchmaker) Len() int {
+	return len(m.entries)
+}
+
+// Entries 들어온 순서대로 대기 항목 목록
+func (m *Matchmaker) Entries() []*QueueEntry {
+	return append([]*QueueEntry(nil), m.entries...)
+}
+
+// Window 지금 entry가 상대를 찾는 레이팅 범위
+func (m *Matchmaker) Window(entry *QueueEntry, now time.Time) float64 {
+	waited := now.Sub(entry.JoinedAt).Seconds()
+	return math.Min(queueBaseWindow+queueWindowGrowth*math.Max(waited, 0), queueMaxWindow)
+}
+
+// Match 짝지을 수 있는 플레이어를 모두 매칭해 대기열에서 빼고 반환
+// 오래 기다린 순서로 상대를 고르며, 둘 중 넓은 범위 안에 있으면 매칭한다
+// 후보 중에는 선호 색이 겹치지 않는 상대, 그다음 레이팅이 가까운 상대를 우선한다
+func (m *Matchmaker) Match(now time.Time) []Match {
+	var matches []Match
+	matched := make(map[*QueueEntry]bool)
+
+	for i, a := range m.entries {
+		if matched[a] {
+			continue
+		}
+
+		var best *QueueEntry
+		bestConflict, bestDiff := false, 0.0
+		for _, b := range m.entries[i+1:] {
+			if matched[b] || b.queueKey() != a.queueKey() {
+				continue
+			}
+			diff := math.Abs(a.Rating - b.Rating)
+			if diff > math.Max(m.Window(a, now), m.Window(b, now)) {
+				continue
+			}
+			conflict := a.Side != "" && a.Side == b.Side
+			if best == nil || (bestConflict && !conflict) || (bestConflict == conflict && diff < bestDiff) {
+				best, bestConflict, bestDiff = b, conflict, diff
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		matched[a], matched[best] = true, true
+		matches = append(matches, m.assignSides(a, best))
+		m.recordWait(a.queueKey(), now.Sub(a.JoinedAt))
+		m.recordWait(best.queueKey(), now.Sub(best.JoinedAt))
+	}
+
+	m.remove(matched)
+	return matches
+}
+
+// assignSides 선호 색 배정 (둘 다 같은 색을 원하면 먼저 기다린 a 우선)
+func (m *Matchmaker) assignSides(a, b *QueueEntry) Match {
+	switch {
+	case a.Side == m.sides[0]:
+		return Match{First: a, Second: b}
+	case a.Side == m.sides[1]:
+		return Match{First: b, Second: a}
+	case b.Side == m.sides[0]:
+		return Match{First: b, Second: a}
+	default:
+		return Match{First: a, Second: b}
+	}
+}
+
+// Expire maxWait 이상 기다린 플레이어를 대기열에서 빼고 반환 (eligible이 있으면 통과한 플레이어만)
+func (m *Matchmaker) Expire(maxWait time.Duration, now time.Time, eligible func(*QueueEntry) bool) []*QueueEntry {
+	expired := make(map[*QueueEntry]bool)
+	var out []*QueueEntry
+	for _, entry := range m.entries {
+		if now.Sub(entry.JoinedAt) >= maxWait && (eligible == nil || eligible(entry)) {
+			expired[entry] = true
+			out = append(out, entry)
+		}
+	}
+	m.remove(expired)
+	return out
+}
+
+// Status 대기 중인 플레이어의 순번과 예상 대기 시간
+func (m *Matchmaker) Status(id string, now time.Time) (QueueStatus, bool) {
+	entry := m.byID[id]
+	if entry == nil {
+		return QueueStatus{}, false
+	}
+
+	key := entry.queueKey()
+	status := QueueStatus{
+		RuleSet:       entry.RuleSet,
+		Casual:        entry.Casual,
+		WaitedSeconds: int(now.Sub(entry.JoinedAt) / time.Second),
+		EstimatedWait: -1,
+		RatingWindow:  int(m.Window(entry, now)),
+	}
+	for _, e := range m.entries {
+		if e.queueKey() != key {
+			continue
+		}
+		status.QueueSize++
+		if e == entry {
+			status.Position = status.QueueSize
+		}
+	}
+
+	// 최근 매칭까지 걸린 평균 시간에서 이미 기다린 시간을 뺌
+	if waits := m.waits[key]; len(waits) > 0 {
+		var total time.Duration
+		for _, w := range waits {
+			total += w
+		}
+		remaining := total/time.Duration(len(waits)) - now.Sub(entry.JoinedAt)
+		status.EstimatedWait = int(math.Ceil(math.Max(remaining.Seconds(), 0)))
+	}
+	return status, true
+}
+
+func (m *Matchmaker) recordWait(key string, wait time.Duration) {
+	waits := append(m.waits[key], wait)
+	if len(waits) > queueWaitHistory {
+		waits = waits[len(waits)-queueWaitHistory:]
+	}
+	m.waits[key] = waits
+}
+
+// remove 대기열에서 여러 항목을 순서를 유지하며 제거
+func (m *Matchmaker) remove(set map[*QueueEntry]bool) {
+	if len(set) == 0 {
+		return
+	}
+	kept := m.entries[:0]
+	for _, entry := range m.entries {
+		if set[entry] {
+			delete(m.byID, entry.ID)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	for i := len(kept); i < len(m.entries); i++ {
+		m.entries[i] = nil
+	}
+	m.entries = kept
+}
+
+// ==================== Hub Integration ====================
+
+// queuedJoin 대기열에 선 참가 요청
+type queuedJoin struct {
+	Client *Client
+	Casual bool
+	BestOf int
+	Setup  gameSetup
+}
+
+// enqueue 참가 요청을 대기열에 넣고 바로 상대 찾기
+// 매칭된 게임은 먼저 기다린 플레이어의 설정을 쓰므로 규칙과 설정 키(setup.Key)가 같은 플레이어끼리만 매칭
+func (h *gameHub[G]) enqueue(client *Client, payload joinPayload, data []byte) {
+	if !h.allowQueue(client) {
+		return
+	}
+
+	setup, err := h.hooks.joinSetup(data)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+	bestOf, err := normalizeBestOf(payload.BestOf)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+
+	side := ""
+	for _, seat := range h.seats {
+		if setup.Seat == seat {
+			side = string(seat)
+		}
+	}
+
+	entry := &QueueEntry{
+		ID:       client.ID,
+		RuleSet:  setup.RuleSet,
+		Casual:   payload.Casual,
+		BestOf:   bestOf,
+		Rating:   h.queueRating(client),
+		Side:     side,
+		Settings: setup.Key,
+		JoinedAt: time.Now(),
+		Data:     &queuedJoin{Client: client, Casual: payload.Casual, BestOf: bestOf, Setup: setup},
+	}
+	if err := h.queue.Enqueue(entry); err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+	log.Printf("%sPlayer %s queued for %s (rating %.0f)", h.logPrefix, client.ID, entry.queueKey(), entry.Rating)
+
+	// 대기 중 메시지
+	h.sendToClient(client, Message{
+		Type: h.msg(MsgWaitingPlayer),
+		Payload: map[string]string{
+			"message": "상대방을 기다리는 중...",
+		},
+	})
+
+	h.processQueue()
+}
+
+// queueRating 매칭에 쓰는 레이팅 (프로필이 없으면 초기값)
+func (h *gameHub[G]) queueRating(client *Client) float64 {
+	if h.players == nil || client.PlayerID == "" {
+		return initialRating
+	}
+	return h.players.RatingOf(client.PlayerID, h.kind).Rating
+}
+
+// processQueue 매칭된 게임 시작, 오래 기다린 플레이어는 AI와 대전, 남은 플레이어에게 상태 전송
+func (h *gameHub[G]) processQueue() {
+	now := time.Now()
+
+	// 버퍼가 가득 차 연결이 정리된 클라이언트는 대기열에서 제외
+	for _, entry := range h.queue.Entries() {
+		if !h.connected(entry.Data.(*queuedJoin).Client) {
+			h.queue.Cancel(entry.ID)
+		}
+	}
+
+	for _, match := range h.queue.Match(now) {
+		h.startMatch(match)
+	}
+
+	// 다전제는 AI와 이어서 둘 수 없으므로 사람 상대를 계속 기다림
+	if h.botFallbackWait > 0 {
+		for _, entry := range h.queue.Expire(h.botFallbackWait, now, func(e *QueueEntry) bool { return e.BestOf == 0 }) {
+			join := entry.Data.(*queuedJoin)
+			log.Printf("%sNo opponent for %s after %v, starting against %s bot", h.logPrefix, join.Client.ID, h.botFallbackWait, h.botFallbackLevel)
+			h.handleBotGame(join.Client, h.botFallbackLevel, join.Setup)
+		}
+	}
+
+	for _, entry := range h.queue.Entries() {
+		h.sendQueueStatus(entry.Data.(*queuedJoin).Client)
+	}
+
+	h.scheduleQueueTick()
+}
+
+// startMatch 매칭된 두 플레이어로 게임 생성 (먼저 기다린 플레이어의 설정 사용)
+func (h *gameHub[G]) startMatch(match Match) {
+	first, second := match.First.Data.(*queuedJoin), match.Second.Data.(*queuedJoin)
+	host := first
+	if match.Second.JoinedAt.Before(match.First.JoinedAt) {
+		host = second
+	}
+
+	game := h.hooks.newGame(host.Setup.Settings)
+	t := game.table()
+	t.Casual = host.Casual
+	t.startSeries(host.BestOf, h.seats)
+	h.addGame(game)
+	log.Printf("%sMatched %s and %s in game %s", h.logPrefix, first.Client.ID, second.Client.ID, t.ID)
+
+	players := map[Seat]*Client{h.seats[0]: first.Client, h.seats[1]: second.Client}
+	if h.seatPlayers(game, players) {
+		h.startGame(game)
+	}
+}
+
+// scheduleQueueTick 대기 중인 플레이어가 있으면 다음 매칭 시도 예약
+func (h *gameHub[G]) scheduleQueueTick() {
+	if h.queue.Len() == 0 || h.queueTickScheduled {
+		return
+	}
+	h.queueTickScheduled = true
+	time.AfterFunc(queueTickInterval, func() {
+		h.queueTick <- struct{}{}
+	})
+}
+
+// handleQueueTick 범위를 넓혀 다시 매칭 시도
+func (h *gameHub[G]) handleQueueTick() {
+	h.queueTickScheduled = false
+	h.processQueue()
+}
+
+func (h *gameHub[G]) handleCancelQueue(client *Client) {
+	if h.queue.Cancel(client.ID) == nil {
+		h.sendError(client, "대기열에 있지 않습니다")
+		return
+	}
+	log.Printf("%sPlayer %s left the queue", h.logPrefix, client.ID)
+
+	h.sendToClient(client, Message{
+		Type: h.msg(MsgQueueCancelled),
+		Payload: map[string]string{
+			"message": "대기열에서 나왔습니다",
+		},
+	})
+}
+
+func (h *gameHub[G]) sendQueueStatus(client *Client) {
+	status, ok := h.queue.Status(client.ID, time.Now())
+	if !ok {
+		return
+	}
+	h.sendToClient(client, Message{
+		Type:    h.msg(MsgQueueStatus),
+		Payload: status,
+	})
+}
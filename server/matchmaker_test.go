@@ -0,0 +1,397 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// queued 테스트용 대기 항목 (waited만큼 전에 들어옴)
+func queued(id string, rating float64, side string, waited time.Duration, now time.Time) *QueueEntry {
+	return &QueueEntry{
+		ID:       id,
+		RuleSet:  RuleSetClassic,
+		Rating:   rating,
+		Side:     side,
+		JoinedAt: now.Add(-waited),
+	}
+}
+
+func TestMatchmakerMatch(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		entries []*QueueEntry
+		want    [][2]string // First, Second ID
+	}{
+		{
+			name: "범위 안이면 바로 매칭",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				queued("b", 1550, "", 0, now),
+			},
+			want: [][2]string{{"a", "b"}},
+		},
+		{
+			name: "범위 밖이면 기다림",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				queued("b", 1700, "", 0, now),
+			},
+		},
+		{
+			name: "기다린 만큼 범위가 넓어짐",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 15*time.Second, now),
+				queued("b", 1700, "", 0, now),
+			},
+			want: [][2]string{{"a", "b"}},
+		},
+		{
+			name: "둘 중 넓은 범위를 사용",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				queued("b", 1700, "", 15*time.Second, now),
+			},
+			want: [][2]string{{"a", "b"}},
+		},
+		{
+			name: "범위는 최대값까지만 넓어짐",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", time.Hour, now),
+				queued("b", 2400, "", time.Hour, now),
+			},
+		},
+		{
+			name: "레이팅이 가까운 상대 우선",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				queued("b", 1590, "", 0, now),
+				queued("c", 1510, "", 0, now),
+			},
+			want: [][2]string{{"a", "c"}},
+		},
+		{
+			name: "선호 색이 겹치지 않는 상대 우선",
+			entries: []*QueueEntry{
+				queued("a", 1500, string(Blue), 0, now),
+				queued("b", 1500, string(Blue), 0, now),
+				queued("c", 1550, string(Red), 0, now),
+			},
+			want: [][2]string{{"a", "c"}},
+		},
+		{
+			name: "선호 색대로 배정",
+			entries: []*QueueEntry{
+				queued("a", 1500, string(Red), 0, now),
+				queued("b", 1500, "", 0, now),
+			},
+			want: [][2]string{{"b", "a"}},
+		},
+		{
+			name: "같은 색을 원하면 먼저 온 쪽 우선",
+			entries: []*QueueEntry{
+				queued("a", 1500, string(Red), 0, now),
+				queued("b", 1500, string(Red), 0, now),
+			},
+			want: [][2]string{{"b", "a"}},
+		},
+		{
+			name: "규칙이 다르면 매칭하지 않음",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				{ID: "b", RuleSet: RuleSetShort, Rating: 1500, JoinedAt: now},
+			},
+		},
+		{
+			name: "레이팅 게임과 친선 게임은 따로",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				{ID: "b", RuleSet: RuleSetClassic, Casual: true, Rating: 1500, JoinedAt: now},
+			},
+		},
+		{
+			name: "다전제 판 수가 같을 때만",
+			entries: []*QueueEntry{
+				{ID: "a", RuleSet: RuleSetClassic, BestOf: 3, Rating: 1500, JoinedAt: now},
+				{ID: "b", RuleSet: RuleSetClassic, BestOf: 5, Rating: 1500, JoinedAt: now},
+				{ID: "c", RuleSet: RuleSetClassic, BestOf: 3, Rating: 1500, JoinedAt: now},
+			},
+			want: [][2]string{{"a", "c"}},
+		},
+		{
+			name: "추가 설정이 같을 때만",
+			entries: []*QueueEntry{
+				{ID: "a", RuleSet: RuleSetClassic, Settings: "30s/auto_play/blind", Rating: 1500, JoinedAt: now},
+				{ID: "b", RuleSet: RuleSetClassic, Settings: "60s/auto_play/blind", Rating: 1500, JoinedAt: now},
+				{ID: "c", RuleSet: RuleSetClassic, Settings: "30s/auto_play/open", Rating: 1500, JoinedAt: now},
+				{ID: "d", RuleSet: RuleSetClassic, Settings: "60s/auto_play/blind", Rating: 1500, JoinedAt: now},
+			},
+			want: [][2]string{{"b", "d"}},
+		},
+		{
+			name: "여러 쌍을 한 번에 매칭",
+			entries: []*QueueEntry{
+				queued("a", 1500, "", 0, now),
+				queued("b", 2000, "", 0, now),
+				queued("c", 1520, "", 0, now),
+				queued("d", 2010, "", 0, now),
+			},
+			want: [][2]string{{"a", "c"}, {"b", "d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchmaker(string(Blue), string(Red))
+			for _, e := range tt.entries {
+				if err := m.Enqueue(e); err != nil {
+					t.Fatalf("Enqueue(%s): %v", e.ID, err)
+				}
+			}
+
+			matches := m.Match(now)
+			if len(matches) != len(tt.want) {
+				t.Fatalf("got %d matches, want %d", len(matches), len(tt.want))
+			}
+			for i, match := range matches {
+				got := [2]string{match.First.ID, match.Second.ID}
+				if got != tt.want[i] {
+					t.Errorf("match %d = %v, want %v", i, got, tt.want[i])
+				}
+				if m.Contains(got[0]) || m.Contains(got[1]) {
+					t.Errorf("matched players %v still queued", got)
+				}
+			}
+			if left := len(tt.entries) - 2*len(tt.want); m.Len() != left {
+				t.Errorf("Len() = %d, want %d", m.Len(), left)
+			}
+		})
+	}
+}
+
+func TestMatchmakerExpire(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		waited   []time.Duration
+		maxWait  time.Duration
+		eligible func(*QueueEntry) bool
+		want     []string
+	}{
+		{name: "아무도 오래 기다리지 않음", waited: []time.Duration{time.Second, 2 * time.Second}, maxWait: time.Minute},
+		{name: "정확히 maxWait면 만료", waited: []time.Duration{time.Minute, time.Second}, maxWait: time.Minute, want: []string{"p0"}},
+		{name: "들어온 순서대로", waited: []time.Duration{2 * time.Minute, time.Second, 3 * time.Minute}, maxWait: time.Minute, want: []string{"p0", "p2"}},
+		{
+			name:     "조건에 맞는 플레이어만",
+			waited:   []time.Duration{2 * time.Minute, 3 * time.Minute},
+			maxWait:  time.Minute,
+			eligible: func(e *QueueEntry) bool { return e.ID != "p0" },
+			want:     []string{"p1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchmaker(string(Blue), string(Red))
+			for i, waited := range tt.waited {
+				// 레이팅 차이를 크게 둬서 서로 매칭되지 않게 함
+				m.Enqueue(queued("p"+string(rune('0'+i)), 1000+float64(i)*1000, "", waited, now))
+			}
+
+			expired := m.Expire(tt.maxWait, now, tt.eligible)
+			if len(expired) != len(tt.want) {
+				t.Fatalf("got %d expired, want %d", len(expired), len(tt.want))
+			}
+			for i, entry := range expired {
+				if entry.ID != tt.want[i] {
+					t.Errorf("expired[%d] = %s, want %s", i, entry.ID, tt.want[i])
+				}
+				if m.Contains(entry.ID) {
+					t.Errorf("%s still queued after expiring", entry.ID)
+				}
+			}
+			if m.Len() != len(tt.waited)-len(tt.want) {
+				t.Errorf("Len() = %d, want %d", m.Len(), len(tt.waited)-len(tt.want))
+			}
+		})
+	}
+}
+
+func TestMatchmakerStatus(t *testing.T) {
+	now := time.Now()
+	m := NewMatchmaker(string(Blue), string(Red))
+	m.Enqueue(queued("a", 1000, "", 30*time.Second, now))
+	m.Enqueue(&QueueEntry{ID: "other", RuleSet: RuleSetShort, Rating: 1000, JoinedAt: now})
+	m.Enqueue(queued("b", 2000, "", 10*time.Second, now))
+
+	if _, ok := m.Status("missing", now); ok {
+		t.Fatal("Status of a player not in the queue should be false")
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want QueueStatus
+	}{
+		{
+			name: "같은 규칙 대기열 안의 순번",
+			id:   "b",
+			want: QueueStatus{RuleSet: RuleSetClassic, Position: 2, QueueSize: 2, WaitedSeconds: 10, EstimatedWait: -1, RatingWindow: 200},
+		},
+		{
+			name: "다른 규칙은 따로 셈",
+			id:   "other",
+			want: QueueStatus{RuleSet: RuleSetShort, Position: 1, QueueSize: 1, WaitedSeconds: 0, EstimatedWait: -1, RatingWindow: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := m.Status(tt.id, now)
+			if !ok {
+				t.Fatalf("Status(%s) not found", tt.id)
+			}
+			if got != tt.want {
+				t.Errorf("Status(%s) = %+v, want %+v", tt.id, got, tt.want)
+			}
+		})
+	}
+
+	// 같은 대기열에서 매칭된 대기 시간 평균(41초)에서 이미 기다린 시간(11초)을 뺀 값으로 예상
+	m.Enqueue(queued("c", 3000, "", 40*time.Second, now))
+	m.Enqueue(queued("d", 3000, "", 40*time.Second, now))
+	later := now.Add(time.Second)
+	m.Match(later)
+	status, _ := m.Status("b", later)
+	if status.EstimatedWait != 30 {
+		t.Errorf("EstimatedWait = %d, want 30", status.EstimatedWait)
+	}
+}
+
+func TestMatchmakerCancel(t *testing.T) {
+	now := time.Now()
+	m := NewMatchmaker(string(Blue), string(Red))
+	a := queued("a", 1500, "", 0, now)
+	m.Enqueue(a)
+	m.Enqueue(queued("b", 3000, "", 0, now))
+
+	if err := m.Enqueue(queued("a", 1500, "", 0, now)); !errors.Is(err, ErrAlreadyQueued) {
+		t.Fatalf("Enqueue twice = %v, want ErrAlreadyQueued", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want *QueueEntry
+		left int
+	}{
+		{name: "대기 중인 플레이어", id: "a", want: a, left: 1},
+		{name: "이미 빠진 플레이어", id: "a", want: nil, left: 1},
+		{name: "대기열에 없는 플레이어", id: "missing", want: nil, left: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Cancel(tt.id); got != tt.want {
+				t.Errorf("Cancel(%s) = %v, want %v", tt.id, got, tt.want)
+			}
+			if m.Contains(tt.id) {
+				t.Errorf("%s still queued", tt.id)
+			}
+			if m.Len() != tt.left {
+				t.Errorf("Len() = %d, want %d", m.Len(), tt.left)
+			}
+		})
+	}
+
+	// 빠진 뒤에는 다시 설 수 있음
+	if err := m.Enqueue(queued("a", 1500, "", 0, now)); err != nil {
+		t.Fatalf("Enqueue after Cancel: %v", err)
+	}
+}
+
+// 차례 제한 시간, 시간 초과 처리, 타일 공개 범위가 다른 플레이어는 매칭하지 않음
+// 잘못된 값은 기본값으로 바꾼 뒤 비교하므로 기본값을 고른 플레이어와 매칭됨
+func TestEnqueueMatchesGameSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     JoinGamePayload
+		second    JoinGamePayload
+		wantMatch bool
+	}{
+		{name: "같은 설정", first: JoinGamePayload{TurnSeconds: 45, TileReveal: RevealOpen}, second: JoinGamePayload{TurnSeconds: 45, TileReveal: RevealOpen}, wantMatch: true},
+		{name: "제한 시간이 다름", first: JoinGamePayload{TurnSeconds: 45}, second: JoinGamePayload{TurnSeconds: 90}},
+		{name: "시간 초과 처리가 다름", first: JoinGamePayload{TimeoutPolicy: TimeoutForfeitGame}, second: JoinGamePayload{TimeoutPolicy: TimeoutAutoPlay}},
+		{name: "공개 범위가 다름", first: JoinGamePayload{TileReveal: RevealColor}, second: JoinGamePayload{}},
+		{name: "잘못된 값은 기본값", first: JoinGamePayload{TurnSeconds: -1, TimeoutPolicy: "never", TileReveal: "x-ray"}, second: JoinGamePayload{}, wantMatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			first, second := testClient("first", 64), testClient("second", 64)
+			h.addClient(first)
+			h.addClient(second)
+
+			h.handleJoinGame(first, Message{Type: MsgJoinGame, Payload: tt.first})
+			h.handleJoinGame(second, Message{Type: MsgJoinGame, Payload: tt.second})
+
+			game := h.games[first.GameID]
+			if matched := game != nil && second.GameID == game.ID; matched != tt.wantMatch {
+				t.Fatalf("matched = %v, want %v", matched, tt.wantMatch)
+			}
+			if game == nil {
+				if h.queue.Len() != 2 {
+					t.Errorf("queue length = %d, want 2", h.queue.Len())
+				}
+				return
+			}
+			t.Cleanup(func() { game.actor.stop() })
+		})
+	}
+}
+
+// 오래 기다린 단판 플레이어는 AI와 대전하고, 다전제 플레이어는 AI와 이어서 둘 수 없으므로 계속 기다림
+func TestBotFallbackSkipsBestOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		bestOf   int
+		wantGame bool
+	}{
+		{name: "단판", wantGame: true},
+		{name: "3판 2선승", bestOf: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			if err := h.SetBotFallback(time.Minute, BotRandom); err != nil {
+				t.Fatal(err)
+			}
+			client := testClient("alone", 64)
+			h.addClient(client)
+
+			h.handleJoinGame(client, Message{Type: MsgJoinGame, Payload: JoinGamePayload{BestOf: tt.bestOf}})
+			for _, entry := range h.queue.Entries() {
+				entry.JoinedAt = entry.JoinedAt.Add(-2 * time.Minute)
+			}
+			h.processQueue()
+
+			game := h.games[client.GameID]
+			if (game != nil) != tt.wantGame {
+				t.Fatalf("bot game started = %v, want %v", game != nil, tt.wantGame)
+			}
+			if h.queue.Contains(client.ID) == tt.wantGame {
+				t.Errorf("still queued = %v", h.queue.Contains(client.ID))
+			}
+			if game == nil {
+				return
+			}
+			stopStartedGame(t, h, game)
+			if len(game.Bots) != 1 || game.Series != nil {
+				t.Errorf("bot game: bots %d, series %+v", len(game.Bots), game.Series)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// 넘버체인지 AI 설정
+const (
+	ncBotMinThink       = 1 * time.Second // 사람처럼 보이도록 두는 최소 고민 시간
+	ncBotMaxThink       = 3 * time.Second
+	ncBotEasyHidden     = 0.2  // 쉬움 AI가 히든 찬스를 쓸 확률
+	ncBotMaterialWeight = 1.0  // 어려움 AI가 라운드 승패 대비 블록 손익을 얼마나 아낄지
+	ncBotHiddenReserve  = 0.08 // 남은 라운드가 많을 때 히든 찬스를 아껴두는 가치
+
+	defaultNCBotFallbackLevel = NCBotHard // 대기열에서 상대를 못 찾았을 때 붙이는 AI 기본 난이도
+)
+
+// ncBotNames 난이도별 표시 이름
+var ncBotNames = map[BotLevel]string{
+	NCBotEasy:   "AI (쉬움)",
+	NCBotNormal: "AI (보통)",
+	NCBotHard:   "AI (어려움)",
+}
+
+// NewNCBot 난이도에 맞는 AI 팀 생성
+func NewNCBot(team TeamColor, level BotLevel) (*Bot, error) {
+	name, ok := ncBotNames[level]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 AI 난이도입니다: %s", level)
+	}
+	return &Bot{Seat: team, Level: level, Name: name}, nil
+}
+
+// ChooseSubmit 이번 라운드에 낼 블록과 히든 찬스 사용 여부 결정
+func (b *Bot) ChooseSubmit(game *NCGame) ([]int, bool) {
+	combos := blockCombos(game.AvailableBlocks[b.Seat], game.Rules.BlocksPerSubmit)
+	if len(combos) == 0 {
+		return nil, false
+	}
+	canHide := game.HiddenLeft(b.Seat, b.Seat) > 0
+
+	switch b.Level {
+	case NCBotNormal:
+		// 상대 평균 합계를 넘는 가장 작은 조합, 없으면 가장 작은 조합을 버림
+		// 큰 블록을 넘겨줄 상황이면 히든으로 가림
+		target := averageComboSum(game.AvailableBlocks[opponentTeam(b.Seat)], game.Rules.BlocksPerSubmit)
+		best := combos[0]
+		for _, combo := range combos {
+			total := sumBlocks(combo)
+			if float64(total) > target && (float64(sumBlocks(best)) <= target || total < sumBlocks(best)) {
+				best = combo
+			}
+		}
+		spread := best[len(best)-1] - best[0]
+		roundsLeft := game.Rules.MaxRounds - game.CurrentRound
+		useHidden := canHide && (2*spread >= maxOf(game.Rules.StartingBlocks) || roundsLeft < game.HiddenLeft(b.Seat, b.Seat))
+		return shuffled(best), useHidden
+
+	case NCBotHard:
+		return b.chooseHard(game, combos, canHide)
+
+	default:
+		return shuffled(combos[rand.Intn(len(combos))]), canHide && rand.Float64() < ncBotEasyHidden
+	}
+}
+
+// ChooseSelection 상대 블록 중 받을 블록 선택
+// 상대가 낸 블록과 순서는 보이지 않으므로 어느 위치든 기대값이 같아 무작위로 고른다
+func (b *Bot) ChooseSelection(game *NCGame) int {
+	return rand.Intn(game.Rules.BlocksPerSubmit) + 1
+}
+
+// chooseHard 라운드 승률과 교환 후 블록 손익을 함께 평가해 가장 좋은 제출 선택
+// 제출한 블록은 모두 소모되고 상대는 그중 가장 큰 블록(히든이면 무작위 블록)을 받는다
+func (b *Bot) chooseHard(game *NCGame, combos [][]int, canHide bool) ([]int, bool) {
+	opponent := opponentTeam(b.Seat)
+	opponentCombos := blockCombos(game.AvailableBlocks[opponent], game.Rules.BlocksPerSubmit)
+	opponentSums := make([]int, len(opponentCombos))
+	for i, combo := range opponentCombos {
+		opponentSums[i] = sumBlocks(combo)
+	}
+
+	// 목표 점수에 가까울수록 이번 라운드 승패가 중요
+	ownScore, opponentScore := game.Team1Score, game.Team2Score
+	if b.Seat == Team2 {
+		ownScore, opponentScore = opponentScore, ownScore
+	}
+	winWeight := 1.0
+	if opponentScore == game.Rules.ScoreTarget-1 {
+		winWeight += 1.0
+	}
+	if ownScore == game.Rules.ScoreTarget-1 {
+		winWeight += 0.5
+	}
+
+	// 뒤에 남은 라운드가 많을수록 블록을 아낌 (연장전은 이번 라운드가 전부)
+	roundsLeft := game.Rules.MaxRounds - game.CurrentRound
+	materialWeight := 0.0
+	if !game.Overtime && roundsLeft > 0 {
+		materialWeight = ncBotMaterialWeight * float64(roundsLeft) / float64(game.Rules.MaxRounds)
+	}
+	scale := float64(maxOf(game.Rules.StartingBlocks) * game.Rules.BlocksPerSubmit)
+
+	// 선택 교환 규칙이면 상대가 무작위로 고르는 것과 같음
+	chosenExchange := game.Rules.Exchange == ExchangeChosen
+	reserve := 0.0
+	if roundsLeft >= game.HiddenLeft(b.Seat, b.Seat) {
+		reserve = ncBotHiddenReserve
+	}
+
+	var best []int
+	bestHidden := false
+	bestValue := 0.0
+	for _, combo := range combos {
+		total := sumBlocks(combo)
+		wins, losses := 0, 0
+		for _, opponentSum := range opponentSums {
+			if total > opponentSum {
+				wins++
+			} else if total < opponentSum {
+				losses++
+			}
+		}
+		roundValue := winWeight * float64(wins-losses) / float64(len(opponentSums))
+
+		options := []bool{false}
+		if canHide {
+			options = append(options, true)
+		}
+		for _, hidden := range options {
+			given := float64(combo[len(combo)-1])
+			if hidden || chosenExchange {
+				given = float64(total) / float64(len(combo))
+			}
+			value := roundValue - materialWeight*(float64(total)+given)/scale
+			if hidden {
+				value -= reserve
+			}
+			if best == nil || value > bestValue {
+				best, bestHidden, bestValue = combo, hidden, value
+			}
+		}
+	}
+
+	return shuffled(best), bestHidden
+}
+
+// averageComboSum 블록 목록에서 size개를 무작위로 냈을 때의 평균 합계
+func averageComboSum(blocks []int, size int) float64 {
+	if len(blocks) == 0 {
+		return 0
+	}
+	return float64(sumBlocks(blocks)*size) / float64(len(blocks))
+}
+
+// blockCombos 블록 목록에서 size개를 고르는 서로 다른 조합 (각 조합은 오름차순)
+func blockCombos(blocks []int, size int) [][]int {
+	sorted := append([]int{}, blocks...)
+	sort.Ints(sorted)
+	if size > len(sorted) {
+		return nil
+	}
+
+	combos := [][]int{}
+	seen := make(map[string]bool)
+	combo := make([]int, 0, size)
+	var pick func(start int)
+	pick = func(start int) {
+		if len(combo) == size {
+			key := fmt.Sprint(combo)
+			if !seen[key] {
+				seen[key] = true
+				combos = append(combos, append([]int{}, combo...))
+			}
+			return
+		}
+		for i := start; i < len(sorted); i++ {
+			combo = append(combo, sorted[i])
+			pick(i + 1)
+			combo = combo[:len(combo)-1]
+		}
+	}
+	pick(0)
+
+	return combos
+}
+
+// shuffled 제출 순서를 섞은 복사본 (상대의 블록 선택에 순서 정보를 주지 않음)
+func shuffled(blocks []int) []int {
+	out := append([]int{}, blocks...)
+	rand.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out
+}
+
+// maxOf 가장 큰 값
+func maxOf(values []int) int {
+	best := 0
+	for _, v := range values {
+		if v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+// ==================== Hub Integration ====================
+
+// scheduleBotMove 현재 단계에서 AI 팀이 할 일이 있으면 고민 시간 후 실행하도록 예약
+func (h *NCHub) scheduleBotMove(game *NCGame) {
+	if len(game.Bots) == 0 {
+		return
+	}
+
+	seq := game.deadlineSeq
+	think := ncBotMinThink + time.Duration(rand.Int63n(int64(ncBotMaxThink-ncBotMinThink)))
+	time.AfterFunc(think, func() {
+		game.actor.post(func() { h.handleBotMove(game, seq) })
+	})
+}
+
+// handleBotMove 예약된 AI 제출/선택 실행 (마감 타이머와 같은 순번으로 오래된 예약 무시)
+func (h *NCHub) handleBotMove(game *NCGame, seq int) {
+	if !game.Ready || game.deadlineSeq != seq {
+		return
+	}
+
+	for team, bot := range game.Bots {
+		switch game.Phase {
+		case NCPhaseSubmitting, NCPhaseOvertime:
+			if game.RoundSubmits[team] != nil {
+				continue
+			}
+			blocks, useHidden := bot.ChooseSubmit(game)
+			payload, _ := json.Marshal(NCSubmitBlocksPayload{Blocks: blocks, UseHidden: useHidden})
+			if err := game.ApplyMove(team, Move{Kind: string(NCMsgSubmitBlocks), Payload: payload}); err != nil {
+				log.Printf("[NC] Error submitting bot blocks for %s in game %s: %v", team, game.ID, err)
+				continue
+			}
+
+		case NCPhaseAwaitingSelection:
+			if !game.SelectionPending(team) {
+				continue
+			}
+			payload, _ := json.Marshal(NCSelectBlockPayload{SelectedBlockChoice: bot.ChooseSelection(game)})
+			if err := game.ApplyMove(team, Move{Kind: string(NCMsgSelectBlock), Payload: payload}); err != nil {
+				log.Printf("[NC] Error selecting bot block for %s in game %s: %v", team, game.ID, err)
+			}
+		}
+	}
+
+	h.advanceRound(game)
+}
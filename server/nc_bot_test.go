@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewNCBot(t *testing.T) {
+	for level := range ncBotNames {
+		bot, err := NewNCBot(Team2, level)
+		if err != nil || bot.Seat != Team2 || bot.Name != ncBotNames[level] {
+			t.Errorf("NewNCBot(%s) = %+v, %v", level, bot, err)
+		}
+	}
+	if _, err := NewNCBot(Team2, "grandmaster"); err == nil {
+		t.Error("unknown level was accepted")
+	}
+}
+
+// AI끼리 끝까지 두면서 제출과 선택이 언제나 규칙에 맞고 게임이 받아들이는지 확인
+func TestNCBotPlaysLegalMoves(t *testing.T) {
+	for _, level := range []BotLevel{NCBotEasy, NCBotNormal, NCBotHard} {
+		for _, rules := range []NCRuleSet{ClassicNCRules(), QuickNCRules(), TripleNCRules(), DraftNCRules()} {
+			t.Run(fmt.Sprintf("%s/%s", level, rules.Name), func(t *testing.T) {
+				for i := 0; i < 5; i++ {
+					playNCBotGame(t, rules, level)
+				}
+			})
+		}
+	}
+}
+
+func playNCBotGame(t *testing.T, rules NCRuleSet, level BotLevel) {
+	t.Helper()
+	game := NewNCGame("test", rules)
+	for _, team := range []TeamColor{Team1, Team2} {
+		bot, _ := NewNCBot(team, level)
+		if err := game.AddBot(bot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := game.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	for rounds := 0; ; rounds++ {
+		if rounds > 2*len(rules.StartingBlocks) {
+			t.Fatal("game did not end")
+		}
+
+		for _, team := range []TeamColor{Team1, Team2} {
+			hiddenLeft := game.HiddenLeft(team, team)
+			blocks, useHidden := game.Bots[team].ChooseSubmit(game)
+			if len(blocks) != rules.BlocksPerSubmit || !isSubset(blocks, game.AvailableBlocks[team]) {
+				t.Fatalf("round %d: %s submitted %v from %v", game.CurrentRound, team, blocks, game.AvailableBlocks[team])
+			}
+			if useHidden && hiddenLeft == 0 {
+				t.Fatalf("round %d: %s used a hidden chance it does not have", game.CurrentRound, team)
+			}
+			if err := game.SubmitBlocks(team, blocks, useHidden, 0); err != nil {
+				t.Fatalf("round %d: %s submitted %v: %v", game.CurrentRound, team, blocks, err)
+			}
+		}
+		if _, err := game.CloseSubmissions(); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, team := range game.PendingSelections() {
+			choice := game.Bots[team].ChooseSelection(game)
+			if choice < 1 || choice > rules.BlocksPerSubmit {
+				t.Fatalf("round %d: %s chose position %d", game.CurrentRound, team, choice)
+			}
+			if err := game.SelectBlock(team, choice); err != nil {
+				t.Fatalf("round %d: %s selected %d: %v", game.CurrentRound, team, choice, err)
+			}
+		}
+
+		if _, err := game.ProcessRound(); err != nil {
+			t.Fatalf("round %d: %v", game.CurrentRound, err)
+		}
+		_, over, _, err := game.NextRound()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if over {
+			return
+		}
+	}
+}
+
+// isSubset 중복을 세어 picked가 모두 blocks 안에 있는지 확인
+func isSubset(picked, blocks []int) bool {
+	left := make(map[int]int)
+	for _, block := range blocks {
+		left[block]++
+	}
+	for _, block := range picked {
+		if left[block] == 0 {
+			return false
+		}
+		left[block]--
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+)
+
+var _ GameEngine = (*NCGame)(nil)
+
+// ncRoundResolution 넘버체인지 라운드 처리 결과 (이 라운드로 연장전이 시작됐는지 포함)
+type ncRoundResolution struct {
+	Result          *NCRoundResultPayload
+	OvertimeStarted bool
+}
+
+// Seats 1팀, 2팀 순서
+func (g *NCGame) Seats() []Seat {
+	return []Seat{Seat(Team1), Seat(Team2)}
+}
+
+// AssignSeat 원하는 팀이 비어있으면 그 팀, 아니면 남은 팀 (AI 좌석 포함)
+func (g *NCGame) AssignSeat(preferred Seat) (Seat, error) {
+	return assignSeat(g.Seats(), preferred, func(seat Seat) bool {
+		team := TeamColor(seat)
+		return g.Players[team] != nil || g.Bots[team] != nil
+	})
+}
+
+// ApplyMove 블록 제출 또는 받을 블록 선택(nc_select_block)
+// 제출 마감(CloseSubmissions)은 허브가 히든 알림과 함께 처리한다 (형식이 틀린 값은 규칙 검사에서 걸러짐)
+func (g *NCGame) ApplyMove(seat Seat, move Move) error {
+	team := TeamColor(seat)
+	if move.Kind == string(NCMsgSelectBlock) {
+		var payload NCSelectBlockPayload
+		json.Unmarshal(move.Payload, &payload)
+		if err := g.SelectBlock(team, payload.SelectedBlockChoice); err != nil {
+			return err
+		}
+		log.Printf("[NC Game %s] Team %s selected block: %d", g.ID, team, payload.SelectedBlockChoice)
+		return nil
+	}
+
+	var payload NCSubmitBlocksPayload
+	json.Unmarshal(move.Payload, &payload)
+	blocks := payload.blockList()
+	if err := g.SubmitBlocks(team, blocks, payload.UseHidden, payload.SelectedBlockChoice); err != nil {
+		return err
+	}
+	g.RoundSubmits[team].Auto = move.Auto
+	log.Printf("[NC Game %s] Team %s submitted blocks: %v (hidden: %v, choice: %d, auto: %v)",
+		g.ID, team, blocks, payload.UseHidden, payload.SelectedBlockChoice, move.Auto)
+	return nil
+}
+
+// RoundComplete 양 팀이 제출하고 받을 블록 선택까지 끝나 결과 처리 단계인지
+func (g *NCGame) RoundComplete() bool {
+	return g.Phase == NCPhaseResolving
+}
+
+// ResolveRound 라운드를 처리하고 다음 단계로 넘어가 ncRoundResolution 반환 (동점으로 끝나면 연장전 시작)
+// 다음 라운드 마감 시각은 허브가 채운다
+func (g *NCGame) ResolveRound() (interface{}, error) {
+	result, err := g.ProcessRound()
+	if err != nil {
+		return nil, err
+	}
+	overtimeStarted, _, _, err := g.NextRound()
+	if err != nil {
+		return nil, err
+	}
+	result.Phase = g.Phase
+	return ncRoundResolution{Result: result, OvertimeStarted: overtimeStarted}, nil
+}
+
+// Outcome 목표 점수, 마지막 라운드, 연장전 결과로 끝났는지
+func (g *NCGame) Outcome() (bool, Seat, string) {
+	over, reason := g.IsGameOver()
+	if !over {
+		return false, "", ""
+	}
+	return true, Seat(g.GetWinner()), reason
+}
+
+// View 팀 시점의 NCGameStatePayload
+func (g *NCGame) View(seat Seat) interface{} {
+	return g.State(TeamColor(seat))
+}
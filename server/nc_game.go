@@ -1,120 +1,181 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"sort"
 	"time"
 )
 
 // NewNCGame 새 넘버체인지 게임 생성
-func NewNCGame(id string) *NCGame {
+func NewNCGame(id string, rules NCRuleSet) *NCGame {
 	return &NCGame{
-		ID:           id,
-		Players:      make(map[TeamColor]*NCClient),
+		Table:        newTable(id),
 		CurrentRound: 1,
 		Team1Score:   0,
 		Team2Score:   0,
 		AvailableBlocks: map[TeamColor][]int{
-			Team1: {1, 2, 3, 4, 5, 6, 7, 1, 2, 3, 4, 5, 6, 7},
-			Team2: {1, 2, 3, 4, 5, 6, 7, 1, 2, 3, 4, 5, 6, 7},
+			Team1: append([]int{}, rules.StartingBlocks...),
+			Team2: append([]int{}, rules.StartingBlocks...),
 		},
 		RoundHistory: []NCRoundHistory{},
 		RoundSubmits: make(map[TeamColor]*NCSubmit),
-		Ready:        false,
+		HiddenUsed:   make(map[TeamColor]int),
+		Submissions:  []NCSubmitRecord{},
+		Phase:        NCPhaseWaiting,
+		Rules:        rules,
+		SubmitLimit:  defaultSubmitLimit,
+		SelectLimit:  defaultSelectLimit,
 	}
 }
 
-// AddPlayer 플레이어 추가
-func (g *NCGame) AddPlayer(client *NCClient, preferredTeam TeamColor) TeamColor {
-	// 선호하는 팀이 비어있으면 해당 팀에 배정
-	if preferredTeam != "" && g.Players[preferredTeam] == nil {
-		g.Players[preferredTeam] = client
-		return preferredTeam
+// AddPlayer 플레이어 추가 (선호하는 팀이 차 있으면 남은 팀에 배정)
+func (g *NCGame) AddPlayer(client *Client, preferredTeam Seat) (Seat, error) {
+	team, err := g.AssignSeat(preferredTeam)
+	if err != nil {
+		return "", err
 	}
+	g.Players[team] = client
+	client.Seat = team
+	return team, nil
+}
 
-	// 선호하는 팀이 없거나 이미 차있으면 빈 팀에 배정
-	if g.Players[Team1] == nil {
-		g.Players[Team1] = client
-		return Team1
-	}
-	if g.Players[Team2] == nil {
-		g.Players[Team2] = client
-		return Team2
+// AddBot 비어있는 팀 좌석에 AI 추가
+func (g *NCGame) AddBot(bot *Bot) error {
+	if g.Players[bot.Seat] != nil || g.Bots[bot.Seat] != nil {
+		return fmt.Errorf("이미 %s 팀의 플레이어가 존재합니다", bot.Seat)
 	}
-
-	// 모든 팀이 차있으면 랜덤 배정
-	teams := []TeamColor{Team1, Team2}
-	return teams[rand.Intn(len(teams))]
+	g.Bots[bot.Seat] = bot
+	return nil
 }
 
 // IsReady 게임 시작 준비 확인
 func (g *NCGame) IsReady() bool {
-	return len(g.Players) == 2
+	return len(g.Players)+len(g.Bots) == 2
 }
 
-// Start 게임 시작
-func (g *NCGame) Start() {
+// Start 게임 시작 (이미 시작한 게임이면 에러)
+func (g *NCGame) Start() error {
+	if err := g.transition(NCPhaseSubmitting); err != nil {
+		return err
+	}
 	g.Ready = true
-	// 랜덤으로 시작 팀 결정
+	// 지정된 팀이 없으면 랜덤으로 시작 팀 결정
 	rand.Seed(time.Now().UnixNano())
-	if rand.Intn(2) == 0 {
+	if g.FirstTeamPreset != "" {
+		g.CurrentTeam = g.FirstTeamPreset
+	} else if rand.Intn(2) == 0 {
 		g.CurrentTeam = Team1
 	} else {
 		g.CurrentTeam = Team2
 	}
+	g.FirstTeam = g.CurrentTeam
+	g.StartedAt = time.Now()
 	log.Printf("[NC Game %s] Started - First team: %s", g.ID, g.CurrentTeam)
+	return nil
 }
 
 // SubmitBlocks 블록 제출
-func (g *NCGame) SubmitBlocks(team TeamColor, block1, block2 int, useHidden bool, selectedBlockChoice int) error {
+func (g *NCGame) SubmitBlocks(team TeamColor, blocks []int, useHidden bool, selectedBlockChoice int) error {
+	// 제출 단계에서 라운드마다 한 번만
+	if err := g.expectPhase("블록을 제출할", NCPhaseSubmitting, NCPhaseOvertime); err != nil {
+		return err
+	}
+	if g.RoundSubmits[team] != nil {
+		return errors.New("이번 라운드 블록을 이미 제출했습니다")
+	}
+
 	// 유효성 검사
-	if !g.hasBlock(team, block1) || !g.hasBlock(team, block2) {
-		return fmt.Errorf("invalid blocks")
+	if len(blocks) != g.Rules.BlocksPerSubmit {
+		return fmt.Errorf("블록을 %d개 제출해야 합니다", g.Rules.BlocksPerSubmit)
+	}
+	if !g.hasBlocks(team, blocks) {
+		return errors.New("가지고 있지 않은 블록입니다")
 	}
 
 	// 히든 찬스 검사
-	if useHidden {
-		if team == Team1 && g.Team1UsedHidden {
-			return fmt.Errorf("hidden chance already used")
-		}
-		if team == Team2 && g.Team2UsedHidden {
-			return fmt.Errorf("hidden chance already used")
-		}
+	if useHidden && g.HiddenUsed[team] >= g.Rules.HiddenChances {
+		return errors.New("히든 찬스를 이미 모두 사용했습니다")
 	}
 
-	// 상대가 히든 사용 시 블록 선택 검사 (1 또는 2만 허용)
-	if selectedBlockChoice != 0 && selectedBlockChoice != 1 && selectedBlockChoice != 2 {
-		return fmt.Errorf("invalid block choice (must be 1 or 2)")
+	// 받을 블록 선택 검사 (1부터 제출 블록 수까지 허용)
+	if selectedBlockChoice < 0 || selectedBlockChoice > g.Rules.BlocksPerSubmit {
+		return fmt.Errorf("받을 블록은 1-%d 중에서 골라야 합니다", g.Rules.BlocksPerSubmit)
 	}
 
 	// 제출 저장
 	g.RoundSubmits[team] = &NCSubmit{
-		Block1:              block1,
-		Block2:              block2,
+		Blocks:              append([]int{}, blocks...),
 		UseHidden:           useHidden,
 		SelectedBlockChoice: selectedBlockChoice,
+		SubmittedAt:         time.Now(),
 	}
 
-	log.Printf("[NC Game %s] Team %s submitted blocks: %d, %d (hidden: %v, choice: %d)",
-		g.ID, team, block1, block2, useHidden, selectedBlockChoice)
+	log.Printf("[NC Game %s] Team %s submitted blocks: %v (hidden: %v, choice: %d)",
+		g.ID, team, blocks, useHidden, selectedBlockChoice)
 
 	return nil
 }
 
+// blockList 제출 블록 (blocks가 없으면 block1, block2 사용)
+func (p NCSubmitBlocksPayload) blockList() []int {
+	if len(p.Blocks) == 0 {
+		return []int{p.Block1, p.Block2}
+	}
+	return p.Blocks
+}
+
+// SelectBlock 블록 선택 단계에서 받을 블록 선택 (마지막으로 남은 선택이면 결과 처리 단계로 넘어감)
+func (g *NCGame) SelectBlock(team TeamColor, choice int) error {
+	if err := g.expectPhase("받을 블록을 고를", NCPhaseAwaitingSelection); err != nil {
+		return err
+	}
+	if !g.SelectionPending(team) {
+		return errors.New("받을 블록을 고를 차례가 아닙니다")
+	}
+	if choice < 1 || choice > g.Rules.BlocksPerSubmit {
+		return errors.New("잘못된 블록 선택입니다")
+	}
+	g.RoundSubmits[team].SelectedBlockChoice = choice
+
+	awaiting := []TeamColor{}
+	for _, t := range g.AwaitingTeams {
+		if t != team {
+			awaiting = append(awaiting, t)
+		}
+	}
+	g.AwaitingTeams = awaiting
+	if len(awaiting) == 0 {
+		return g.transition(NCPhaseResolving)
+	}
+	return nil
+}
+
 // ProcessRound 라운드 처리
 func (g *NCGame) ProcessRound() (*NCRoundResultPayload, error) {
-	// 양 팀이 모두 제출했는지 확인
-	if len(g.RoundSubmits) != 2 {
-		return nil, fmt.Errorf("waiting for submissions")
+	// 제출과 블록 선택이 모두 끝난 라운드만 처리
+	if g.Phase != NCPhaseResolving {
+		return nil, fmt.Errorf("%d라운드 결과를 아직 처리할 수 없습니다 (단계: %s)", g.CurrentRound, g.Phase)
 	}
 
 	team1Submit := g.RoundSubmits[Team1]
 	team2Submit := g.RoundSubmits[Team2]
 
+	// 블록 교환 로직 (받을 블록을 정할 수 없으면 아무 상태도 바꾸지 않음)
+	team1ReceivedBlock, err := g.receivedBlock(Team1)
+	if err != nil {
+		return nil, err
+	}
+	team2ReceivedBlock, err := g.receivedBlock(Team2)
+	if err != nil {
+		return nil, err
+	}
+
 	// 합계 계산
-	team1Total := team1Submit.Block1 + team1Submit.Block2
-	team2Total := team2Submit.Block1 + team2Submit.Block2
+	team1Total := sumBlocks(team1Submit.Blocks)
+	team2Total := sumBlocks(team2Submit.Blocks)
 
 	// 승자 결정
 	var winner TeamColor
@@ -128,54 +189,15 @@ func (g *NCGame) ProcessRound() (*NCRoundResultPayload, error) {
 
 	// 히든 찬스 사용 기록
 	if team1Submit.UseHidden {
-		g.Team1UsedHidden = true
+		g.HiddenUsed[Team1]++
 	}
 	if team2Submit.UseHidden {
-		g.Team2UsedHidden = true
-	}
-
-	// 블록 교환 로직
-	var team1ReceivedBlock, team2ReceivedBlock int
-
-	// 레드가 히든 사용 시 블루이 선택한 블록 받기, 아니면 블루이 더 큰 블록 받기
-	if team2Submit.UseHidden {
-		// 레드가 히든 사용 -> 블루이 레드의 블록1 또는 블록2 중 선택
-		if team1Submit.SelectedBlockChoice == 0 {
-			return nil, fmt.Errorf("team1 must select a block (opponent used hidden)")
-		}
-		if team1Submit.SelectedBlockChoice == 1 {
-			team1ReceivedBlock = team2Submit.Block1
-		} else if team1Submit.SelectedBlockChoice == 2 {
-			team1ReceivedBlock = team2Submit.Block2
-		} else {
-			return nil, fmt.Errorf("invalid block choice")
-		}
-	} else {
-		// 레드가 히든 사용 안함 -> 블루이 레드의 더 큰 블록 받기
-		team1ReceivedBlock = max(team2Submit.Block1, team2Submit.Block2)
-	}
-
-	// 블루이 히든 사용 시 레드가 선택한 블록 받기, 아니면 레드가 더 큰 블록 받기
-	if team1Submit.UseHidden {
-		// 블루이 히든 사용 -> 레드가 블루의 블록1 또는 블록2 중 선택
-		if team2Submit.SelectedBlockChoice == 0 {
-			return nil, fmt.Errorf("team2 must select a block (opponent used hidden)")
-		}
-		if team2Submit.SelectedBlockChoice == 1 {
-			team2ReceivedBlock = team1Submit.Block1
-		} else if team2Submit.SelectedBlockChoice == 2 {
-			team2ReceivedBlock = team1Submit.Block2
-		} else {
-			return nil, fmt.Errorf("invalid block choice")
-		}
-	} else {
-		// 블루이 히든 사용 안함 -> 레드가 블루의 더 큰 블록 받기
-		team2ReceivedBlock = max(team1Submit.Block1, team1Submit.Block2)
+		g.HiddenUsed[Team2]++
 	}
 
 	// 각 팀의 블록에서 제출한 블록 제거
-	g.removeBlocks(Team1, team1Submit.Block1, team1Submit.Block2)
-	g.removeBlocks(Team2, team2Submit.Block1, team2Submit.Block2)
+	g.removeBlocks(Team1, team1Submit.Blocks)
+	g.removeBlocks(Team2, team2Submit.Blocks)
 
 	// 교환된 블록 추가
 	g.AvailableBlocks[Team1] = append(g.AvailableBlocks[Team1], team1ReceivedBlock)
@@ -184,26 +206,31 @@ func (g *NCGame) ProcessRound() (*NCRoundResultPayload, error) {
 	// 라운드 히스토리 저장
 	history := NCRoundHistory{
 		Round:             g.CurrentRound,
-		Team1Block1:       team1Submit.Block1,
-		Team1Block2:       team1Submit.Block2,
+		Team1Block1:       team1Submit.Blocks[0],
+		Team1Block2:       team1Submit.Blocks[1],
 		Team1Total:        team1Total,
-		Team2Block1:       team2Submit.Block1,
-		Team2Block2:       team2Submit.Block2,
+		Team2Block1:       team2Submit.Blocks[0],
+		Team2Block2:       team2Submit.Blocks[1],
 		Team2Total:        team2Total,
 		Winner:            winner,
 		Team1Hidden:       team1Submit.UseHidden,
 		Team2Hidden:       team2Submit.UseHidden,
 		Team1ReceivedBlock: team1ReceivedBlock,
 		Team2ReceivedBlock: team2ReceivedBlock,
+		Team1Played:       team1Submit.Blocks,
+		Team2Played:       team2Submit.Blocks,
 	}
 	g.RoundHistory = append(g.RoundHistory, history)
+	g.recordSubmit(Team1, team1Submit)
+	g.recordSubmit(Team2, team2Submit)
 
 	log.Printf("[NC Game %s] Round %d result - Team1: %d, Team2: %d, Winner: %s",
 		g.ID, g.CurrentRound, team1Total, team2Total, winner)
 
-	// 다음 라운드 준비
+	// 다음 라운드 준비 (무승부 제안은 제안한 라운드 동안만 유효)
 	g.CurrentRound++
 	g.RoundSubmits = make(map[TeamColor]*NCSubmit)
+	g.DrawOffer = ""
 
 	// 다음 차례는 반대 팀
 	var nextTeam TeamColor
@@ -217,11 +244,11 @@ func (g *NCGame) ProcessRound() (*NCRoundResultPayload, error) {
 	// 결과 페이로드 생성 (nextTeam 포함)
 	result := &NCRoundResultPayload{
 		Round:             g.CurrentRound - 1, // 방금 끝난 라운드 번호
-		Team1Block1:       team1Submit.Block1,
-		Team1Block2:       team1Submit.Block2,
+		Team1Block1:       team1Submit.Blocks[0],
+		Team1Block2:       team1Submit.Blocks[1],
 		Team1Total:        team1Total,
-		Team2Block1:       team2Submit.Block1,
-		Team2Block2:       team2Submit.Block2,
+		Team2Block1:       team2Submit.Blocks[0],
+		Team2Block2:       team2Submit.Blocks[1],
 		Team2Total:        team2Total,
 		Winner:            winner,
 		Team1Score:        g.Team1Score,
@@ -230,30 +257,63 @@ func (g *NCGame) ProcessRound() (*NCRoundResultPayload, error) {
 		Team2Hidden:       team2Submit.UseHidden,
 		Team1ReceivedBlock: team1ReceivedBlock,
 		Team2ReceivedBlock: team2ReceivedBlock,
+		Team1Played:       team1Submit.Blocks,
+		Team2Played:       team2Submit.Blocks,
 		NextTeam:          nextTeam,
 	}
 
 	return result, nil
 }
 
+// recordSubmit 기보에 팀 제출 기록 (블록 선택까지 끝난 최종 제출)
+func (g *NCGame) recordSubmit(team TeamColor, submit *NCSubmit) {
+	g.Submissions = append(g.Submissions, NCSubmitRecord{
+		Round:               g.CurrentRound,
+		Team:                team,
+		Blocks:              submit.Blocks,
+		UseHidden:           submit.UseHidden,
+		SelectedBlockChoice: submit.SelectedBlockChoice,
+		At:                  submit.SubmittedAt,
+		Auto:                submit.Auto,
+	})
+}
+
 // IsGameOver 게임 종료 확인
 func (g *NCGame) IsGameOver() (bool, string) {
-	// 7점 먼저 획득
-	if g.Team1Score >= 7 {
-		return true, "score_limit"
+	// 연장전: 먼저 라운드를 이기면 종료, 블록이 부족해 더 제출할 수 없어도 종료
+	if g.Overtime {
+		if g.Team1Score != g.Team2Score || !g.canSubmit(Team1) || !g.canSubmit(Team2) {
+			return true, reasonOvertime
+		}
+		return false, ""
+	}
+
+	// 목표 점수 먼저 획득
+	if g.Team1Score >= g.Rules.ScoreTarget {
+		return true, reasonScoreLimit
 	}
-	if g.Team2Score >= 7 {
-		return true, "score_limit"
+	if g.Team2Score >= g.Rules.ScoreTarget {
+		return true, reasonScoreLimit
 	}
 
-	// 12라운드 완료
-	if g.CurrentRound > 12 {
-		return true, "rounds_complete"
+	// 모든 라운드 완료 (동점이면 연장전으로)
+	if g.CurrentRound > g.Rules.MaxRounds && g.Team1Score != g.Team2Score {
+		return true, reasonRoundsComplete
 	}
 
 	return false, ""
 }
 
+// EnterOvertime 마지막 라운드까지 동점이면 연장전 시작
+func (g *NCGame) EnterOvertime() bool {
+	if g.Overtime || g.CurrentRound <= g.Rules.MaxRounds || g.Team1Score != g.Team2Score {
+		return false
+	}
+	g.Overtime = true
+	log.Printf("[NC Game %s] Tied %d-%d after round %d, entering overtime", g.ID, g.Team1Score, g.Team2Score, g.Rules.MaxRounds)
+	return true
+}
+
 // GetWinner 승자 결정
 func (g *NCGame) GetWinner() TeamColor {
 	if g.Team1Score > g.Team2Score {
@@ -261,33 +321,149 @@ func (g *NCGame) GetWinner() TeamColor {
 	} else if g.Team2Score > g.Team1Score {
 		return Team2
 	}
+
+	// 연장전 중 블록이 떨어지면 남은 블록 합계가 큰 팀 승리
+	if g.Overtime {
+		team1Left := sumBlocks(g.AvailableBlocks[Team1])
+		team2Left := sumBlocks(g.AvailableBlocks[Team2])
+		if team1Left > team2Left {
+			return Team1
+		} else if team2Left > team1Left {
+			return Team2
+		}
+	}
 	return "" // 무승부
 }
 
-// hasBlock 팀이 해당 블록을 가지고 있는지 확인
-func (g *NCGame) hasBlock(team TeamColor, block int) bool {
-	for _, b := range g.AvailableBlocks[team] {
-		if b == block {
+// canSubmit 팀에게 한 라운드를 제출할 만큼 블록이 남아있는지 확인
+func (g *NCGame) canSubmit(team TeamColor) bool {
+	return len(g.AvailableBlocks[team]) >= g.Rules.BlocksPerSubmit
+}
+
+// UsedHidden 팀이 히든 찬스를 이미 사용했는지 확인 (viewer에게 보이는 이번 라운드 제출 포함)
+func (g *NCGame) UsedHidden(team, viewer TeamColor) bool {
+	return g.HiddenUsed[team] > 0 || g.pendingHidden(team, viewer)
+}
+
+// HiddenLeft 팀에게 남은 히든 찬스 횟수 (viewer에게 보이는 이번 라운드 제출 포함)
+func (g *NCGame) HiddenLeft(team, viewer TeamColor) int {
+	left := g.Rules.HiddenChances - g.HiddenUsed[team]
+	if g.pendingHidden(team, viewer) {
+		left--
+	}
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// pendingHidden 팀이 이번 라운드 제출에서 히든 찬스를 썼고 viewer가 그 사실을 알 수 있는지
+// 상대 팀과 관전자는 제출이 마감되어 히든 사용을 알린 뒤(블록 선택, 결과 처리 단계)에만 알 수 있다
+func (g *NCGame) pendingHidden(team, viewer TeamColor) bool {
+	submit := g.RoundSubmits[team]
+	if submit == nil || !submit.UseHidden {
+		return false
+	}
+	return team == viewer || g.Phase == NCPhaseAwaitingSelection || g.Phase == NCPhaseResolving
+}
+
+// SelectionPending 블록 선택 단계에서 받을 블록 선택이 남아있는지 확인
+func (g *NCGame) SelectionPending(team TeamColor) bool {
+	if g.Phase != NCPhaseAwaitingSelection {
+		return false
+	}
+	for _, t := range g.AwaitingTeams {
+		if t == team {
 			return true
 		}
 	}
 	return false
 }
 
-// removeBlocks 블록 제거 (한 번만)
-func (g *NCGame) removeBlocks(team TeamColor, block1, block2 int) {
-	blocks := g.AvailableBlocks[team]
-	newBlocks := []int{}
-	removed1 := false
-	removed2 := false
+// PendingSelections 블록 선택이 남아있는 팀 목록
+func (g *NCGame) PendingSelections() []TeamColor {
+	if g.Phase != NCPhaseAwaitingSelection {
+		return []TeamColor{}
+	}
+	return append([]TeamColor{}, g.AwaitingTeams...)
+}
 
+// SmallestBlocks 남은 블록 중 가장 작은 블록들 (제출 블록 수만큼)
+func (g *NCGame) SmallestBlocks(team TeamColor) []int {
+	blocks := append([]int{}, g.AvailableBlocks[team]...)
+	sort.Ints(blocks)
+	if len(blocks) < g.Rules.BlocksPerSubmit {
+		return nil
+	}
+	return blocks[:g.Rules.BlocksPerSubmit]
+}
+
+// DefaultSelection 직접 선택하지 않았을 때 기본 선택 (가장 큰 블록)
+func (g *NCGame) DefaultSelection(team TeamColor) int {
+	opponentSubmit := g.RoundSubmits[opponentTeam(team)]
+	if opponentSubmit == nil {
+		return 1
+	}
+	return largestBlockIndex(opponentSubmit.Blocks) + 1
+}
+
+// mustSelect 상대 제출에 대해 받을 블록을 직접 골라야 하는지 확인
+func (g *NCGame) mustSelect(opponentSubmit *NCSubmit) bool {
+	return opponentSubmit.UseHidden || g.Rules.Exchange == ExchangeChosen
+}
+
+// receivedBlock 팀이 이번 라운드에 상대에게서 받는 블록
+func (g *NCGame) receivedBlock(team TeamColor) (int, error) {
+	submit := g.RoundSubmits[team]
+	opponentSubmit := g.RoundSubmits[opponentTeam(team)]
+
+	// 상대가 히든을 사용했거나 선택 교환 규칙이면 선택한 블록, 아니면 가장 큰 블록
+	if !g.mustSelect(opponentSubmit) {
+		return opponentSubmit.Blocks[largestBlockIndex(opponentSubmit.Blocks)], nil
+	}
+	if submit.SelectedBlockChoice == 0 {
+		return 0, fmt.Errorf("%s 팀이 받을 블록을 고르지 않았습니다", team)
+	}
+	if submit.SelectedBlockChoice > len(opponentSubmit.Blocks) {
+		return 0, errors.New("잘못된 블록 선택입니다")
+	}
+	return opponentSubmit.Blocks[submit.SelectedBlockChoice-1], nil
+}
+
+// opponentTeam 상대 팀 반환
+func opponentTeam(team TeamColor) TeamColor {
+	if team == Team1 {
+		return Team2
+	}
+	return Team1
+}
+
+// hasBlocks 팀이 해당 블록들을 모두 가지고 있는지 확인 (같은 숫자는 개수까지 확인)
+func (g *NCGame) hasBlocks(team TeamColor, blocks []int) bool {
+	counts := make(map[int]int)
+	for _, b := range g.AvailableBlocks[team] {
+		counts[b]++
+	}
 	for _, b := range blocks {
-		if b == block1 && !removed1 {
-			removed1 = true
-			continue
+		if counts[b] == 0 {
+			return false
 		}
-		if b == block2 && !removed2 {
-			removed2 = true
+		counts[b]--
+	}
+	return true
+}
+
+// removeBlocks 블록 제거 (제출한 개수만큼만)
+func (g *NCGame) removeBlocks(team TeamColor, blocks []int) {
+	remove := make(map[int]int)
+	for _, b := range blocks {
+		remove[b]++
+	}
+
+	newBlocks := []int{}
+	for _, b := range g.AvailableBlocks[team] {
+		if remove[b] > 0 {
+			remove[b]--
 			continue
 		}
 		newBlocks = append(newBlocks, b)
@@ -296,6 +472,26 @@ func (g *NCGame) removeBlocks(team TeamColor, block1, block2 int) {
 	g.AvailableBlocks[team] = newBlocks
 }
 
+// sumBlocks 블록 합계
+func sumBlocks(blocks []int) int {
+	total := 0
+	for _, b := range blocks {
+		total += b
+	}
+	return total
+}
+
+// largestBlockIndex 가장 큰 블록의 위치 (같으면 앞쪽)
+func largestBlockIndex(blocks []int) int {
+	index := 0
+	for i, b := range blocks {
+		if b > blocks[index] {
+			index = i
+		}
+	}
+	return index
+}
+
 // max 두 수 중 큰 값 반환
 func max(a, b int) int {
 	if a > b {
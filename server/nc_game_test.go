@@ -0,0 +1,125 @@
+package server
+
+import "testing"
+
+func TestNCEnterOvertime(t *testing.T) {
+	tests := []struct {
+		name     string
+		round    int // 방금 끝난 라운드 다음 번호
+		score    [2]int
+		overtime bool // 이미 연장전인지
+		want     bool
+	}{
+		{name: "마지막 라운드 전 동점", round: 12, score: [2]int{5, 5}},
+		{name: "마지막 라운드 후 동점", round: 13, score: [2]int{5, 5}, want: true},
+		{name: "마지막 라운드 후 승부 남", round: 13, score: [2]int{6, 5}},
+		{name: "이미 연장전", round: 14, score: [2]int{5, 5}, overtime: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewNCGame("test", ClassicNCRules())
+			game.CurrentRound = tt.round
+			game.Team1Score, game.Team2Score = tt.score[0], tt.score[1]
+			game.Overtime = tt.overtime
+
+			if got := game.EnterOvertime(); got != tt.want {
+				t.Errorf("EnterOvertime = %v, want %v", got, tt.want)
+			}
+			if game.Overtime != (tt.want || tt.overtime) {
+				t.Errorf("Overtime = %v", game.Overtime)
+			}
+		})
+	}
+}
+
+func TestNCOvertimeEnds(t *testing.T) {
+	tests := []struct {
+		name       string
+		score      [2]int
+		team1      []int // 1팀에 남은 블록
+		team2      []int
+		wantOver   bool
+		wantWinner TeamColor
+	}{
+		{name: "연장전 라운드 승리", score: [2]int{6, 5}, team1: []int{1, 2}, team2: []int{3, 4}, wantOver: true, wantWinner: Team1},
+		{name: "동점이고 블록이 남음", score: [2]int{5, 5}, team1: []int{1, 2}, team2: []int{3, 4}},
+		{name: "블록이 떨어지면 남은 합계로 결정", score: [2]int{5, 5}, team1: []int{5}, team2: []int{3, 4}, wantOver: true, wantWinner: Team2},
+		{name: "남은 합계도 같으면 무승부", score: [2]int{5, 5}, team1: []int{7}, team2: []int{3, 4}, wantOver: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewNCGame("test", ClassicNCRules())
+			game.CurrentRound = 14
+			game.Overtime = true
+			game.Team1Score, game.Team2Score = tt.score[0], tt.score[1]
+			game.AvailableBlocks[Team1] = tt.team1
+			game.AvailableBlocks[Team2] = tt.team2
+
+			over, reason := game.IsGameOver()
+			if over != tt.wantOver {
+				t.Fatalf("IsGameOver = %v, want %v", over, tt.wantOver)
+			}
+			if !over {
+				return
+			}
+			if reason != reasonOvertime {
+				t.Errorf("reason = %q, want %q", reason, reasonOvertime)
+			}
+			if winner := game.GetWinner(); winner != tt.wantWinner {
+				t.Errorf("winner = %q, want %q", winner, tt.wantWinner)
+			}
+		})
+	}
+}
+
+// 마지막 라운드를 비기면 연장전으로 넘어가고 연장전 라운드를 이긴 팀이 승리한다
+func TestNCOvertimePlayedOut(t *testing.T) {
+	rules, err := resolveNCRuleSet("", &NCRuleSet{StartingBlocks: []int{1, 2, 3, 4}, ScoreTarget: 1, MaxRounds: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := NewNCGame("test", rules)
+	if err := game.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	play := func(team1, team2 []int) (bool, bool, string) {
+		t.Helper()
+		if err := game.SubmitBlocks(Team1, team1, false, 0); err != nil {
+			t.Fatalf("SubmitBlocks(Team1): %v", err)
+		}
+		if err := game.SubmitBlocks(Team2, team2, false, 0); err != nil {
+			t.Fatalf("SubmitBlocks(Team2): %v", err)
+		}
+		if _, err := game.CloseSubmissions(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := game.ProcessRound(); err != nil {
+			t.Fatal(err)
+		}
+		overtimeStarted, over, reason, err := game.NextRound()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return overtimeStarted, over, reason
+	}
+
+	// 1라운드 3-3 무승부: 라운드 제한에 닿았지만 동점이라 연장전
+	if overtimeStarted, over, _ := play([]int{1, 2}, []int{1, 2}); !overtimeStarted || over {
+		t.Fatalf("after tied last round: overtimeStarted=%v over=%v", overtimeStarted, over)
+	}
+	if game.Phase != NCPhaseOvertime {
+		t.Fatalf("phase = %s, want %s", game.Phase, NCPhaseOvertime)
+	}
+
+	// 연장전 라운드 7-5로 1팀 승리
+	overtimeStarted, over, reason := play([]int{3, 4}, []int{2, 3})
+	if overtimeStarted || !over || reason != reasonOvertime {
+		t.Fatalf("after overtime round: overtimeStarted=%v over=%v reason=%q", overtimeStarted, over, reason)
+	}
+	if game.Phase != NCPhaseFinished || game.GetWinner() != Team1 {
+		t.Errorf("phase %s winner %q, want %s %s", game.Phase, game.GetWinner(), NCPhaseFinished, Team1)
+	}
+}
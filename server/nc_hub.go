@@ -7,372 +7,302 @@ import (
 	"github.com/google/uuid"
 )
 
-type NCHub struct {
-	// 등록된 클라이언트
-	clients map[*NCClient]bool
-
-	// 게임 목록
-	games map[string]*NCGame
+// ncRoundFailed 라운드를 처리할 수 없어 게임을 취소할 때 양 팀에게 보내는 사유
+const ncRoundFailed = "라운드를 처리하지 못해 게임을 취소합니다"
 
-	// 대기 중인 게임
-	waitingGame *NCGame
+// NCHub 넘버체인지 허브 (공통 처리는 gameHub, 여기에는 넘버체인지 설정과 알림 형식만 둔다)
+type NCHub struct {
+	*gameHub[*NCGame]
+}
 
-	// 클라이언트로부터 받은 메시지
-	broadcast chan []byte
+func NewNCHub() *NCHub {
+	h := &NCHub{}
+	h.gameHub = newGameHub[*NCGame](h, GameNumberChange, []Seat{Team1, Team2}, "nc_", "[NC] ")
+	return h
+}
 
-	// 클라이언트 등록
-	register chan *NCClient
+// ==================== Game Hooks ====================
 
-	// 클라이언트 등록 해제
-	unregister chan *NCClient
+// joinSetup 참가 요청의 프리셋 규칙
+func (h *NCHub) joinSetup(data []byte) (gameSetup, error) {
+	var payload NCJoinGamePayload
+	json.Unmarshal(data, &payload)
 
-	// 게임 메시지
-	gameMessage chan NCGameMessage
+	rules, err := NCRuleSetByName(payload.RuleSet)
+	if err != nil {
+		return gameSetup{}, err
+	}
+	return gameSetup{Seat: payload.Team, RuleSet: rules.Name, Settings: rules}, nil
 }
 
-type NCGameMessage struct {
-	Client  *NCClient
-	Message NCMessage
-}
+// roomSetup 방 만들기 요청의 규칙 (직접 지정한 규칙 허용)
+func (h *NCHub) roomSetup(data []byte) (gameSetup, error) {
+	var payload NCCreateRoomPayload
+	json.Unmarshal(data, &payload)
 
-func NewNCHub() *NCHub {
-	return &NCHub{
-		broadcast:   make(chan []byte),
-		register:    make(chan *NCClient),
-		unregister:  make(chan *NCClient),
-		clients:     make(map[*NCClient]bool),
-		games:       make(map[string]*NCGame),
-		gameMessage: make(chan NCGameMessage),
+	rules, err := resolveNCRuleSet(payload.RuleSet, payload.Rules)
+	if err != nil {
+		return gameSetup{}, err
 	}
+	return gameSetup{Seat: payload.Team, RuleSet: rules.Name, Settings: rules}, nil
 }
 
-func (h *NCHub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.clients[client] = true
-			log.Printf("[NC] Client registered: %s", client.ID)
-
-		case client := <-h.unregister:
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.Send)
-				h.handleDisconnect(client)
-				log.Printf("[NC] Client unregistered: %s", client.ID)
-			}
-
-		case message := <-h.gameMessage:
-			h.handleGameMessage(message)
-		}
+// configureRoom 방 설정 변경 요청의 규칙 반영
+func (h *NCHub) configureRoom(current interface{}, data []byte) (interface{}, error) {
+	var payload NCConfigureRoomPayload
+	json.Unmarshal(data, &payload)
+
+	if payload.RuleSet == "" && payload.Rules == nil {
+		return current, nil
+	}
+	rules, err := resolveNCRuleSet(payload.RuleSet, payload.Rules)
+	if err != nil {
+		return nil, err
 	}
+	return rules, nil
 }
 
-func (h *NCHub) handleDisconnect(client *NCClient) {
-	if client.GameID != "" {
-		game := h.games[client.GameID]
-		if game != nil {
-			// 상대방에게 알림
-			for team, player := range game.Players {
-				if player != nil && player.ID != client.ID {
-					h.sendToClient(player, NCMessage{
-						Type: NCMsgError,
-						Payload: NCErrorPayload{
-							Message: "상대방이 연결을 종료했습니다",
-						},
-					})
-					// 상대방도 게임에서 제거
-					delete(game.Players, team)
-				}
-			}
-			// 게임 삭제
-			delete(h.games, client.GameID)
-
-			// 대기 중인 게임이었다면 초기화
-			if h.waitingGame != nil && h.waitingGame.ID == client.GameID {
-				h.waitingGame = nil
-			}
-		}
+// roomPayload 참가자 시점의 방 상태
+func (h *NCHub) roomPayload(room *Room, viewer Seat) interface{} {
+	owner, names := room.seatNames()
+	return NCRoomPayload{
+		Code:        room.Code,
+		YourTeam:    viewer,
+		OwnerTeam:   owner,
+		Team1Name:   names[Team1],
+		Team2Name:   names[Team2],
+		HasPassword: room.Password != "",
+		Rules:       room.Settings.(NCRuleSet),
+		BestOf:      room.BestOf,
+		ExpiresAt:   room.ExpiresAt.UnixMilli(),
 	}
 }
 
-func (h *NCHub) handleGameMessage(gm NCGameMessage) {
-	switch gm.Message.Type {
-	case NCMsgJoinGame:
-		h.handleJoinGame(gm.Client, gm.Message)
-	case NCMsgSubmitBlocks:
-		h.handleSubmitBlocks(gm.Client, gm.Message)
-	case NCMsgSelectBlock:
-		h.handleSelectBlock(gm.Client, gm.Message)
-	}
+// newGame 규칙으로 새 게임 생성
+func (h *NCHub) newGame(settings interface{}) *NCGame {
+	return NewNCGame(uuid.New().String(), settings.(NCRuleSet))
 }
 
-func (h *NCHub) handleJoinGame(client *NCClient, msg NCMessage) {
-	payloadBytes, _ := json.Marshal(msg.Payload)
-	var payload NCJoinGamePayload
-	json.Unmarshal(payloadBytes, &payload)
+// rematchGame 끝난 게임과 같은 규칙, 제한 시간으로 새 게임 생성 (선공 팀은 그대로)
+func (h *NCHub) rematchGame(prev *NCGame) *NCGame {
+	game := NewNCGame(uuid.New().String(), prev.Rules)
+	game.SubmitLimit = prev.SubmitLimit
+	game.SelectLimit = prev.SelectLimit
+	game.FirstTeamPreset = prev.FirstTeam
+	return game
+}
 
-	log.Printf("[NC] Player %s (%s) joining with team preference: %s", client.ID, payload.PlayerName, payload.Team)
+func (h *NCHub) newBot(level BotLevel) (*Bot, error) {
+	return NewNCBot("", level)
+}
 
-	// 플레이어 이름 저장
-	client.Name = payload.PlayerName
+func (h *NCHub) defaultBotLevel() BotLevel {
+	return defaultNCBotFallbackLevel
+}
 
-	var game *NCGame
+func (h *NCHub) playerJoined(seat Seat, gameID, sessionToken string) interface{} {
+	return map[string]interface{}{
+		"yourTeam":     seat,
+		"gameId":       gameID,
+		"sessionToken": sessionToken,
+	}
+}
 
-	// 대기 중인 게임이 있으면 참가, 없으면 새로 생성
-	if h.waitingGame == nil {
-		gameID := uuid.New().String()
-		game = NewNCGame(gameID)
-		h.waitingGame = game
-		h.games[game.ID] = game
-		log.Printf("[NC] Created new game %s", game.ID)
-	} else {
-		game = h.waitingGame
-		h.waitingGame = nil // 게임이 가득 찼으므로 대기 게임 초기화
-		log.Printf("[NC] Joining existing game %s", game.ID)
+// begin 첫 라운드 제출 마감을 시작하고 양 팀에게 게임 시작 알림
+func (h *NCHub) begin(game *NCGame) {
+	h.startDeadline(game)
+
+	for playerTeam, player := range game.Players {
+		h.sendToClient(player, Message{
+			Type: NCMsgGameStart,
+			Payload: NCGameStartPayload{
+				YourTeam:    playerTeam,
+				FirstTeam:   game.CurrentTeam,
+				Team1Name:   game.PlayerName(Team1),
+				Team2Name:   game.PlayerName(Team2),
+				Rules:       game.Rules,
+				Team1Blocks: game.AvailableBlocks[Team1],
+				Team2Blocks: game.AvailableBlocks[Team2],
+				Deadline:    deadlineMillis(game.Deadline),
+				Rated:       game.Rated,
+				Series:      game.seriesScore(),
+				Phase:       game.Phase,
+			},
+		})
 	}
+}
 
-	client.GameID = game.ID
+func (h *NCHub) finish(game *NCGame) {
+	if err := game.Finish(); err != nil {
+		log.Printf("[NC] Error finishing game %s: %v", game.ID, err)
+	}
+	h.stopDeadline(game)
+}
 
-	// 플레이어 팀 배정
-	team := game.AddPlayer(client, payload.Team)
-	client.Team = team
+func (h *NCHub) gameOver(game *NCGame, winner Seat, reason string, ratings map[Seat]*RatingChange) interface{} {
+	return NCGameOverPayload{
+		Winner:     winner,
+		Team1Score: game.Team1Score,
+		Team2Score: game.Team2Score,
+		Reason:     reason,
+		Ratings:    ratings,
+		Series:     game.seriesScore(),
+		Phase:      game.Phase,
+	}
+}
 
-	log.Printf("[NC] Player %s (%s) joined as %s. Total players: %d", client.ID, payload.PlayerName, team, len(game.Players))
+func (h *NCHub) drawOffer(seat Seat) interface{} {
+	return NCDrawOfferPayload{Team: seat}
+}
 
-	// 플레이어에게 자신의 팀 알림
-	h.sendToClient(client, NCMessage{
-		Type: NCMsgPlayerJoined,
-		Payload: map[string]interface{}{
-			"yourTeam": team,
-			"gameId":   game.ID,
-		},
-	})
+func (h *NCHub) opponentStatus(seat Seat, grace int) interface{} {
+	return NCOpponentStatusPayload{Team: seat, GraceSeconds: grace}
+}
 
-	// 게임 시작 확인
-	if game.IsReady() && !game.Ready {
-		game.Start()
-		log.Printf("[NC] Game %s is ready! Starting game with %d players", game.ID, len(game.Players))
+func (h *NCHub) rematchNotice(seat Seat, reason string, expiresAt int64) interface{} {
+	return NCRematchPayload{Team: seat, Reason: reason, ExpiresAt: expiresAt}
+}
 
-		// 플레이어 이름 가져오기
-		team1Name := ""
-		team2Name := ""
-		if p := game.Players[Team1]; p != nil {
-			team1Name = p.Name
-		}
-		if p := game.Players[Team2]; p != nil {
-			team2Name = p.Name
-		}
+func (h *NCHub) storeRecord(store *RecordStore, game *NCGame, winner Seat, reason string, ratings map[Seat]*RatingChange) {
+	store.SaveNCGame(newNCGameRecord(game, winner, reason, ratings))
+}
 
-		// 두 플레이어 모두에게 게임 시작 알림
-		for playerTeam, player := range game.Players {
-			h.sendToClient(player, NCMessage{
-				Type: NCMsgGameStart,
-				Payload: NCGameStartPayload{
-					YourTeam:  playerTeam,
-					FirstTeam: game.CurrentTeam,
-					Team1Name: team1Name,
-					Team2Name: team2Name,
-				},
-			})
-		}
-	} else {
-		log.Printf("[NC] Game %s waiting for more players. Current: %d", game.ID, len(game.Players))
-		// 대기 중 메시지
-		h.sendToClient(client, NCMessage{
-			Type: NCMsgWaitingPlayer,
-			Payload: map[string]string{
-				"message": "상대방을 기다리는 중...",
-			},
-		})
+func (h *NCHub) loadReplay(store *RecordStore, gameID string) (*Replay, error) {
+	record, err := store.LoadNCGame(gameID)
+	if err != nil {
+		return nil, err
 	}
+	return h.buildReplay(record), nil
 }
 
-func (h *NCHub) handleSubmitBlocks(client *NCClient, msg NCMessage) {
-	game := h.games[client.GameID]
-	if game == nil {
-		h.sendToClient(client, NCMessage{
-			Type: NCMsgError,
-			Payload: NCErrorPayload{
-				Message: "게임을 찾을 수 없습니다",
-			},
-		})
-		return
+func (h *NCHub) handleMessage(client *Client, msg Message) {
+	switch msg.Type {
+	case NCMsgSubmitBlocks, NCMsgSelectBlock:
+		h.routeToGame(client, "게임을 찾을 수 없습니다", func(game *NCGame) { h.handleMove(game, client, msg) })
 	}
+}
 
+// ==================== Game Progress ====================
+
+// handleMove 블록 제출이나 받을 블록 선택을 엔진에 적용
+func (h *NCHub) handleMove(game *NCGame, client *Client, msg Message) {
 	payloadBytes, _ := json.Marshal(msg.Payload)
-	var payload NCSubmitBlocksPayload
-	json.Unmarshal(payloadBytes, &payload)
-
-	// 블록 제출
-	if err := game.SubmitBlocks(client.Team, payload.Block1, payload.Block2, payload.UseHidden, payload.SelectedBlockChoice); err != nil {
-		h.sendToClient(client, NCMessage{
-			Type: NCMsgError,
-			Payload: NCErrorPayload{
-				Message: err.Error(),
-			},
-		})
-		return
+	move := Move{Kind: string(msg.Type), Payload: payloadBytes}
+	if err := playMove(game, client.Seat, move, h.roundEvents(game)); err != nil {
+		h.sendError(client, err.Error())
 	}
+}
 
-	log.Printf("[NC] Team %s submitted blocks: %d, %d (hidden: %v, choice: %d)",
-		client.Team, payload.Block1, payload.Block2, payload.UseHidden, payload.SelectedBlockChoice)
-
-	// 히든 찬스 사용 시 상대방에게 알림
-	if payload.UseHidden {
-		var opponentTeam TeamColor
-		if client.Team == Team1 {
-			opponentTeam = Team2
-		} else {
-			opponentTeam = Team1
-		}
+// notifyHidden 히든 찬스 사용을 상대 팀과 관전자에게 알림 (제출을 마감해 단계가 정해진 뒤 호출)
+func (h *NCHub) notifyHidden(game *NCGame, team TeamColor) {
+	notice := game.hiddenNotice(team)
 
-		if opponentClient := game.Players[opponentTeam]; opponentClient != nil {
-			h.sendToClient(opponentClient, NCMessage{
-				Type: NCMsgUseHidden,
-				Payload: map[string]interface{}{
-					"team": client.Team,
-				},
-			})
-			log.Printf("[NC] Notified %s that %s used hidden chance", opponentTeam, client.Team)
-		}
+	opponent := opponentTeam(team)
+	if opponentClient := game.Players[opponent]; opponentClient != nil {
+		h.sendToClient(opponentClient, notice)
+		log.Printf("[NC] Notified %s that %s used hidden chance", opponent, team)
 	}
+	h.broadcastToSpectators(game, notice)
+}
 
-	// 양 팀이 모두 제출했는지 확인
-	if len(game.RoundSubmits) == 2 {
-		// 어느 한 팀이라도 히든을 사용했는지 확인
-		team1Submit := game.RoundSubmits[Team1]
-		team2Submit := game.RoundSubmits[Team2]
-
-		anyoneUsedHidden := (team1Submit != nil && team1Submit.UseHidden) || (team2Submit != nil && team2Submit.UseHidden)
-
-		// 누군가 히든을 사용했다면, 상대방의 블록 선택을 기다려야 함
-		if anyoneUsedHidden {
-			log.Printf("[NC] Someone used hidden, waiting for block selection in handleSelectBlock")
-			return
-		}
+// hiddenNotice team의 히든 찬스 사용 알림 (지금 단계와 블록 선택이 남은 팀 포함)
+func (g *NCGame) hiddenNotice(team TeamColor) Message {
+	return Message{
+		Type: NCMsgUseHidden,
+		Payload: NCUseHiddenPayload{
+			Team:          team,
+			Phase:         g.Phase,
+			AwaitingTeams: g.PendingSelections(),
+		},
+	}
+}
 
-		// 라운드 처리 (둘 다 히든을 사용하지 않은 경우만)
-		result, err := game.ProcessRound()
-		if err != nil {
-			log.Printf("[NC] Error processing round: %v", err)
-			return
-		}
+// roundEvents 넘버체인지 엔진 진행을 양 팀과 관전자에게 알리는 방법
+func (h *NCHub) roundEvents(game *NCGame) engineEvents {
+	return engineEvents{
+		moved: func(seat Seat, move Move) {
+			h.closeSubmissions(game)
+		},
+		resolved: func(result interface{}, over bool) {
+			h.announceRound(game, result.(ncRoundResolution), over)
+		},
+		over: func(winner Seat, reason string) {
+			h.endGame(game, winner, reason)
+		},
+		// 처리할 수 없는 라운드면 멈춘 채 두지 않고 게임을 취소
+		failed: func(err error) {
+			log.Printf("[NC] Error resolving round in game %s: %v", game.ID, err)
+			h.abortGame(game, ncRoundFailed)
+		},
+	}
+}
 
-		// 라운드 결과 전송
-		h.broadcastToGame(game, NCMessage{
-			Type:    NCMsgRoundResult,
-			Payload: result,
-		})
+// advanceRound 엔진 밖에서 둔 수(마감 시간 초과) 뒤에 제출을 마감하고, 선택까지 끝났으면 라운드 처리
+func (h *NCHub) advanceRound(game *NCGame) {
+	h.closeSubmissions(game)
+	advanceEngine(game, h.roundEvents(game))
+}
 
-		// 게임 종료 확인
-		isOver, reason := game.IsGameOver()
-		if isOver {
-			winner := game.GetWinner()
-			h.broadcastToGame(game, NCMessage{
-				Type: NCMsgGameOver,
-				Payload: NCGameOverPayload{
-					Winner:     winner,
-					Team1Score: game.Team1Score,
-					Team2Score: game.Team2Score,
-					Reason:     reason,
-				},
-			})
-
-			// 게임 종료 처리
-			delete(h.games, game.ID)
-			log.Printf("[NC] Game %s ended. Winner: %s, Reason: %s", game.ID, winner, reason)
-		}
+// closeSubmissions 양 팀 제출이 끝났으면 제출을 마감하고 히든 찬스 사용과 블록 선택 단계 알림
+func (h *NCHub) closeSubmissions(game *NCGame) {
+	if !game.acceptingSubmits() || len(game.RoundSubmits) != 2 {
+		return
 	}
-}
 
-func (h *NCHub) handleSelectBlock(client *NCClient, msg NCMessage) {
-	game := h.games[client.GameID]
-	if game == nil {
-		h.sendToClient(client, NCMessage{
-			Type: NCMsgError,
-			Payload: NCErrorPayload{
-				Message: "게임을 찾을 수 없습니다",
-			},
-		})
+	// 누군가 히든을 사용했다면, 상대방의 블록 선택을 기다려야 함
+	pending, err := game.CloseSubmissions()
+	if err != nil {
+		log.Printf("[NC] Error closing submissions: %v", err)
 		return
 	}
 
-	payloadBytes, _ := json.Marshal(msg.Payload)
-	var payload NCSelectBlockPayload
-	json.Unmarshal(payloadBytes, &payload)
-
-	// 이미 제출한 상태에서 블록 선택 업데이트
-	if submit := game.RoundSubmits[client.Team]; submit != nil {
-		submit.SelectedBlockChoice = payload.SelectedBlockChoice
-		log.Printf("[NC] Team %s updated block choice: %d", client.Team, payload.SelectedBlockChoice)
-
-		// 양 팀이 모두 제출했는지 확인
-		if len(game.RoundSubmits) == 2 {
-			// 상대가 히든을 사용했는지 확인
-			var opponentTeam TeamColor
-			if client.Team == Team1 {
-				opponentTeam = Team2
-			} else {
-				opponentTeam = Team1
-			}
-
-			opponentSubmit := game.RoundSubmits[opponentTeam]
-			if opponentSubmit != nil && opponentSubmit.UseHidden {
-				// 블록 선택이 완료되었으므로 라운드 처리
-				result, err := game.ProcessRound()
-				if err != nil {
-					log.Printf("[NC] Error processing round: %v", err)
-					return
-				}
-
-				// 라운드 결과 전송
-				h.broadcastToGame(game, NCMessage{
-					Type:    NCMsgRoundResult,
-					Payload: result,
-				})
-
-				// 게임 종료 확인
-				isOver, reason := game.IsGameOver()
-				if isOver {
-					winner := game.GetWinner()
-					h.broadcastToGame(game, NCMessage{
-						Type: NCMsgGameOver,
-						Payload: NCGameOverPayload{
-							Winner:     winner,
-							Team1Score: game.Team1Score,
-							Team2Score: game.Team2Score,
-							Reason:     reason,
-						},
-					})
-
-					// 게임 종료 처리
-					delete(h.games, game.ID)
-					log.Printf("[NC] Game %s ended. Winner: %s, Reason: %s", game.ID, winner, reason)
-				}
-			}
+	// 히든 찬스 사용은 제출이 모두 끝나 블록 선택 단계가 정해진 뒤 알림
+	for _, team := range []TeamColor{Team1, Team2} {
+		if game.RoundSubmits[team].UseHidden {
+			h.notifyHidden(game, team)
 		}
 	}
+	if len(pending) > 0 {
+		log.Printf("[NC] Someone used hidden, waiting for block selection from %v", pending)
+		h.startDeadline(game)
+		h.broadcastPublic(game, Message{
+			Type: NCMsgSelectionPhase,
+			Payload: NCSelectionPhasePayload{
+				Round:        game.CurrentRound,
+				PendingTeams: pending,
+				Deadline:     deadlineMillis(game.Deadline),
+				Phase:        game.Phase,
+			},
+		})
+	}
 }
 
-func (h *NCHub) sendToClient(client *NCClient, message NCMessage) {
-	data, err := json.Marshal(message)
-	if err != nil {
-		log.Printf("[NC] Error marshaling message: %v", err)
-		return
+// announceRound 라운드 결과 전송 (게임이 이어지면 다음 라운드 마감 시작, 연장전이 시작됐으면 알림)
+func (h *NCHub) announceRound(game *NCGame, round ncRoundResolution, over bool) {
+	result := round.Result
+	if over {
+		h.stopDeadline(game)
+	} else {
+		h.startDeadline(game)
+		result.Deadline = deadlineMillis(game.Deadline)
 	}
 
-	select {
-	case client.Send <- data:
-	default:
-		close(client.Send)
-		delete(h.clients, client)
-	}
-}
+	// 라운드 결과 전송
+	h.broadcastPublic(game, Message{
+		Type:    NCMsgRoundResult,
+		Payload: result,
+	})
 
-func (h *NCHub) broadcastToGame(game *NCGame, message NCMessage) {
-	for _, player := range game.Players {
-		if player != nil {
-			h.sendToClient(player, message)
-		}
+	if round.OvertimeStarted {
+		h.broadcastPublic(game, Message{
+			Type: NCMsgOvertimeStart,
+			Payload: NCOvertimeStartPayload{
+				Round:      game.CurrentRound,
+				Team1Score: game.Team1Score,
+				Team2Score: game.Team2Score,
+				Deadline:   result.Deadline,
+				Phase:      game.Phase,
+			},
+		})
 	}
 }
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ncTestGame 두 팀이 앉아 시작한 게임 (허브 루프 없이 처리 함수를 바로 호출)
+func ncTestGame(t *testing.T, rules NCRuleSet) (*NCHub, *NCGame, *Client, *Client) {
+	t.Helper()
+	h := NewNCHub()
+	game := NewNCGame("test", rules)
+	game.FirstTeamPreset = Team1
+
+	team1 := &Client{ID: "team1", Send: make(chan []byte, 64), GameID: game.ID, Seat: Team1}
+	team2 := &Client{ID: "team2", Send: make(chan []byte, 64), GameID: game.ID, Seat: Team2}
+	for _, c := range []*Client{team1, team2} {
+		h.addClient(c)
+		game.Players[c.Seat] = c
+	}
+	h.games[game.ID] = game
+
+	if err := game.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	// 마감 타이머가 게임 고루틴에 일을 넘길 수 있게 함 (테스트는 처리 함수를 직접 호출)
+	game.actor = startGameActor()
+	t.Cleanup(func() {
+		h.stopDeadline(game)
+		game.actor.stop()
+	})
+	return h, game, team1, team2
+}
+
+// sentNC 클라이언트가 받은 메시지 (payload는 원본 그대로)
+type sentNC struct {
+	Type    MessageType     `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ncSent 지금까지 client에게 보낸 메시지
+func ncSent(t *testing.T, client *Client) []sentNC {
+	t.Helper()
+	var sent []sentNC
+	for {
+		select {
+		case data := <-client.Send:
+			var msg sentNC
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("unmarshal %s: %v", data, err)
+			}
+			sent = append(sent, msg)
+		default:
+			return sent
+		}
+	}
+}
+
+// stopStartedNCGame 허브가 시작한 게임의 고루틴과 마감 타이머 정리
+func stopStartedNCGame(t *testing.T, h *NCHub, game *NCGame) {
+	t.Cleanup(func() {
+		done := make(chan struct{})
+		game.actor.post(func() {
+			h.stopDeadline(game)
+			close(done)
+		})
+		<-done
+		game.actor.stop()
+	})
+}
+
+func submit(blocks []int, useHidden bool) Message {
+	return Message{Type: NCMsgSubmitBlocks, Payload: NCSubmitBlocksPayload{Blocks: blocks, UseHidden: useHidden}}
+}
+
+func TestNCUseHiddenNotice(t *testing.T) {
+	tests := []struct {
+		name         string
+		team1Hidden  bool
+		team2Hidden  bool
+		wantAwaiting []TeamColor
+	}{
+		{name: "1팀만 히든", team1Hidden: true, wantAwaiting: []TeamColor{Team2}},
+		{name: "2팀만 히든", team2Hidden: true, wantAwaiting: []TeamColor{Team1}},
+		{name: "두 팀 모두 히든", team1Hidden: true, team2Hidden: true, wantAwaiting: []TeamColor{Team1, Team2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+
+			h.handleMove(game, team1, submit([]int{1, 2}, tt.team1Hidden))
+			for _, msg := range append(ncSent(t, team1), ncSent(t, team2)...) {
+				if msg.Type == NCMsgUseHidden {
+					t.Fatal("hidden chance announced before both teams submitted")
+				}
+			}
+
+			h.handleMove(game, team2, submit([]int{3, 4}, tt.team2Hidden))
+
+			// 히든 사용은 상대 팀에게만 알림
+			for _, c := range []struct {
+				client *Client
+				hidden bool // 상대 팀이 히든을 썼는지
+			}{{team1, tt.team2Hidden}, {team2, tt.team1Hidden}} {
+				var notices []NCUseHiddenPayload
+				for _, msg := range ncSent(t, c.client) {
+					if msg.Type != NCMsgUseHidden {
+						continue
+					}
+					var notice NCUseHiddenPayload
+					if err := json.Unmarshal(msg.Payload, &notice); err != nil {
+						t.Fatal(err)
+					}
+					notices = append(notices, notice)
+				}
+
+				if !c.hidden {
+					if len(notices) != 0 {
+						t.Errorf("%s got %d hidden notices, want none", c.client.Seat, len(notices))
+					}
+					continue
+				}
+				if len(notices) != 1 {
+					t.Fatalf("%s got %d hidden notices, want 1", c.client.Seat, len(notices))
+				}
+				notice := notices[0]
+				if notice.Team != opponentTeam(c.client.Seat) {
+					t.Errorf("notice team = %s, want %s", notice.Team, opponentTeam(c.client.Seat))
+				}
+				if notice.Phase != NCPhaseAwaitingSelection {
+					t.Errorf("notice phase = %s, want %s", notice.Phase, NCPhaseAwaitingSelection)
+				}
+				if !sameTeams(notice.AwaitingTeams, tt.wantAwaiting) {
+					t.Errorf("notice awaitingTeams = %v, want %v", notice.AwaitingTeams, tt.wantAwaiting)
+				}
+			}
+		})
+	}
+}
+
+func sameTeams(got, want []TeamColor) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// 처리할 수 없는 라운드는 결과 처리 단계에 멈춰 두지 않고 취소해 두 팀에게 알린다
+func TestNCRoundFailureAbortsGame(t *testing.T) {
+	h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+	go h.Run()
+
+	game.SubmitBlocks(Team1, []int{6, 7}, true, 0)
+	game.SubmitBlocks(Team2, []int{1, 2}, false, 0)
+	// 2팀이 받을 블록을 고르지 않은 채 결과 처리 단계로 넘어간 상황
+	game.transition(NCPhaseResolving)
+	h.advanceRound(game)
+
+	for _, c := range []*Client{team1, team2} {
+		var got string
+		for _, msg := range ncSent(t, c) {
+			if msg.Type == NCMsgError {
+				var payload ErrorPayload
+				json.Unmarshal(msg.Payload, &payload)
+				got = payload.Message
+			}
+		}
+		if got != ncRoundFailed {
+			t.Errorf("%s error = %q, want %q", c.Seat, got, ncRoundFailed)
+		}
+	}
+	if game.Phase != NCPhaseFinished {
+		t.Errorf("phase = %s, want %s", game.Phase, NCPhaseFinished)
+	}
+	var open bool
+	h.call(func() { open = h.games[game.ID] != nil })
+	if open {
+		t.Error("failed game is still open")
+	}
+}
+
+// 다른 게임을 관전하거나 다시보기를 보던 연결이 좌석으로 돌아오면 둘 다 그만둔다
+func TestNCResumeStopsSpectatingAndReplay(t *testing.T) {
+	h, game, team1, _ := ncTestGame(t, ClassicNCRules())
+	session := h.newSession(game, team1)
+	delete(game.Players, Team1)
+	session.Disconnected = true
+
+	watched := NewNCGame("watched", ClassicNCRules())
+	h.games[watched.ID] = watched
+
+	client := &Client{ID: "returning", Send: make(chan []byte, 64)}
+	h.addClient(client)
+	client.Spectating = watched.ID
+	watched.Spectators[client] = true
+	client.Replay = newReplay("old")
+	h.replays[client.Replay] = client
+	replay := client.Replay
+
+	h.handleResume(client, Message{Type: NCMsgResume, Payload: ResumePayload{SessionToken: session.Token}})
+	// 좌석으로 돌아가는 일은 게임 고루틴이 처리함
+	done := make(chan struct{})
+	game.actor.post(func() { close(done) })
+	<-done
+
+	if client.Spectating != "" || watched.Spectators[client] {
+		t.Error("resumed client is still spectating the other game")
+	}
+	if client.Replay != nil || h.replays[replay] != nil {
+		t.Error("resumed client is still watching a replay")
+	}
+	if game.Players[Team1] != client {
+		t.Error("resumed client did not take the seat")
+	}
+}
+
+// 재접속한 팀은 레이팅 프로필을 그대로 이어받아 다음 게임도 레이팅에 반영된다
+func TestNCResumeKeepsPlayerID(t *testing.T) {
+	h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+	h.players = testPlayerStore()
+	alice, _ := h.players.Identify("", "alice")
+	bob, _ := h.players.Identify("", "bob")
+	team1.PlayerID, team2.PlayerID = alice.ID, bob.ID
+	session := h.newSession(game, team1)
+	h.newSession(game, team2)
+	delete(game.Players, Team1)
+	session.Disconnected = true
+
+	client := &Client{ID: "returning", Send: make(chan []byte, 64)}
+	h.addClient(client)
+	h.handleResume(client, Message{Type: NCMsgResume, Payload: ResumePayload{SessionToken: session.Token}})
+	done := make(chan struct{})
+	game.actor.post(func() { close(done) })
+	<-done
+	if client.PlayerID != alice.ID {
+		t.Fatalf("resumed PlayerID = %q, want %q", client.PlayerID, alice.ID)
+	}
+
+	// 재접속한 연결로 재대결하면 레이팅 게임
+	game.Team1Score = game.Rules.ScoreTarget
+	h.openRematch(game, Team1)
+	h.handleRematchOffer(client)
+	h.handleRematchAccept(team2)
+	rematch := h.games[client.GameID]
+	if rematch == nil || rematch == game {
+		t.Fatal("rematch did not start a new game")
+	}
+	stopStartedNCGame(t, h, rematch)
+	if !rematch.Rated {
+		t.Error("rematch after resume is not rated")
+	}
+}
+
+// 상대 팀과 관전자는 제출이 마감되기 전에는 이번 라운드 히든 찬스 사용을 상태에서도 알 수 없다
+func TestNCStateHidesPendingHidden(t *testing.T) {
+	h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+	h.handleMove(game, team1, submit([]int{1, 2}, true))
+
+	tests := []struct {
+		name       string
+		viewer     TeamColor
+		wantUsed   bool
+		wantLeft   int
+		afterClose bool
+	}{
+		{name: "제출 중 관전자", viewer: "", wantUsed: false, wantLeft: 1},
+		{name: "제출 중 상대 팀", viewer: Team2, wantUsed: false, wantLeft: 1},
+		{name: "제출 중 본인 팀", viewer: Team1, wantUsed: true, wantLeft: 0},
+		{name: "블록 선택 중 관전자", viewer: "", wantUsed: true, wantLeft: 0, afterClose: true},
+		{name: "블록 선택 중 상대 팀", viewer: Team2, wantUsed: true, wantLeft: 0, afterClose: true},
+	}
+	for _, tt := range tests {
+		if tt.afterClose && game.Phase != NCPhaseAwaitingSelection {
+			h.handleMove(game, team2, submit([]int{6, 7}, false))
+			if game.Phase != NCPhaseAwaitingSelection {
+				t.Fatalf("phase = %s, want %s", game.Phase, NCPhaseAwaitingSelection)
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			state := game.State(tt.viewer)
+			if state.Team1UsedHidden != tt.wantUsed || state.Team1HiddenLeft != tt.wantLeft {
+				t.Errorf("team1 used hidden = %v, left = %d; want %v, %d",
+					state.Team1UsedHidden, state.Team1HiddenLeft, tt.wantUsed, tt.wantLeft)
+			}
+			if state.Team2UsedHidden || state.Team2HiddenLeft != 1 {
+				t.Errorf("team2 used hidden = %v, left = %d", state.Team2UsedHidden, state.Team2HiddenLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"log"
+)
+
+// ncPhaseTransitions 단계별로 넘어갈 수 있는 다음 단계
+// 한 라운드는 submitting(연장전이면 overtime) -> [awaiting_selection] -> resolving 순서로 진행하고,
+// 기권, 무승부, 연결 끊김으로는 어느 단계에서든 finished로 끝날 수 있다
+var ncPhaseTransitions = map[NCPhase][]NCPhase{
+	NCPhaseWaiting:           {NCPhaseSubmitting, NCPhaseFinished},
+	NCPhaseSubmitting:        {NCPhaseAwaitingSelection, NCPhaseResolving, NCPhaseFinished},
+	NCPhaseOvertime:          {NCPhaseAwaitingSelection, NCPhaseResolving, NCPhaseFinished},
+	NCPhaseAwaitingSelection: {NCPhaseResolving, NCPhaseFinished},
+	NCPhaseResolving:         {NCPhaseSubmitting, NCPhaseOvertime, NCPhaseFinished},
+}
+
+// ncPhaseBusy 단계에 맞지 않는 메시지를 거절할 때 앞에 붙이는 단계 설명
+var ncPhaseBusy = map[NCPhase]string{
+	NCPhaseWaiting:           "게임이 아직 시작되지 않아",
+	NCPhaseSubmitting:        "블록을 제출하는 단계라",
+	NCPhaseAwaitingSelection: "받을 블록을 고르는 단계라",
+	NCPhaseResolving:         "라운드 결과를 처리하는 중이라",
+	NCPhaseOvertime:          "연장전 블록을 제출하는 단계라",
+	NCPhaseFinished:          "게임이 이미 끝나",
+}
+
+// canMove 이 단계에서 to로 넘어갈 수 있는지
+func (p NCPhase) canMove(to NCPhase) bool {
+	for _, next := range ncPhaseTransitions[p] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition 다음 단계로 진행 (허용되지 않은 전환이면 단계를 바꾸지 않고 에러)
+// awaiting은 awaiting_selection 단계에서 받을 블록을 골라야 하는 팀
+func (g *NCGame) transition(to NCPhase, awaiting ...TeamColor) error {
+	if !g.Phase.canMove(to) {
+		log.Printf("[NC Game %s] Invalid phase transition %s -> %s", g.ID, g.Phase, to)
+		return fmt.Errorf("%s 단계에서 %s 단계로 넘어갈 수 없습니다", g.Phase, to)
+	}
+	g.Phase = to
+	g.AwaitingTeams = append([]TeamColor{}, awaiting...)
+	return nil
+}
+
+// expectPhase 지금 단계가 allowed 중 하나가 아니면 action을 할 수 없는 이유를 담은 에러
+// (예: "받을 블록을 고르는 단계라 블록을 제출할 수 없습니다")
+func (g *NCGame) expectPhase(action string, allowed ...NCPhase) error {
+	for _, phase := range allowed {
+		if g.Phase == phase {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %s 수 없습니다", ncPhaseBusy[g.Phase], action)
+}
+
+// acceptingSubmits 블록을 제출받는 단계인지 (연장전 포함)
+func (g *NCGame) acceptingSubmits() bool {
+	return g.Phase == NCPhaseSubmitting || g.Phase == NCPhaseOvertime
+}
+
+// CloseSubmissions 양 팀 제출이 끝난 라운드를 블록 선택 단계나 결과 처리 단계로 넘김
+// 받을 블록을 골라야 하는 팀이 있으면 그 팀 목록 반환 (상대가 히든을 썼거나 선택 교환 규칙이고 아직 고르지 않은 팀)
+func (g *NCGame) CloseSubmissions() ([]TeamColor, error) {
+	if !g.acceptingSubmits() || len(g.RoundSubmits) != 2 {
+		return nil, fmt.Errorf("%d라운드는 제출 마감을 기다리는 중이 아닙니다 (단계: %s)", g.CurrentRound, g.Phase)
+	}
+
+	pending := []TeamColor{}
+	for _, team := range []TeamColor{Team1, Team2} {
+		opponentSubmit := g.RoundSubmits[opponentTeam(team)]
+		if g.mustSelect(opponentSubmit) && g.RoundSubmits[team].SelectedBlockChoice == 0 {
+			pending = append(pending, team)
+		}
+	}
+
+	if len(pending) > 0 {
+		return pending, g.transition(NCPhaseAwaitingSelection, pending...)
+	}
+	return nil, g.transition(NCPhaseResolving)
+}
+
+// NextRound 처리한 라운드 뒤의 단계로 진행
+// 마지막 라운드까지 동점이면 연장전을 시작하고, 게임이 끝났으면 finished로 바꾸고 종료 사유 반환
+// 결과 처리 단계가 아니면 아무것도 바꾸지 않고 에러
+func (g *NCGame) NextRound() (overtimeStarted bool, over bool, reason string, err error) {
+	if g.Phase != NCPhaseResolving {
+		return false, false, "", fmt.Errorf("%d라운드 결과를 처리하는 중이 아닙니다 (단계: %s)", g.CurrentRound, g.Phase)
+	}
+
+	overtimeStarted = g.EnterOvertime()
+	over, reason = g.IsGameOver()
+
+	switch {
+	case over:
+		err = g.transition(NCPhaseFinished)
+	case g.Overtime:
+		err = g.transition(NCPhaseOvertime)
+	default:
+		err = g.transition(NCPhaseSubmitting)
+	}
+	return overtimeStarted, over, reason, err
+}
+
+// Finish 게임 종료 (이미 끝났으면 그대로)
+func (g *NCGame) Finish() error {
+	if g.Phase == NCPhaseFinished {
+		return nil
+	}
+	return g.transition(NCPhaseFinished)
+}
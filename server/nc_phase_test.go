@@ -0,0 +1,239 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ncGameIn phase 단계에 있는 게임 (단계 전환 검사 없이 바로 설정)
+func ncGameIn(phase NCPhase) *NCGame {
+	game := NewNCGame("test", ClassicNCRules())
+	game.Phase = phase
+	return game
+}
+
+func TestNCPhaseTransition(t *testing.T) {
+	tests := []struct {
+		from, to NCPhase
+		ok       bool
+	}{
+		{NCPhaseWaiting, NCPhaseSubmitting, true},
+		{NCPhaseWaiting, NCPhaseFinished, true},
+		{NCPhaseSubmitting, NCPhaseAwaitingSelection, true},
+		{NCPhaseSubmitting, NCPhaseResolving, true},
+		{NCPhaseSubmitting, NCPhaseFinished, true},
+		{NCPhaseOvertime, NCPhaseAwaitingSelection, true},
+		{NCPhaseOvertime, NCPhaseResolving, true},
+		{NCPhaseAwaitingSelection, NCPhaseResolving, true},
+		{NCPhaseAwaitingSelection, NCPhaseFinished, true},
+		{NCPhaseResolving, NCPhaseSubmitting, true},
+		{NCPhaseResolving, NCPhaseOvertime, true},
+		{NCPhaseResolving, NCPhaseFinished, true},
+
+		{NCPhaseWaiting, NCPhaseResolving, false},
+		{NCPhaseWaiting, NCPhaseOvertime, false},
+		{NCPhaseSubmitting, NCPhaseSubmitting, false},
+		{NCPhaseSubmitting, NCPhaseOvertime, false},
+		{NCPhaseAwaitingSelection, NCPhaseSubmitting, false},
+		{NCPhaseAwaitingSelection, NCPhaseAwaitingSelection, false},
+		{NCPhaseResolving, NCPhaseAwaitingSelection, false},
+		{NCPhaseResolving, NCPhaseResolving, false},
+		{NCPhaseFinished, NCPhaseSubmitting, false},
+		{NCPhaseFinished, NCPhaseFinished, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			game := ncGameIn(tt.from)
+			game.AwaitingTeams = []TeamColor{Team1}
+
+			err := game.transition(tt.to, Team2)
+			if (err == nil) != tt.ok {
+				t.Fatalf("transition error = %v, want ok=%v", err, tt.ok)
+			}
+			if !tt.ok {
+				// 거절한 전환은 단계도 선택 대기 팀도 바꾸지 않음
+				if game.Phase != tt.from || !reflect.DeepEqual(game.AwaitingTeams, []TeamColor{Team1}) {
+					t.Errorf("rejected transition changed state to %s %v", game.Phase, game.AwaitingTeams)
+				}
+				return
+			}
+			if game.Phase != tt.to || !reflect.DeepEqual(game.AwaitingTeams, []TeamColor{Team2}) {
+				t.Errorf("state = %s %v, want %s [%s]", game.Phase, game.AwaitingTeams, tt.to, Team2)
+			}
+		})
+	}
+}
+
+func TestNCExpectPhase(t *testing.T) {
+	game := ncGameIn(NCPhaseAwaitingSelection)
+	if err := game.expectPhase("블록을 제출할", NCPhaseSubmitting, NCPhaseOvertime); err == nil ||
+		err.Error() != "받을 블록을 고르는 단계라 블록을 제출할 수 없습니다" {
+		t.Errorf("expectPhase = %v", err)
+	}
+	if err := game.expectPhase("받을 블록을 고를", NCPhaseAwaitingSelection); err != nil {
+		t.Errorf("expectPhase in allowed phase = %v", err)
+	}
+}
+
+func TestNCCloseSubmissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       NCRuleSet
+		team1Hidden bool
+		team2Hidden bool
+		team2Choice int // 제출할 때 미리 고른 블록
+		wantPending []TeamColor
+		wantPhase   NCPhase
+	}{
+		{name: "히든 없음", rules: ClassicNCRules(), wantPending: nil, wantPhase: NCPhaseResolving},
+		{name: "1팀 히든", rules: ClassicNCRules(), team1Hidden: true, wantPending: []TeamColor{Team2}, wantPhase: NCPhaseAwaitingSelection},
+		{name: "2팀 히든", rules: ClassicNCRules(), team2Hidden: true, wantPending: []TeamColor{Team1}, wantPhase: NCPhaseAwaitingSelection},
+		{name: "두 팀 모두 히든", rules: ClassicNCRules(), team1Hidden: true, team2Hidden: true, wantPending: []TeamColor{Team1, Team2}, wantPhase: NCPhaseAwaitingSelection},
+		{name: "미리 고른 팀은 기다리지 않음", rules: ClassicNCRules(), team1Hidden: true, team2Hidden: true, team2Choice: 1, wantPending: []TeamColor{Team1}, wantPhase: NCPhaseAwaitingSelection},
+		{name: "선택 교환 규칙", rules: DraftNCRules(), wantPending: []TeamColor{Team1, Team2}, wantPhase: NCPhaseAwaitingSelection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewNCGame("test", tt.rules)
+			if err := game.Start(); err != nil {
+				t.Fatal(err)
+			}
+			if err := game.SubmitBlocks(Team1, []int{1, 2}, tt.team1Hidden, 0); err != nil {
+				t.Fatal(err)
+			}
+			if err := game.SubmitBlocks(Team2, []int{3, 4}, tt.team2Hidden, tt.team2Choice); err != nil {
+				t.Fatal(err)
+			}
+
+			pending, err := game.CloseSubmissions()
+			if err != nil {
+				t.Fatalf("CloseSubmissions: %v", err)
+			}
+			if !reflect.DeepEqual(pending, tt.wantPending) || game.Phase != tt.wantPhase {
+				t.Errorf("got %v in %s, want %v in %s", pending, game.Phase, tt.wantPending, tt.wantPhase)
+			}
+			if _, err := game.CloseSubmissions(); err == nil {
+				t.Error("closing submissions twice should fail")
+			}
+		})
+	}
+}
+
+// 두 팀 모두 히든을 쓰면 각 팀이 받을 블록을 고를 때까지 라운드를 처리하지 않는다
+func TestNCBothHiddenSelections(t *testing.T) {
+	game := NewNCGame("test", ClassicNCRules())
+	if err := game.Start(); err != nil {
+		t.Fatal(err)
+	}
+	game.SubmitBlocks(Team1, []int{1, 7}, true, 0)
+	game.SubmitBlocks(Team2, []int{2, 6}, true, 0)
+	if _, err := game.CloseSubmissions(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := game.ProcessRound(); err == nil {
+		t.Fatal("ProcessRound before any selection should fail")
+	}
+	if err := game.SelectBlock(Team1, 1); err != nil {
+		t.Fatalf("SelectBlock(Team1): %v", err)
+	}
+	if err := game.SelectBlock(Team1, 2); err == nil {
+		t.Error("selecting twice should fail")
+	}
+	if game.Phase != NCPhaseAwaitingSelection || !reflect.DeepEqual(game.PendingSelections(), []TeamColor{Team2}) {
+		t.Fatalf("after Team1 selects: %s %v", game.Phase, game.PendingSelections())
+	}
+	if err := game.SelectBlock(Team2, 1); err != nil {
+		t.Fatalf("SelectBlock(Team2): %v", err)
+	}
+	if game.Phase != NCPhaseResolving {
+		t.Fatalf("phase = %s after both selected, want %s", game.Phase, NCPhaseResolving)
+	}
+
+	result, err := game.ProcessRound()
+	if err != nil {
+		t.Fatalf("ProcessRound: %v", err)
+	}
+	// 각자 고른 블록을 받음 (가장 큰 블록이 아님)
+	if result.Team1ReceivedBlock != 2 || result.Team2ReceivedBlock != 1 {
+		t.Errorf("received %d/%d, want 2/1", result.Team1ReceivedBlock, result.Team2ReceivedBlock)
+	}
+	if game.HiddenUsed[Team1] != 1 || game.HiddenUsed[Team2] != 1 {
+		t.Errorf("hidden used = %v", game.HiddenUsed)
+	}
+}
+
+// 받을 블록을 정할 수 없는 라운드는 점수와 블록을 바꾸지 않는다
+func TestNCProcessRoundLeavesStateOnError(t *testing.T) {
+	game := NewNCGame("test", ClassicNCRules())
+	if err := game.Start(); err != nil {
+		t.Fatal(err)
+	}
+	game.SubmitBlocks(Team1, []int{6, 7}, true, 0)
+	game.SubmitBlocks(Team2, []int{1, 2}, false, 0)
+	if err := game.transition(NCPhaseResolving); err != nil {
+		t.Fatal(err)
+	}
+	blocks := append([]int{}, game.AvailableBlocks[Team1]...)
+
+	if _, err := game.ProcessRound(); err == nil {
+		t.Fatal("ProcessRound without Team2's selection should fail")
+	}
+	if game.Team1Score != 0 || game.Team2Score != 0 {
+		t.Errorf("scores changed to %d-%d", game.Team1Score, game.Team2Score)
+	}
+	if !reflect.DeepEqual(game.AvailableBlocks[Team1], blocks) || len(game.RoundHistory) != 0 || game.CurrentRound != 1 {
+		t.Error("failed round changed blocks, history or round number")
+	}
+}
+
+func TestNCNextRound(t *testing.T) {
+	tests := []struct {
+		name         string
+		phase        NCPhase
+		score        [2]int
+		round        int
+		wantErr      bool
+		wantPhase    NCPhase
+		wantOvertime bool
+	}{
+		{name: "결과 처리 단계가 아님", phase: NCPhaseSubmitting, round: 2, wantErr: true, wantPhase: NCPhaseSubmitting},
+		{name: "다음 라운드", phase: NCPhaseResolving, score: [2]int{1, 0}, round: 2, wantPhase: NCPhaseSubmitting},
+		{name: "목표 점수", phase: NCPhaseResolving, score: [2]int{7, 3}, round: 11, wantPhase: NCPhaseFinished},
+		{name: "마지막 라운드 동점이면 연장전", phase: NCPhaseResolving, score: [2]int{5, 5}, round: 13, wantPhase: NCPhaseOvertime, wantOvertime: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := ncGameIn(tt.phase)
+			game.Team1Score, game.Team2Score = tt.score[0], tt.score[1]
+			game.CurrentRound = tt.round
+
+			overtime, _, _, err := game.NextRound()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextRound error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if game.Phase != tt.wantPhase || overtime != tt.wantOvertime {
+				t.Errorf("phase %s overtime %v, want %s %v", game.Phase, overtime, tt.wantPhase, tt.wantOvertime)
+			}
+		})
+	}
+}
+
+func TestNCStartAndFinish(t *testing.T) {
+	game := NewNCGame("test", ClassicNCRules())
+	if err := game.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Start(); err == nil {
+		t.Error("starting twice should fail")
+	}
+	if err := game.Finish(); err != nil || game.Phase != NCPhaseFinished {
+		t.Fatalf("Finish = %v, phase %s", err, game.Phase)
+	}
+	if err := game.Finish(); err != nil {
+		t.Errorf("finishing a finished game = %v, want nil", err)
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// buildReplay 넘버체인지 기록을 라이브 관전자가 받은 것과 같은 메시지 순서로 재구성
+func (h *NCHub) buildReplay(record *NCGameRecord) *Replay {
+	replay := newReplay(record.ID)
+
+	game := NewNCGame(record.ID, record.Rules)
+	game.Ready = true
+	if err := game.transition(NCPhaseSubmitting); err != nil {
+		log.Printf("[NC] Error rebuilding game %s for replay: %v", record.ID, err)
+		return replay
+	}
+	game.CurrentTeam = record.FirstTeam
+	game.FirstTeam = record.FirstTeam
+	game.Sessions[Team1] = &Session{Name: record.Team1.Name}
+	game.Sessions[Team2] = &Session{Name: record.Team2.Name}
+
+	snapshot := func() {
+		state := game.State("")
+		state.Team1Connected, state.Team2Connected = true, true
+		replay.addSnapshot(Message{Type: NCMsgGameState, Payload: state})
+	}
+
+	last := record.StartedAt
+	for start := 0; start+1 < len(record.Submissions); start += 2 {
+		round := game.CurrentRound
+		submits := append([]NCSubmitRecord{}, record.Submissions[start:start+2]...)
+		sort.SliceStable(submits, func(i, j int) bool { return submits[i].At.Before(submits[j].At) })
+
+		snapshot()
+
+		// 제출 자체는 공개되지 않으므로 시간 초과와 히든 사용 알림만 재생
+		var delay time.Duration
+		for _, submit := range submits {
+			delay += replayStep(last, submit.At)
+			last = submit.At
+
+			if submit.Auto {
+				replay.addFrame(round, delay, Message{
+					Type: NCMsgTimeout,
+					Payload: NCTimeoutPayload{
+						Team:  submit.Team,
+						Round: round,
+						Phase: game.Phase,
+					},
+				})
+				delay = 0
+			}
+
+			game.RoundSubmits[submit.Team] = &NCSubmit{
+				Blocks:    submit.Blocks,
+				UseHidden: submit.UseHidden,
+			}
+		}
+
+		// 블록 선택 단계 (선택 시각은 기록되지 않으므로 최소 간격만 둠)
+		pending, err := game.CloseSubmissions()
+		if err != nil {
+			log.Printf("[NC] Error rebuilding round %d of game %s for replay: %v", round, record.ID, err)
+			break
+		}
+		for _, team := range []TeamColor{Team1, Team2} {
+			if game.RoundSubmits[team].UseHidden {
+				replay.addFrame(round, delay, game.hiddenNotice(team))
+				delay = 0
+			}
+		}
+		if len(pending) > 0 {
+			replay.addFrame(round, delay, Message{
+				Type: NCMsgSelectionPhase,
+				Payload: NCSelectionPhasePayload{
+					Round:        round,
+					PendingTeams: pending,
+					Phase:        game.Phase,
+				},
+			})
+			delay = replayMinStep
+		}
+		for _, submit := range submits {
+			if game.SelectionPending(submit.Team) {
+				game.SelectBlock(submit.Team, submit.SelectedBlockChoice)
+			}
+		}
+
+		result, err := game.ProcessRound()
+		if err != nil {
+			log.Printf("[NC] Error rebuilding round %d of game %s for replay: %v", round, record.ID, err)
+			break
+		}
+		overtimeStarted, _, _, err := game.NextRound()
+		if err != nil {
+			log.Printf("[NC] Error rebuilding round %d of game %s for replay: %v", round, record.ID, err)
+			break
+		}
+		result.Phase = game.Phase
+		replay.addFrame(round, delay, Message{Type: NCMsgRoundResult, Payload: result})
+
+		if overtimeStarted {
+			replay.addFrame(round, 0, Message{
+				Type: NCMsgOvertimeStart,
+				Payload: NCOvertimeStartPayload{
+					Round:      game.CurrentRound,
+					Team1Score: game.Team1Score,
+					Team2Score: game.Team2Score,
+					Phase:      game.Phase,
+				},
+			})
+		}
+	}
+
+	// 라운드 도중 취소된 게임은 마지막 라운드 시작 상태까지 보여줌
+	if record.Reason == recordReasonAborted || replay.TotalRounds() == 0 {
+		snapshot()
+	}
+
+	round := replay.TotalRounds()
+	delay := replayStep(last, record.EndedAt)
+	if record.Reason == recordReasonAborted {
+		replay.addFrame(round, delay, Message{
+			Type:    NCMsgError,
+			Payload: ErrorPayload{Message: "게임이 종료되었습니다"},
+		})
+		return replay
+	}
+
+	replay.addFrame(round, delay, Message{
+		Type: NCMsgGameOver,
+		Payload: NCGameOverPayload{
+			Winner:     record.Winner,
+			Team1Score: record.Team1Score,
+			Team2Score: record.Team2Score,
+			Reason:     record.Reason,
+			Ratings:    record.Ratings,
+			Phase:      NCPhaseFinished,
+		},
+	})
+
+	return replay
+}
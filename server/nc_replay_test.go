@@ -0,0 +1,101 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testNCRecord 1라운드에 1팀이 히든을 쓰고 2팀이 받을 블록을 고른 두 라운드 기록
+func testNCRecord() *NCGameRecord {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	return &NCGameRecord{
+		ID:        "replay",
+		Team1:     PlayerRecord{Name: "team1"},
+		Team2:     PlayerRecord{Name: "team2"},
+		Rules:     ClassicNCRules(),
+		FirstTeam: Team1,
+		StartedAt: start,
+		EndedAt:   at(4500 * time.Millisecond),
+		Submissions: []NCSubmitRecord{
+			{Round: 1, Team: Team1, Blocks: []int{1, 7}, UseHidden: true, At: at(1 * time.Second)},
+			{Round: 1, Team: Team2, Blocks: []int{2, 5}, SelectedBlockChoice: 1, At: at(2 * time.Second)},
+			// 기록 순서와 달리 2팀이 먼저 제출
+			{Round: 2, Team: Team1, Blocks: []int{2, 3}, At: at(4 * time.Second)},
+			{Round: 2, Team: Team2, Blocks: []int{3, 4}, At: at(3 * time.Second)},
+		},
+		Winner:     Team1,
+		Team1Score: 1,
+		Team2Score: 1,
+		Reason:     reasonScoreLimit,
+	}
+}
+
+func TestBuildNCReplayFrames(t *testing.T) {
+	replay := NewNCHub().buildReplay(testNCRecord())
+
+	types, delays := replayFrames(t, replay)
+	wantTypes := []string{
+		"nc_use_hidden", "nc_selection_phase", "nc_round_result",
+		"nc_round_result",
+		"nc_game_over",
+	}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Fatalf("frames = %v, want %v", types, wantTypes)
+	}
+	// 제출은 공개되지 않으므로 두 팀의 제출 간격을 합쳐 다음 프레임 앞에 둠
+	wantDelays := []time.Duration{2 * time.Second, 0, replayMinStep, 2 * time.Second, 500 * time.Millisecond}
+	if !reflect.DeepEqual(delays, wantDelays) {
+		t.Errorf("delays = %v, want %v", delays, wantDelays)
+	}
+
+	var notice NCUseHiddenPayload
+	decodePayload(t, replay.Frames[0].Data, &notice)
+	if notice.Team != Team1 || !sameTeams(notice.AwaitingTeams, []TeamColor{Team2}) {
+		t.Errorf("hidden notice = %+v", notice)
+	}
+
+	// 2팀은 1팀 블록 중 첫 번째를 골랐고, 1팀은 2팀 블록 중 큰 것을 받음
+	var result NCRoundResultPayload
+	decodePayload(t, replay.Frames[2].Data, &result)
+	if result.Winner != Team1 || result.Team1ReceivedBlock != 5 || result.Team2ReceivedBlock != 1 {
+		t.Errorf("round 1 result = winner %s received %d/%d", result.Winner, result.Team1ReceivedBlock, result.Team2ReceivedBlock)
+	}
+}
+
+func TestNCReplaySeek(t *testing.T) {
+	replay := NewNCHub().buildReplay(testNCRecord())
+	if replay.TotalRounds() != 2 {
+		t.Fatalf("TotalRounds = %d, want 2", replay.TotalRounds())
+	}
+
+	data, err := replay.Seek(2)
+	if err != nil {
+		t.Fatalf("Seek(2): %v", err)
+	}
+	var state NCGameStatePayload
+	decodePayload(t, data, &state)
+	if state.Round != 2 || state.Team1Score != 1 || state.Team2Score != 0 {
+		t.Errorf("snapshot = round %d %d-%d, want round 2 1-0", state.Round, state.Team1Score, state.Team2Score)
+	}
+	if replay.Position != 3 || replay.Round() != 2 {
+		t.Errorf("position %d round %d, want 3 2", replay.Position, replay.Round())
+	}
+}
+
+// 라운드 도중 취소된 게임은 그 라운드 시작 상태와 종료 알림으로 끝남
+func TestBuildNCReplayAborted(t *testing.T) {
+	record := testNCRecord()
+	record.Submissions = record.Submissions[:2]
+	record.Reason = recordReasonAborted
+	replay := NewNCHub().buildReplay(record)
+
+	types, _ := replayFrames(t, replay)
+	if last := types[len(types)-1]; last != string(NCMsgError) {
+		t.Errorf("last frame = %s, want %s", last, NCMsgError)
+	}
+	if replay.TotalRounds() != 2 {
+		t.Errorf("TotalRounds = %d, want 2", replay.TotalRounds())
+	}
+}
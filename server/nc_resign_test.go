@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ncLastError client가 마지막으로 받은 에러 메시지 (없으면 빈 값)
+func ncLastError(t *testing.T, client *Client) string {
+	t.Helper()
+	message := ""
+	for _, msg := range ncSent(t, client) {
+		if msg.Type == NCMsgError {
+			var payload ErrorPayload
+			json.Unmarshal(msg.Payload, &payload)
+			message = payload.Message
+		}
+	}
+	return message
+}
+
+func TestNCDrawOfferExpiresAtRoundEnd(t *testing.T) {
+	h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+
+	h.handleOfferDraw(game, team1)
+	if game.DrawOffer != Team1 {
+		t.Fatalf("DrawOffer = %q, want %q", game.DrawOffer, Team1)
+	}
+	h.handleMove(game, team1, submit([]int{1, 2}, false))
+	h.handleMove(game, team2, submit([]int{3, 4}, false))
+	if game.CurrentRound != 2 || game.DrawOffer != "" {
+		t.Fatalf("round %d DrawOffer %q, want round 2 with no offer", game.CurrentRound, game.DrawOffer)
+	}
+
+	ncSent(t, team2)
+	h.handleAcceptDraw(game, team2)
+	if got := ncLastError(t, team2); got != "상대가 무승부를 제안하지 않았습니다" {
+		t.Errorf("accepting an expired offer: error = %q", got)
+	}
+}
+
+func TestNCOfferDrawToAbsentOpponent(t *testing.T) {
+	h, game, team1, _ := ncTestGame(t, ClassicNCRules())
+	delete(game.Players, Team2)
+
+	h.handleOfferDraw(game, team1)
+	if got := ncLastError(t, team1); got != drawOpponentAway {
+		t.Errorf("error = %q, want %q", got, drawOpponentAway)
+	}
+	if game.DrawOffer != "" {
+		t.Error("rejected offer was recorded")
+	}
+}
+
+func TestNCDrawOfferInResumeState(t *testing.T) {
+	h, game, _, team2 := ncTestGame(t, ClassicNCRules())
+	h.handleOfferDraw(game, team2)
+
+	if got := game.State(Team1).DrawOffer; got != Team2 {
+		t.Errorf("Team1's state DrawOffer = %q, want %q", got, Team2)
+	}
+	if got := game.State("").DrawOffer; got != "" {
+		t.Errorf("spectator state DrawOffer = %q, want none", got)
+	}
+}
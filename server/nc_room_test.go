@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// 방 입장, 권한, 연결 끊김은 두 허브가 같은 코드를 쓰므로 room_test.go에서 확인하고
+// 여기서는 넘버체인지 규칙이 방 상태와 시작한 게임까지 이어지는지만 본다
+
+// ncTestRoom quick 규칙으로 만든 넘버체인지 방에 손님까지 입장한 상태
+func ncTestRoom(t *testing.T) (*NCHub, *Room, *Client, *Client) {
+	t.Helper()
+	h := NewNCHub()
+	owner := testClient("owner", 64)
+	guest := testClient("guest", 64)
+	h.addClient(owner)
+	h.addClient(guest)
+
+	h.handleCreateRoom(owner, Message{Payload: NCCreateRoomPayload{PlayerName: "방장", Team: Team2, RuleSet: NCRuleSetQuick}})
+	room := owner.Room
+	if room == nil {
+		t.Fatalf("room not created: %q", ncLastError(t, owner))
+	}
+	h.handleJoinRoom(guest, Message{Payload: JoinRoomPayload{Code: room.Code, PlayerName: "손님"}})
+	if guest.Room != room {
+		t.Fatalf("guest did not join: %q", ncLastError(t, guest))
+	}
+	ncSent(t, owner)
+	ncSent(t, guest)
+	return h, room, owner, guest
+}
+
+// ncRoomState client가 마지막으로 받은 방 상태 (없으면 nil)
+func ncRoomState(t *testing.T, client *Client) *NCRoomPayload {
+	t.Helper()
+	var state *NCRoomPayload
+	for _, msg := range ncSent(t, client) {
+		if msg.Type == NCMsgRoomUpdated {
+			state = &NCRoomPayload{}
+			json.Unmarshal(msg.Payload, state)
+		}
+	}
+	return state
+}
+
+// 방 설정 변경은 넘버체인지 규칙 프리셋과 직접 지정한 규칙을 받고, 잘못된 규칙이면 그대로 둔다
+func TestNCConfigureRoomRules(t *testing.T) {
+	custom := NCRuleSet{StartingBlocks: []int{1, 2, 3, 4, 5, 6}, ScoreTarget: 3, MaxRounds: 3}
+
+	tests := []struct {
+		name      string
+		payload   NCConfigureRoomPayload
+		wantRules string
+		wantErr   bool
+	}{
+		{name: "규칙 변경 없음", payload: NCConfigureRoomPayload{}, wantRules: NCRuleSetQuick},
+		{name: "프리셋", payload: NCConfigureRoomPayload{RuleSet: NCRuleSetTriple}, wantRules: NCRuleSetTriple},
+		{name: "직접 지정한 규칙", payload: NCConfigureRoomPayload{Rules: &custom}, wantRules: "custom"},
+		{name: "없는 프리셋", payload: NCConfigureRoomPayload{RuleSet: "unknown"}, wantRules: NCRuleSetQuick, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, room, owner, guest := ncTestRoom(t)
+
+			h.handleConfigureRoom(owner, Message{Payload: tt.payload})
+
+			if got := room.Settings.(NCRuleSet).Name; got != tt.wantRules {
+				t.Errorf("room rules = %q, want %q", got, tt.wantRules)
+			}
+			if tt.wantErr {
+				if ncLastError(t, owner) == "" {
+					t.Error("no error for invalid rules")
+				}
+				return
+			}
+			state := ncRoomState(t, guest)
+			if state == nil {
+				t.Fatal("guest got no room update")
+			}
+			if state.Rules.Name != tt.wantRules || state.YourTeam != Team1 || state.OwnerTeam != Team2 {
+				t.Errorf("room state = %q you %q owner %q, want %q %q %q",
+					state.Rules.Name, state.YourTeam, state.OwnerTeam, tt.wantRules, Team1, Team2)
+			}
+		})
+	}
+}
+
+// 방에서 시작한 넘버체인지 게임은 방 규칙을 쓰고 방에서 고른 팀에 앉힌다
+func TestNCStartRoomGame(t *testing.T) {
+	h, _, owner, guest := ncTestRoom(t)
+
+	h.handleStartRoom(owner)
+
+	game := h.games[owner.GameID]
+	if game == nil {
+		t.Fatalf("room game not started: %q", ncLastError(t, owner))
+	}
+	stopStartedNCGame(t, h, game)
+	if game.Rules.Name != NCRuleSetQuick {
+		t.Errorf("game rules = %q, want %q", game.Rules.Name, NCRuleSetQuick)
+	}
+	if game.Players[Team2] != owner || game.Players[Team1] != guest {
+		t.Errorf("teams = %v, want owner on %q", game.Players, Team2)
+	}
+	if !game.Casual {
+		t.Error("room game is rated")
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 넘버체인지 규칙 프리셋 이름
+const (
+	NCRuleSetClassic = "classic"
+	NCRuleSetQuick   = "quick"
+	NCRuleSetTriple  = "triple"
+	NCRuleSetDraft   = "draft"
+)
+
+// 사용자 지정 규칙 제한
+const (
+	maxNCBlock           = 20
+	maxNCStartingBlocks  = 40
+	maxNCBlocksPerSubmit = 5
+)
+
+// ClassicNCRules 기본 규칙: 1-7 블록 두 벌, 2개씩 제출, 7점 먼저 또는 12라운드, 히든 1회
+func ClassicNCRules() NCRuleSet {
+	return NCRuleSet{
+		Name:            NCRuleSetClassic,
+		StartingBlocks:  []int{1, 2, 3, 4, 5, 6, 7, 1, 2, 3, 4, 5, 6, 7},
+		BlocksPerSubmit: 2,
+		ScoreTarget:     7,
+		MaxRounds:       12,
+		HiddenChances:   1,
+		Exchange:        ExchangeLarger,
+	}
+}
+
+// QuickNCRules 빠른 규칙: 1-5 블록 두 벌, 2개씩 제출, 5점 먼저 또는 8라운드, 히든 1회
+func QuickNCRules() NCRuleSet {
+	return NCRuleSet{
+		Name:            NCRuleSetQuick,
+		StartingBlocks:  []int{1, 2, 3, 4, 5, 1, 2, 3, 4, 5},
+		BlocksPerSubmit: 2,
+		ScoreTarget:     5,
+		MaxRounds:       8,
+		HiddenChances:   1,
+		Exchange:        ExchangeLarger,
+	}
+}
+
+// TripleNCRules 세 블록 규칙: 1-9 블록 두 벌, 3개씩 제출, 5점 먼저 또는 8라운드, 히든 2회
+func TripleNCRules() NCRuleSet {
+	return NCRuleSet{
+		Name:            NCRuleSetTriple,
+		StartingBlocks:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		BlocksPerSubmit: 3,
+		ScoreTarget:     5,
+		MaxRounds:       8,
+		HiddenChances:   2,
+		Exchange:        ExchangeLarger,
+	}
+}
+
+// DraftNCRules 선택 교환 규칙: 기본 블록과 점수, 매 라운드 받을 블록을 직접 선택 (히든 없음)
+func DraftNCRules() NCRuleSet {
+	rules := ClassicNCRules()
+	rules.Name = NCRuleSetDraft
+	rules.HiddenChances = 0
+	rules.Exchange = ExchangeChosen
+	return rules
+}
+
+// NCRuleSetByName 이름으로 프리셋 조회 (빈 이름은 classic)
+func NCRuleSetByName(name string) (NCRuleSet, error) {
+	switch name {
+	case "", NCRuleSetClassic:
+		return ClassicNCRules(), nil
+	case NCRuleSetQuick:
+		return QuickNCRules(), nil
+	case NCRuleSetTriple:
+		return TripleNCRules(), nil
+	case NCRuleSetDraft:
+		return DraftNCRules(), nil
+	}
+	return NCRuleSet{}, fmt.Errorf("알 수 없는 규칙입니다: %s", name)
+}
+
+// resolveNCRuleSet 직접 지정한 규칙이 있으면 검증 후 사용, 없으면 프리셋 사용
+func resolveNCRuleSet(name string, custom *NCRuleSet) (NCRuleSet, error) {
+	if custom == nil {
+		return NCRuleSetByName(name)
+	}
+
+	rules := *custom
+	rules.StartingBlocks = append([]int{}, custom.StartingBlocks...)
+	if rules.Name == "" {
+		rules.Name = "custom"
+	}
+	if rules.BlocksPerSubmit == 0 {
+		rules.BlocksPerSubmit = 2
+	}
+	if rules.Exchange == "" {
+		rules.Exchange = ExchangeLarger
+	}
+
+	if err := rules.Validate(); err != nil {
+		return NCRuleSet{}, err
+	}
+	return rules, nil
+}
+
+// Validate 규칙 유효성 검사
+func (r NCRuleSet) Validate() error {
+	if len(r.StartingBlocks) == 0 || len(r.StartingBlocks) > maxNCStartingBlocks {
+		return fmt.Errorf("시작 블록은 1-%d개여야 합니다", maxNCStartingBlocks)
+	}
+	for _, block := range r.StartingBlocks {
+		if block < 1 || block > maxNCBlock {
+			return fmt.Errorf("블록은 1-%d 사이여야 합니다", maxNCBlock)
+		}
+	}
+	if r.BlocksPerSubmit < 2 || r.BlocksPerSubmit > maxNCBlocksPerSubmit {
+		return fmt.Errorf("제출 블록 수는 2-%d개여야 합니다", maxNCBlocksPerSubmit)
+	}
+	if r.MaxRounds < 1 || r.BlocksLeftAfter(r.MaxRounds-1) < r.BlocksPerSubmit {
+		return errors.New("마지막 라운드까지 제출할 블록이 부족합니다")
+	}
+	if r.ScoreTarget < 1 || r.ScoreTarget > r.MaxRounds {
+		return errors.New("목표 점수는 라운드 수를 넘을 수 없습니다")
+	}
+	if r.HiddenChances < 0 || r.HiddenChances > r.MaxRounds {
+		return errors.New("히든 찬스 횟수는 라운드 수를 넘을 수 없습니다")
+	}
+	switch r.Exchange {
+	case ExchangeLarger:
+	case ExchangeChosen:
+		// 매 라운드 직접 선택하므로 히든 찬스가 의미 없음
+		if r.HiddenChances > 0 {
+			return errors.New("선택 교환 규칙에서는 히든 찬스를 사용할 수 없습니다")
+		}
+	default:
+		return errors.New("알 수 없는 교환 규칙입니다")
+	}
+	return nil
+}
+
+// BlocksLeftAfter rounds 라운드 후 팀에 남는 블록 수 (제출한 만큼 빠지고 하나를 받음)
+func (r NCRuleSet) BlocksLeftAfter(rounds int) int {
+	return len(r.StartingBlocks) - rounds*(r.BlocksPerSubmit-1)
+}
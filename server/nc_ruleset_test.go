@@ -0,0 +1,88 @@
+package server
+
+import "testing"
+
+func TestNCRuleSetPresetsValid(t *testing.T) {
+	for _, name := range []string{"", NCRuleSetClassic, NCRuleSetQuick, NCRuleSetTriple, NCRuleSetDraft} {
+		rules, err := NCRuleSetByName(name)
+		if err != nil {
+			t.Fatalf("NCRuleSetByName(%q): %v", name, err)
+		}
+		if err := rules.Validate(); err != nil {
+			t.Errorf("preset %q is invalid: %v", rules.Name, err)
+		}
+	}
+	if _, err := NCRuleSetByName("blitz"); err == nil {
+		t.Error("unknown preset was accepted")
+	}
+}
+
+func TestNCRuleSetValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *NCRuleSet)
+		want   string // 빈 값이면 통과
+	}{
+		{name: "기본 규칙", modify: func(r *NCRuleSet) {}},
+		{name: "블록 없음", modify: func(r *NCRuleSet) { r.StartingBlocks = nil }, want: "시작 블록은 1-40개여야 합니다"},
+		{name: "블록 초과", modify: func(r *NCRuleSet) { r.StartingBlocks = make([]int, maxNCStartingBlocks+1) }, want: "시작 블록은 1-40개여야 합니다"},
+		{name: "블록 0", modify: func(r *NCRuleSet) { r.StartingBlocks[0] = 0 }, want: "블록은 1-20 사이여야 합니다"},
+		{name: "너무 큰 블록", modify: func(r *NCRuleSet) { r.StartingBlocks[0] = maxNCBlock + 1 }, want: "블록은 1-20 사이여야 합니다"},
+		{name: "한 개씩 제출", modify: func(r *NCRuleSet) { r.BlocksPerSubmit = 1 }, want: "제출 블록 수는 2-5개여야 합니다"},
+		{name: "너무 많이 제출", modify: func(r *NCRuleSet) { r.BlocksPerSubmit = maxNCBlocksPerSubmit + 1 }, want: "제출 블록 수는 2-5개여야 합니다"},
+		// 14개로 시작해 라운드마다 하나씩 줄어 13라운드에 1개만 남음
+		{name: "마지막 라운드 블록 부족", modify: func(r *NCRuleSet) { r.MaxRounds = 14 }, want: "마지막 라운드까지 제출할 블록이 부족합니다"},
+		{name: "블록이 딱 맞는 라운드", modify: func(r *NCRuleSet) { r.MaxRounds = 13 }},
+		{name: "라운드 0", modify: func(r *NCRuleSet) { r.MaxRounds = 0 }, want: "마지막 라운드까지 제출할 블록이 부족합니다"},
+		{name: "라운드보다 큰 목표 점수", modify: func(r *NCRuleSet) { r.ScoreTarget = 13 }, want: "목표 점수는 라운드 수를 넘을 수 없습니다"},
+		{name: "목표 점수 0", modify: func(r *NCRuleSet) { r.ScoreTarget = 0 }, want: "목표 점수는 라운드 수를 넘을 수 없습니다"},
+		{name: "음수 히든", modify: func(r *NCRuleSet) { r.HiddenChances = -1 }, want: "히든 찬스 횟수는 라운드 수를 넘을 수 없습니다"},
+		{name: "라운드보다 많은 히든", modify: func(r *NCRuleSet) { r.HiddenChances = 13 }, want: "히든 찬스 횟수는 라운드 수를 넘을 수 없습니다"},
+		{name: "선택 교환에 히든", modify: func(r *NCRuleSet) { r.Exchange = ExchangeChosen }, want: "선택 교환 규칙에서는 히든 찬스를 사용할 수 없습니다"},
+		{name: "알 수 없는 교환 규칙", modify: func(r *NCRuleSet) { r.Exchange = "smaller" }, want: "알 수 없는 교환 규칙입니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := ClassicNCRules()
+			tt.modify(&rules)
+			err := rules.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveNCRuleSet(t *testing.T) {
+	// 직접 지정한 규칙이 없으면 프리셋
+	rules, err := resolveNCRuleSet(NCRuleSetDraft, nil)
+	if err != nil || rules.Name != NCRuleSetDraft {
+		t.Fatalf("resolveNCRuleSet(draft, nil) = %s, %v", rules.Name, err)
+	}
+
+	// 빈 값은 기본값으로 채우고, 호출한 쪽의 블록과 공유하지 않음
+	custom := &NCRuleSet{StartingBlocks: []int{1, 2, 3, 4, 5, 6}, ScoreTarget: 3, MaxRounds: 5}
+	rules, err = resolveNCRuleSet(NCRuleSetTriple, custom)
+	if err != nil {
+		t.Fatalf("resolveNCRuleSet(custom): %v", err)
+	}
+	if rules.Name != "custom" || rules.BlocksPerSubmit != 2 || rules.Exchange != ExchangeLarger {
+		t.Errorf("defaults = %s %d %s", rules.Name, rules.BlocksPerSubmit, rules.Exchange)
+	}
+	custom.StartingBlocks[0] = 9
+	if rules.StartingBlocks[0] != 1 {
+		t.Error("resolved rules share the caller's starting blocks")
+	}
+
+	// 잘못된 규칙은 거절
+	if _, err := resolveNCRuleSet("", &NCRuleSet{StartingBlocks: []int{1, 2}, ScoreTarget: 1, MaxRounds: 3}); err == nil {
+		t.Error("invalid custom rules were accepted")
+	}
+}
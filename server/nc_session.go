@@ -0,0 +1,52 @@
+package server
+
+// State 팀 시점의 게임 상태 스냅샷 생성 (viewer가 비어있으면 관전자 시점)
+func (g *NCGame) State(viewer TeamColor) NCGameStatePayload {
+	state := NCGameStatePayload{
+		GameID:                g.ID,
+		YourTeam:              viewer,
+		Round:                 g.CurrentRound,
+		Team1Score:            g.Team1Score,
+		Team2Score:            g.Team2Score,
+		CurrentTeam:           g.CurrentTeam,
+		Team1Blocks:           g.AvailableBlocks[Team1],
+		Team2Blocks:           g.AvailableBlocks[Team2],
+		Team1UsedHidden:       g.UsedHidden(Team1, viewer),
+		Team2UsedHidden:       g.UsedHidden(Team2, viewer),
+		Team1HiddenLeft:       g.HiddenLeft(Team1, viewer),
+		Team2HiddenLeft:       g.HiddenLeft(Team2, viewer),
+		Team1Submitted:        g.RoundSubmits[Team1] != nil,
+		Team2Submitted:        g.RoundSubmits[Team2] != nil,
+		Team1SelectionPending: g.SelectionPending(Team1),
+		Team2SelectionPending: g.SelectionPending(Team2),
+		Team1Name:             g.PlayerName(Team1),
+		Team2Name:             g.PlayerName(Team2),
+		Team1Connected:        g.IsConnected(Team1),
+		Team2Connected:        g.IsConnected(Team2),
+		RoundHistory:          g.RoundHistory,
+		Rules:                 g.Rules,
+		Overtime:              g.Overtime,
+		Deadline:              deadlineMillis(g.Deadline),
+		Phase:                 g.Phase,
+		AwaitingTeams:         g.PendingSelections(),
+	}
+
+	if submit := g.RoundSubmits[viewer]; submit != nil {
+		state.YourSubmit = &NCSubmitBlocksPayload{
+			Block1:              submit.Blocks[0],
+			Block2:              submit.Blocks[1],
+			Blocks:              submit.Blocks,
+			UseHidden:           submit.UseHidden,
+			SelectedBlockChoice: submit.SelectedBlockChoice,
+		}
+	}
+
+	if s := g.Sessions[viewer]; s != nil {
+		state.SessionToken = s.Token
+	}
+	if viewer != "" {
+		state.DrawOffer = g.DrawOffer
+	}
+
+	return state
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// 제출/선택 제한 시간 기본값
+const (
+	defaultSubmitLimit = 60 * time.Second
+	defaultSelectLimit = 30 * time.Second
+)
+
+// startDeadline 지금 단계의 마감 시간 시작 (이전 타이머는 무효화)
+// 제출 단계(연장전 포함)는 SubmitLimit, 블록 선택 단계는 SelectLimit
+func (h *NCHub) startDeadline(game *NCGame) {
+	h.stopDeadline(game)
+
+	limit := game.SubmitLimit
+	if game.Phase == NCPhaseAwaitingSelection {
+		limit = game.SelectLimit
+	}
+
+	seq := game.deadlineSeq
+	game.Deadline = time.Now().Add(limit)
+	game.deadlineTimer = time.AfterFunc(limit, func() {
+		game.actor.post(func() { h.handleDeadline(game, seq) })
+	})
+
+	// AI 팀이 있으면 마감 전에 제출/선택하도록 예약
+	h.scheduleBotMove(game)
+}
+
+// stopDeadline 진행 중인 마감 타이머 중지
+func (h *NCHub) stopDeadline(game *NCGame) {
+	if game.deadlineTimer != nil {
+		game.deadlineTimer.Stop()
+		game.deadlineTimer = nil
+	}
+	game.deadlineSeq++
+	game.Deadline = time.Time{}
+}
+
+// handleDeadline 마감 시간을 넘긴 팀에게 기본 동작 적용
+// 제출 단계: 가장 작은 블록들 자동 제출, 선택 단계: 가장 큰 블록 자동 선택 (seq가 지난 마감이면 무시)
+func (h *NCHub) handleDeadline(game *NCGame, seq int) {
+	if !game.Ready || game.deadlineSeq != seq {
+		return
+	}
+
+	round := game.CurrentRound
+	phase := game.Phase
+
+	switch phase {
+	case NCPhaseSubmitting, NCPhaseOvertime:
+		for _, team := range []TeamColor{Team1, Team2} {
+			if game.RoundSubmits[team] != nil {
+				continue
+			}
+
+			blocks := game.SmallestBlocks(team)
+			payload, _ := json.Marshal(NCSubmitBlocksPayload{Blocks: blocks})
+			if err := game.ApplyMove(Seat(team), Move{Kind: string(NCMsgSubmitBlocks), Payload: payload, Auto: true}); err != nil {
+				log.Printf("[NC] Error auto-submitting for %s: %v", team, err)
+				continue
+			}
+			log.Printf("[NC] Submit deadline passed for %s in game %s, auto-submitted %v", team, game.ID, blocks)
+
+			// 제출한 블록은 본인에게만 공개
+			if player := game.Players[team]; player != nil {
+				h.sendToClient(player, Message{
+					Type: NCMsgTimeout,
+					Payload: NCTimeoutPayload{
+						Team:   team,
+						Round:  round,
+						Phase:  phase,
+						Block1: blocks[0],
+						Block2: blocks[1],
+						Blocks: blocks,
+					},
+				})
+			}
+			notice := Message{
+				Type: NCMsgTimeout,
+				Payload: NCTimeoutPayload{
+					Team:  team,
+					Round: round,
+					Phase: phase,
+				},
+			}
+			if opponent := game.Players[opponentTeam(team)]; opponent != nil {
+				h.sendToClient(opponent, notice)
+			}
+			h.broadcastToSpectators(game, notice)
+		}
+
+	case NCPhaseAwaitingSelection:
+		for _, team := range game.PendingSelections() {
+			choice := game.DefaultSelection(team)
+			payload, _ := json.Marshal(NCSelectBlockPayload{SelectedBlockChoice: choice})
+			if err := game.ApplyMove(Seat(team), Move{Kind: string(NCMsgSelectBlock), Payload: payload, Auto: true}); err != nil {
+				log.Printf("[NC] Error auto-selecting for %s: %v", team, err)
+				continue
+			}
+			log.Printf("[NC] Select deadline passed for %s in game %s, defaulted to block %d", team, game.ID, choice)
+
+			h.broadcastPublic(game, Message{
+				Type: NCMsgTimeout,
+				Payload: NCTimeoutPayload{
+					Team:                team,
+					Round:               round,
+					Phase:               phase,
+					SelectedBlockChoice: choice,
+				},
+			})
+		}
+	}
+
+	h.advanceRound(game)
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// ncTimeouts client가 받은 시간 초과 알림 (팀별)
+func ncTimeouts(t *testing.T, client *Client) map[TeamColor]NCTimeoutPayload {
+	t.Helper()
+	notices := map[TeamColor]NCTimeoutPayload{}
+	for _, msg := range ncSent(t, client) {
+		if msg.Type == NCMsgTimeout {
+			var payload NCTimeoutPayload
+			json.Unmarshal(msg.Payload, &payload)
+			notices[payload.Team] = payload
+		}
+	}
+	return notices
+}
+
+func TestNCHandleDeadline(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(h *NCHub, game *NCGame, team1, team2 *Client)
+		stale      bool // 지난 단계의 마감
+		wantPhase  NCPhase
+		wantAuto   map[TeamColor][]int // 자동 제출된 블록
+		wantSelect map[TeamColor]int   // 자동 선택된 블록
+	}{
+		{
+			name:      "제출 마감에 두 팀 모두 자동 제출",
+			wantPhase: NCPhaseSubmitting,
+			wantAuto:  map[TeamColor][]int{Team1: {1, 1}, Team2: {1, 1}},
+		},
+		{
+			name: "제출 마감에 제출하지 않은 팀만 자동 제출",
+			setup: func(h *NCHub, game *NCGame, team1, team2 *Client) {
+				h.handleMove(game, team1, submit([]int{5, 6}, false))
+			},
+			wantPhase: NCPhaseSubmitting,
+			wantAuto:  map[TeamColor][]int{Team2: {1, 1}},
+		},
+		{
+			name: "연장전 마감에 자동 제출",
+			setup: func(h *NCHub, game *NCGame, team1, team2 *Client) {
+				game.Overtime = true
+				game.Phase = NCPhaseOvertime
+			},
+			wantPhase: NCPhaseOvertime,
+			wantAuto:  map[TeamColor][]int{Team1: {1, 1}, Team2: {1, 1}},
+		},
+		{
+			name: "선택 마감에 가장 큰 블록 선택",
+			setup: func(h *NCHub, game *NCGame, team1, team2 *Client) {
+				h.handleMove(game, team1, submit([]int{2, 5}, true))
+				h.handleMove(game, team2, submit([]int{3, 4}, false))
+			},
+			wantPhase:  NCPhaseAwaitingSelection,
+			wantSelect: map[TeamColor]int{Team2: 2},
+		},
+		{
+			name:      "지난 마감은 무시",
+			stale:     true,
+			wantPhase: NCPhaseSubmitting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, game, team1, team2 := ncTestGame(t, ClassicNCRules())
+			if tt.setup != nil {
+				tt.setup(h, game, team1, team2)
+			}
+			if game.Phase != tt.wantPhase {
+				t.Fatalf("phase = %s, want %s", game.Phase, tt.wantPhase)
+			}
+			h.startDeadline(game)
+			seq := game.deadlineSeq
+			if tt.stale {
+				h.startDeadline(game)
+			}
+			ncSent(t, team1)
+			ncSent(t, team2)
+
+			h.handleDeadline(game, seq)
+
+			notices := map[TeamColor]map[TeamColor]NCTimeoutPayload{
+				Team1: ncTimeouts(t, team1),
+				Team2: ncTimeouts(t, team2),
+			}
+			if tt.stale {
+				if len(notices[Team1]) > 0 || len(notices[Team2]) > 0 || len(game.RoundSubmits) > 0 {
+					t.Fatalf("stale deadline acted: %v, submits %v", notices, game.RoundSubmits)
+				}
+				return
+			}
+			if game.CurrentRound != 2 {
+				t.Fatalf("round = %d, want the timed out round resolved", game.CurrentRound)
+			}
+
+			for _, record := range game.Submissions {
+				team := record.Team
+				want, auto := tt.wantAuto[team]
+				if record.Auto != auto || (auto && !reflect.DeepEqual(record.Blocks, want)) {
+					t.Errorf("%s submit = %+v, want auto %v %v", team, record, auto, want)
+				}
+				if choice, ok := tt.wantSelect[team]; ok && record.SelectedBlockChoice != choice {
+					t.Errorf("%s selected %d, want %d", team, record.SelectedBlockChoice, choice)
+				}
+			}
+
+			for team, want := range tt.wantAuto {
+				// 자동 제출한 블록은 본인만 알 수 있음
+				if got := notices[team][team]; !reflect.DeepEqual(got.Blocks, want) || got.Phase != tt.wantPhase {
+					t.Errorf("%s own timeout = %+v, want blocks %v", team, got, want)
+				}
+				other := opponentTeam(team)
+				if got, ok := notices[other][team]; !ok || got.Blocks != nil || got.Block1 != 0 {
+					t.Errorf("%s sees %s timeout = %+v", other, team, got)
+				}
+			}
+			for team, want := range tt.wantSelect {
+				for _, viewer := range []TeamColor{Team1, Team2} {
+					if got := notices[viewer][team]; got.SelectedBlockChoice != want || got.Phase != tt.wantPhase {
+						t.Errorf("%s sees %s timeout = %+v, want choice %d", viewer, team, got, want)
+					}
+				}
+			}
+			if len(tt.wantAuto)+len(tt.wantSelect) != len(notices[Team1]) {
+				t.Errorf("timeouts = %v", notices[Team1])
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Glicko 레이팅 설정
+const (
+	initialRating  = 1500.0
+	initialRD      = 350.0 // 처음 보는 플레이어의 불확실성
+	minRD          = 30.0
+	provisionalRD  = 110.0 // 이보다 불확실하면 잠정 레이팅으로 표시
+	rdGrowthPerDay = 34.6  // 쉬는 동안 늘어나는 편차 (약 100일이면 초기값으로 돌아감)
+	maxPlayerName  = 20
+	maxHistory     = 1000 // 게임 종류별로 보관하는 레이팅 기록 수
+
+	playersFileName = "players.json"
+)
+
+// ErrUnknownPlayerToken 발급한 적 없는 플레이어 토큰
+var ErrUnknownPlayerToken = errors.New("알 수 없는 플레이어 토큰입니다")
+
+// glickoQ Glicko 상수 q = ln(10) / 400
+var glickoQ = math.Ln10 / 400
+
+// newRating 처음 게임을 하는 플레이어의 레이팅
+func newRating() *Rating {
+	return &Rating{Rating: initialRating, RD: initialRD}
+}
+
+// currentRD 마지막 게임 이후 쉰 기간만큼 늘어난 편차
+func (r *Rating) currentRD(now time.Time) float64 {
+	if r.LastPlayed.IsZero() {
+		return r.RD
+	}
+	days := now.Sub(r.LastPlayed).Hours() / 24
+	return math.Min(math.Sqrt(r.RD*r.RD+rdGrowthPerDay*rdGrowthPerDay*days), initialRD)
+}
+
+// Provisional 아직 레이팅이 불확실한지 확인
+func (r *Rating) Provisional() bool {
+	return r.RD > provisionalRD
+}
+
+// View 클라이언트에 보낼 레이팅 요약
+func (r *Rating) View() RatingView {
+	return RatingView{
+		Rating:      int(math.Round(r.Rating)),
+		RD:          int(math.Round(r.RD)),
+		Games:       r.Games,
+		Provisional: r.Provisional(),
+	}
+}
+
+// glickoG 상대 편차에 따른 가중치
+func glickoG(rd float64) float64 {
+	return 1 / math.Sqrt(1+3*glickoQ*glickoQ*rd*rd/(math.Pi*math.Pi))
+}
+
+// glickoUpdate 한 판 결과(score: 승 1, 무 0.5, 패 0)로 새 레이팅과 편차 계산
+func glickoUpdate(rating, rd, opponentRating, opponentRD, score float64) (float64, float64) {
+	g := glickoG(opponentRD)
+	expected := 1 / (1 + math.Pow(10, -g*(rating-opponentRating)/400))
+	dSquared := 1 / (glickoQ * glickoQ * g * g * expected * (1 - expected))
+	precision := 1/(rd*rd) + 1/dSquared
+
+	newRating := rating + glickoQ/precision*g*(score-expected)
+	newRD := math.Max(math.Sqrt(1/precision), minRD)
+	return newRating, newRD
+}
+
+// ==================== Player Store ====================
+
+// PlayerStore 플레이어 프로필과 레이팅 저장소
+// 두 허브가 함께 쓰므로 잠금으로 보호하고, 파일 쓰기는 별도 고루틴에서 모아서 처리한다
+type PlayerStore struct {
+	mu      sync.Mutex
+	path    string
+	byID    map[string]*PlayerProfile
+	byToken map[string]*PlayerProfile
+	dirty   chan struct{}
+	closed  bool
+	done    chan struct{} // 쓰기 고루틴이 마지막 변경까지 기록하고 끝남
+}
+
+// NewPlayerStore dir의 프로필 파일을 읽어 저장소 생성 후 쓰기 고루틴 시작
+func NewPlayerStore(dir string) (*PlayerStore, error) {
+	s := &PlayerStore{
+		path:    filepath.Join(dir, playersFileName),
+		byID:    make(map[string]*PlayerProfile),
+		byToken: make(map[string]*PlayerProfile),
+		dirty:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("플레이어 파일 읽기 실패: %w", err)
+	}
+	if err == nil {
+		var profiles []*PlayerProfile
+		if err := json.Unmarshal(data, &profiles); err != nil {
+			return nil, fmt.Errorf("플레이어 파일 해석 실패: %w", err)
+		}
+		for _, p := range profiles {
+			if p.Ratings == nil {
+				p.Ratings = make(map[GameKind]*Rating)
+			}
+			for _, r := range p.Ratings {
+				r.backfill()
+			}
+			s.byID[p.ID] = p
+			s.byToken[p.Token] = p
+		}
+		log.Printf("Loaded %d player profiles", len(profiles))
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// run 변경이 있을 때마다 전체 프로필을 파일에 기록 (쓰는 동안 생긴 변경은 한 번에 모아 다시 기록)
+// Close 후에는 마지막으로 한 번 기록하고 끝남
+func (s *PlayerStore) run() {
+	defer close(s.done)
+	for range s.dirty {
+		s.mu.Lock()
+		profiles := make([]*PlayerProfile, 0, len(s.byID))
+		for _, p := range s.byID {
+			profiles = append(profiles, p)
+		}
+		data, err := json.Marshal(profiles)
+		closed := s.closed
+		s.mu.Unlock()
+
+		if err != nil {
+			log.Printf("Error marshaling player profiles: %v", err)
+		} else if err := writeFileAtomic(s.path, data); err != nil {
+			log.Printf("Error writing player profiles: %v", err)
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+// Close 지금까지의 변경을 파일에 기록할 때까지 기다림 (서버 종료 시 호출)
+func (s *PlayerStore) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.markDirty()
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// markDirty 파일 쓰기 예약 (이미 예약되어 있으면 무시)
+func (s *PlayerStore) markDirty() {
+	select {
+	case s.dirty <- struct{}{}:
+	default:
+	}
+}
+
+// Identify 토큰으로 프로필 조회 (이름이 주어지면 갱신)
+// 토큰이 없을 때만 새 프로필을 발급하고, 발급한 적 없는 토큰은 새 프로필로 바꾸지 않고 ErrUnknownPlayerToken
+func (s *PlayerStore) Identify(token, name string) (*PlayerProfile, error) {
+	name = strings.TrimSpace(name)
+	if len([]rune(name)) > maxPlayerName {
+		name = string([]rune(name)[:maxPlayerName])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile := s.byToken[token]
+	if profile == nil && token != "" {
+		return nil, ErrUnknownPlayerToken
+	}
+	if profile == nil {
+		profile = &PlayerProfile{
+			ID:        uuid.New().String(),
+			Token:     uuid.New().String(),
+			Name:      name,
+			Ratings:   make(map[GameKind]*Rating),
+			CreatedAt: time.Now(),
+		}
+		s.byID[profile.ID] = profile
+		s.byToken[profile.Token] = profile
+		s.markDirty()
+	} else if name != "" && name != profile.Name {
+		profile.Name = name
+		s.markDirty()
+	}
+
+	copied := *profile
+	return &copied, nil
+}
+
+// tokenOf 프로필 ID의 플레이어 토큰 (모르는 ID면 빈 값)
+func (s *PlayerStore) tokenOf(playerID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if profile := s.byID[playerID]; profile != nil {
+		return profile.Token
+	}
+	return ""
+}
+
+// RatingOf 플레이어의 게임별 레이팅 (처음이면 초기값)
+func (s *PlayerStore) RatingOf(playerID string, kind GameKind) Rating {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p := s.byID[playerID]; p != nil && p.Ratings[kind] != nil {
+		return *p.Ratings[kind]
+	}
+	return *newRating()
+}
+
+// RecordResult 두 플레이어의 한 판 결과를 레이팅에 반영 (scoreA: A 기준 승 1, 무 0.5, 패 0)
+func (s *PlayerStore) RecordResult(kind GameKind, gameID, playerA, playerB string, scoreA float64) (*RatingChange, *RatingChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, b := s.byID[playerA], s.byID[playerB]
+	if a == nil || b == nil || a == b {
+		return nil, nil, fmt.Errorf("레이팅을 반영할 수 없는 플레이어입니다")
+	}
+
+	ratingA, ratingB := a.rating(kind), b.rating(kind)
+	now := time.Now()
+	rdA, rdB := ratingA.currentRD(now), ratingB.currentRD(now)
+
+	newA, newRDA := glickoUpdate(ratingA.Rating, rdA, ratingB.Rating, rdB, scoreA)
+	newB, newRDB := glickoUpdate(ratingB.Rating, rdB, ratingA.Rating, rdA, 1-scoreA)
+
+	changeA := ratingA.apply(a.ID, gameID, newA, newRDA, scoreA, now)
+	changeB := ratingB.apply(b.ID, gameID, newB, newRDB, 1-scoreA, now)
+	s.markDirty()
+
+	return changeA, changeB, nil
+}
+
+// rating 게임 종류별 레이팅 (없으면 생성)
+func (p *PlayerProfile) rating(kind GameKind) *Rating {
+	r := p.Ratings[kind]
+	if r == nil {
+		r = newRating()
+		p.Ratings[kind] = r
+	}
+	return r
+}
+
+// apply 새 레이팅을 반영하고 변화량 반환
+func (r *Rating) apply(playerID, gameID string, rating, rd, score float64, now time.Time) *RatingChange {
+	before := int(math.Round(r.Rating))
+
+	r.Rating = rating
+	r.RD = rd
+	r.Games++
+	r.LastPlayed = now
+	result := scoreResult(score)
+	switch result {
+	case resultWin:
+		r.Wins++
+	case resultLoss:
+		r.Losses++
+	default:
+		r.Draws++
+	}
+
+	after := int(math.Round(r.Rating))
+	r.Streak = r.Streak.next(result)
+	r.month(now, before).record(result, after)
+	r.History = append(r.History, RatingPoint{
+		GameID: gameID,
+		At:     now,
+		Rating: after,
+		Delta:  after - before,
+		Result: result,
+	})
+	if len(r.History) > maxHistory {
+		r.History = r.History[len(r.History)-maxHistory:]
+	}
+	return &RatingChange{
+		PlayerID:    playerID,
+		Before:      before,
+		After:       after,
+		Delta:       after - before,
+		Provisional: r.Provisional(),
+	}
+}
+
+// month now가 속한 달의 집계 (없으면 before에서 시작하는 집계 생성)
+func (r *Rating) month(now time.Time, before int) *MonthlyRating {
+	if r.Monthly == nil {
+		r.Monthly = make(map[string]*MonthlyRating)
+	}
+	key := now.UTC().Format(monthLayout)
+	m := r.Monthly[key]
+	if m == nil {
+		m = &MonthlyRating{StartRating: before, Rating: before}
+		r.Monthly[key] = m
+	}
+	return m
+}
+
+// backfill 월별 집계와 연속 기록이 없던 때 저장한 레이팅을 남아있는 기록으로 채움
+func (r *Rating) backfill() {
+	if r.Monthly != nil || len(r.History) == 0 {
+		return
+	}
+	for _, point := range r.History {
+		r.month(point.At, point.Rating-point.Delta).record(point.Result, point.Rating)
+		r.Streak = r.Streak.next(point.Result)
+	}
+}
+
+// record 한 판의 결과와 끝난 뒤 레이팅 반영
+func (m *MonthlyRating) record(result string, after int) {
+	m.Games++
+	switch result {
+	case resultWin:
+		m.Wins++
+	case resultLoss:
+		m.Losses++
+	default:
+		m.Draws++
+	}
+	m.Rating = after
+}
+
+// next result가 이어지면 횟수를 늘리고, 끊기면 새로 셈
+func (s Streak) next(result string) Streak {
+	if s.Result == result {
+		s.Count++
+		return s
+	}
+	return Streak{Result: result, Count: 1}
+}
+
+// scoreResult 점수를 결과 이름으로 변환
+func scoreResult(score float64) string {
+	switch score {
+	case 1:
+		return resultWin
+	case 0:
+		return resultLoss
+	default:
+		return resultDraw
+	}
+}
+
+// resultScore 승자 기준 A의 점수 (무승부 0.5)
+func resultScore(aWon, bWon bool) float64 {
+	switch {
+	case aWon:
+		return 1
+	case bWon:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// ==================== Hub Integration ====================
+
+// SetPlayerStore 레이팅 프로필 저장소 지정 (nil이면 레이팅 사용 안 함)
+func (h *gameHub[G]) SetPlayerStore(store *PlayerStore) {
+	h.players = store
+}
+
+func (h *gameHub[G]) handleIdentify(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload IdentifyPayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	if h.players == nil {
+		h.sendError(client, "레이팅을 사용할 수 없습니다")
+		return
+	}
+
+	// 이미 확인한 연결은 토큰을 빼고 보내도 새 프로필을 받지 않음 (레이팅 초기화 방지)
+	token := payload.PlayerToken
+	if token == "" && client.PlayerID != "" {
+		token = h.players.tokenOf(client.PlayerID)
+	}
+
+	profile, err := h.players.Identify(token, payload.PlayerName)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+	client.PlayerID = profile.ID
+	if client.Name == "" {
+		client.Name = profile.Name
+	}
+	rating := h.players.RatingOf(profile.ID, h.kind)
+
+	h.sendToClient(client, Message{
+		Type: h.msg(MsgIdentity),
+		Payload: IdentityPayload{
+			PlayerID:    profile.ID,
+			PlayerToken: profile.Token,
+			Name:        profile.Name,
+			Rating:      rating.View(),
+		},
+	})
+}
+
+// isRatedGame 두 좌석 모두 서로 다른 프로필의 사람이고 친선 게임이 아닌지 확인
+func (h *gameHub[G]) isRatedGame(game G) bool {
+	t := game.table()
+	if h.players == nil || t.Casual || len(t.Bots) > 0 {
+		return false
+	}
+	first, second := t.Sessions[h.seats[0]], t.Sessions[h.seats[1]]
+	return first != nil && second != nil && first.PlayerID != "" && second.PlayerID != "" && first.PlayerID != second.PlayerID
+}
+
+// rateGame 레이팅 게임 결과 반영 (레이팅 게임이 아니면 nil)
+func (h *gameHub[G]) rateGame(game G, winner Seat) map[Seat]*RatingChange {
+	t := game.table()
+	// 다전제 게임은 다전제가 끝날 때 한 번에 반영
+	if t.Series.bestOf() {
+		return nil
+	}
+	return h.rateResult(game, t.ID, winner)
+}
+
+// rateResult 게임 또는 다전제(id) 결과를 두 좌석의 레이팅에 반영
+func (h *gameHub[G]) rateResult(game G, id string, winner Seat) map[Seat]*RatingChange {
+	t := game.table()
+	if !t.Rated || h.players == nil {
+		return nil
+	}
+
+	firstSeat, secondSeat := h.seats[0], h.seats[1]
+	first, second := t.Sessions[firstSeat], t.Sessions[secondSeat]
+	score := resultScore(winner == firstSeat, winner == secondSeat)
+	firstChange, secondChange, err := h.players.RecordResult(h.kind, id, first.PlayerID, second.PlayerID, score)
+	if err != nil {
+		log.Printf("%sError rating %s: %v", h.logPrefix, id, err)
+		return nil
+	}
+
+	log.Printf("%sRated %s: %s %d -> %d, %s %d -> %d", h.logPrefix, id, firstSeat, firstChange.Before, firstChange.After, secondSeat, secondChange.Before, secondChange.After)
+	return map[Seat]*RatingChange{firstSeat: firstChange, secondSeat: secondChange}
+}
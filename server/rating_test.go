@@ -0,0 +1,109 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGlickoUpdate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		rating, rd           float64
+		opponent, opponentRD float64
+		score                float64
+		wantRating, wantRD   float64
+	}{
+		{name: "처음 만난 두 플레이어 중 승자", rating: 1500, rd: 350, opponent: 1500, opponentRD: 350, score: 1, wantRating: 1662.2, wantRD: 290.2},
+		{name: "처음 만난 두 플레이어 중 패자", rating: 1500, rd: 350, opponent: 1500, opponentRD: 350, score: 0, wantRating: 1337.8, wantRD: 290.2},
+		{name: "같은 레이팅 무승부", rating: 1500, rd: 350, opponent: 1500, opponentRD: 350, score: 0.5, wantRating: 1500, wantRD: 290.2},
+		{name: "확실한 약자에게 승리", rating: 1500, rd: 200, opponent: 1400, opponentRD: 30, score: 1, wantRating: 1563.4, wantRD: 175.2},
+		{name: "강자에게 패배", rating: 1500, rd: 200, opponent: 1550, opponentRD: 100, score: 0, wantRating: 1426.8, wantRD: 175.7},
+		{name: "편차는 최소값 아래로 줄지 않음", rating: 1500, rd: minRD, opponent: 1500, opponentRD: minRD, score: 1, wantRating: 1502.6, wantRD: minRD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating, rd := glickoUpdate(tt.rating, tt.rd, tt.opponent, tt.opponentRD, tt.score)
+			if math.Abs(rating-tt.wantRating) > 0.1 || math.Abs(rd-tt.wantRD) > 0.1 {
+				t.Errorf("glickoUpdate = %.1f/%.1f, want %.1f/%.1f", rating, rd, tt.wantRating, tt.wantRD)
+			}
+		})
+	}
+}
+
+// 예상 밖의 결과일수록 레이팅이 더 크게 움직임
+func TestGlickoUpsetMovesMore(t *testing.T) {
+	expected, _ := glickoUpdate(1700, 100, 1500, 100, 1)
+	upset, _ := glickoUpdate(1500, 100, 1700, 100, 1)
+	if upset-1500 <= expected-1700 {
+		t.Errorf("upset gain %.1f is not larger than expected gain %.1f", upset-1500, expected-1700)
+	}
+}
+
+func TestCurrentRD(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		rating Rating
+		want   float64
+	}{
+		{name: "처음 보는 플레이어", rating: Rating{RD: initialRD}, want: initialRD},
+		{name: "방금 둔 플레이어", rating: Rating{RD: 50, LastPlayed: now}, want: 50},
+		{name: "하루 쉼", rating: Rating{RD: 50, LastPlayed: now.AddDate(0, 0, -1)}, want: math.Sqrt(50*50 + rdGrowthPerDay*rdGrowthPerDay)},
+		{name: "오래 쉬면 초기값까지만 늘어남", rating: Rating{RD: 50, LastPlayed: now.AddDate(-1, 0, 0)}, want: initialRD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rating.currentRD(now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("currentRD = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testPlayerStore 파일 쓰기 고루틴 없이 메모리에만 있는 저장소
+func testPlayerStore() *PlayerStore {
+	return &PlayerStore{
+		byID:    make(map[string]*PlayerProfile),
+		byToken: make(map[string]*PlayerProfile),
+		dirty:   make(chan struct{}, 1),
+	}
+}
+
+func TestRecordResult(t *testing.T) {
+	s := testPlayerStore()
+	a, _ := s.Identify("", "a")
+	b, _ := s.Identify("", "b")
+
+	changeA, changeB, err := s.RecordResult(GameNineDragons, "g1", a.ID, b.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changeA.Before != 1500 || changeA.After != 1662 || changeB.After != 1338 {
+		t.Errorf("changes = %+v, %+v", changeA, changeB)
+	}
+	if !changeA.Provisional || !changeB.Provisional {
+		t.Error("one game should leave both ratings provisional")
+	}
+
+	ratingA := s.RatingOf(a.ID, GameNineDragons)
+	if ratingA.Games != 1 || ratingA.Wins != 1 || len(ratingA.History) != 1 || ratingA.History[0].Result != resultWin {
+		t.Errorf("rating A = %+v", ratingA)
+	}
+	if ratingB := s.RatingOf(b.ID, GameNineDragons); ratingB.Losses != 1 {
+		t.Errorf("rating B = %+v", ratingB)
+	}
+	// 게임 종류마다 따로 관리
+	if other := s.RatingOf(a.ID, GameNumberChange); other.Games != 0 || other.Rating != initialRating {
+		t.Errorf("Number Change rating changed to %+v", other)
+	}
+
+	if _, _, err := s.RecordResult(GameNineDragons, "g2", a.ID, a.ID, 1); err == nil {
+		t.Error("rating a player against themselves succeeded")
+	}
+	if _, _, err := s.RecordResult(GameNineDragons, "g3", a.ID, "nobody", 1); err == nil {
+		t.Error("rating an unknown player succeeded")
+	}
+}
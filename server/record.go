@@ -0,0 +1,359 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// 기록 저장 설정
+const (
+	recordKindNineDragons  = "ninedragons"
+	recordKindNumberChange = "numberchange"
+	recordKindSeries       = "series" // 두 게임 종류의 다전제 기록을 함께 저장
+
+	recordReasonCompleted = "completed" // 종료 사유 없이 끝난 구룡투 게임 (score_limit, rounds_complete 이전 기록)
+	// 승패 없이 취소된 게임 (gameHub.abortGame): 시작 전 상대 이탈, 유예 시간이 끝날 때 둘 다 접속 끊김,
+	// 좌석 배정 실패, 넘버체인지 라운드 처리 실패, AI 추가 실패
+	recordReasonAborted = "aborted"
+)
+
+// ErrRecordNotFound 저장된 기록이 없음
+var ErrRecordNotFound = errors.New("게임 기록을 찾을 수 없습니다")
+
+// recordIDPattern 파일 경로로 쓰는 게임 ID 형식 (uuid)
+var recordIDPattern = regexp.MustCompile(`^[0-9a-fA-F-]{1,64}$`)
+
+// recordWrite 저장 대기 중인 기록
+type recordWrite struct {
+	Path string
+	Data []byte
+}
+
+// RecordStore 종료된 게임 기록을 디렉터리에 JSON 파일로 저장
+// 쓰기는 별도 고루틴에서 처리해 허브 이벤트 루프가 디스크를 기다리지 않는다
+// 대기열은 크기 제한이 없어 디스크가 밀려도 기록을 버리지 않는다
+type RecordStore struct {
+	dir   string
+	tiles *tileStats
+
+	mu      sync.Mutex
+	pending []recordWrite
+	wake    chan struct{} // 대기열에 기록이 들어왔음을 쓰기 고루틴에 알림
+	closed  bool
+	done    chan struct{} // 쓰기 고루틴이 대기열을 비우고 끝남
+}
+
+// NewRecordStore dir 아래에 기록을 저장하는 저장소 생성 후 쓰기 고루틴 시작
+func NewRecordStore(dir string) (*RecordStore, error) {
+	for _, kind := range []string{recordKindNineDragons, recordKindNumberChange, recordKindSeries} {
+		if err := os.MkdirAll(filepath.Join(dir, "games", kind), 0o755); err != nil {
+			return nil, fmt.Errorf("기록 디렉터리 생성 실패: %w", err)
+		}
+	}
+
+	s := newRecordStore(dir)
+	s.loadTileStats()
+	go s.run()
+	return s, nil
+}
+
+// newRecordStore 쓰기 고루틴을 시작하지 않은 저장소 (디렉터리는 만들지 않음)
+func newRecordStore(dir string) *RecordStore {
+	return &RecordStore{
+		dir:   dir,
+		tiles: newTileStats(),
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+// run 대기열의 기록을 순서대로 디스크에 기록 (Close 후 대기열을 모두 비우면 끝남)
+func (s *RecordStore) run() {
+	defer close(s.done)
+	for range s.wake {
+		for {
+			s.mu.Lock()
+			writes, closed := s.pending, s.closed
+			s.pending = nil
+			s.mu.Unlock()
+
+			if len(writes) == 0 {
+				if closed {
+					return
+				}
+				break
+			}
+			for _, w := range writes {
+				s.write(w)
+			}
+		}
+	}
+}
+
+// Close 대기 중인 기록을 모두 디스크에 쓸 때까지 기다림 (서버 종료 시 호출)
+// 닫은 뒤 저장하는 기록은 호출한 고루틴에서 바로 쓴다
+func (s *RecordStore) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	s.signal()
+	<-s.done
+}
+
+// signal 쓰기 고루틴 깨우기 (이미 깨워 두었으면 무시)
+func (s *RecordStore) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// write 기록 하나를 디스크에 기록
+func (s *RecordStore) write(w recordWrite) {
+	if err := writeFileAtomic(w.Path, w.Data); err != nil {
+		log.Printf("Error writing game record %s: %v", w.Path, err)
+	}
+}
+
+// SaveGame 구룡투 게임 기록 저장 예약
+func (s *RecordStore) SaveGame(record *GameRecord) {
+	s.enqueue(recordKindNineDragons, record.ID, record)
+	s.tiles.add(record)
+}
+
+// SaveNCGame 넘버체인지 게임 기록 저장 예약
+func (s *RecordStore) SaveNCGame(record *NCGameRecord) {
+	s.enqueue(recordKindNumberChange, record.ID, record)
+}
+
+// SaveSeries 다전제 기록 저장 예약
+func (s *RecordStore) SaveSeries(record *SeriesRecord) {
+	s.enqueue(recordKindSeries, record.ID, record)
+}
+
+// LoadGame 저장된 구룡투 게임 기록 조회
+func (s *RecordStore) LoadGame(id string) (*GameRecord, error) {
+	record := &GameRecord{}
+	if err := s.load(recordKindNineDragons, id, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// LoadNCGame 저장된 넘버체인지 게임 기록 조회
+func (s *RecordStore) LoadNCGame(id string) (*NCGameRecord, error) {
+	record := &NCGameRecord{}
+	if err := s.load(recordKindNumberChange, id, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// LoadSeries 저장된 다전제 기록 조회
+func (s *RecordStore) LoadSeries(id string) (*SeriesRecord, error) {
+	record := &SeriesRecord{}
+	if err := s.load(recordKindSeries, id, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// enqueue 기록을 직렬화해 대기열에 넣음 (기다리지 않으므로 허브 루프와 게임 고루틴 어디서든 호출)
+func (s *RecordStore) enqueue(kind, id string, record interface{}) {
+	w, ok := s.prepare(kind, id, record)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		s.write(w)
+		return
+	}
+	s.pending = append(s.pending, w)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// prepare 기록을 저장할 경로와 내용 (ID가 잘못되었거나 직렬화에 실패하면 false)
+func (s *RecordStore) prepare(kind, id string, record interface{}) (recordWrite, bool) {
+	if !recordIDPattern.MatchString(id) {
+		log.Printf("Refusing to save record with invalid id %q", id)
+		return recordWrite{}, false
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		log.Printf("Error marshaling game record %s: %v", id, err)
+		return recordWrite{}, false
+	}
+	return recordWrite{Path: s.path(kind, id), Data: data}, true
+}
+
+// load 기록 파일을 읽어 out에 디코딩
+func (s *RecordStore) load(kind, id string, out interface{}) error {
+	if !recordIDPattern.MatchString(id) {
+		return ErrRecordNotFound
+	}
+
+	data, err := os.ReadFile(s.path(kind, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrRecordNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (s *RecordStore) path(kind, id string) string {
+	return filepath.Join(s.dir, "games", kind, id+".json")
+}
+
+// writeFileAtomic 임시 파일에 쓴 뒤 이름을 바꿔 읽는 쪽이 반쯤 쓴 파일을 보지 않게 함
+// 이름을 바꾸기 전에 내용을, 바꾼 뒤에 디렉터리를 디스크에 반영해 전원이 나가도 기록이 남는다
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".record-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir 디렉터리 항목 변경(파일 생성, 이름 변경)을 디스크에 반영
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// ==================== Record Builders ====================
+
+// newGameRecord 종료 시점의 구룡투 게임으로 기록 생성
+func newGameRecord(game *Game, winner PlayerColor, reason string, ratings map[PlayerColor]*RatingChange) *GameRecord {
+	if reason == "" {
+		reason = recordReasonCompleted
+	}
+	record := &GameRecord{
+		ID:            game.ID,
+		Blue:          game.playerRecord(Blue),
+		Red:           game.playerRecord(Red),
+		Rules:         game.Rules,
+		TurnSeconds:   int(game.TurnLimit / time.Second),
+		TimeoutPolicy: game.TimeoutPolicy,
+		TileReveal:    game.TileReveal,
+		FirstPlayer:   game.FirstPlayer,
+		StartedAt:     game.StartedAt,
+		EndedAt:       time.Now(),
+		Moves:         game.Moves,
+		Rounds:        game.Rounds,
+		Winner:        winner,
+		BlueWins:      game.BlueWins,
+		RedWins:       game.RedWins,
+		Reason:        reason,
+		Rated:         game.Rated,
+		Ratings:       ratings,
+	}
+	if game.Series.bestOf() {
+		record.SeriesID = game.Series.ID
+	}
+	return record
+}
+
+// newNCGameRecord 종료 시점의 넘버체인지 게임으로 기록 생성
+func newNCGameRecord(game *NCGame, winner TeamColor, reason string, ratings map[TeamColor]*RatingChange) *NCGameRecord {
+	record := &NCGameRecord{
+		ID:          game.ID,
+		Team1:       game.playerRecord(Team1),
+		Team2:       game.playerRecord(Team2),
+		Rules:       game.Rules,
+		FirstTeam:   game.FirstTeam,
+		StartedAt:   game.StartedAt,
+		EndedAt:     time.Now(),
+		Submissions: game.Submissions,
+		Rounds:      game.RoundHistory,
+		Winner:      winner,
+		Team1Score:  game.Team1Score,
+		Team2Score:  game.Team2Score,
+		Overtime:    game.Overtime,
+		Reason:      reason,
+		Rated:       game.Rated,
+		Ratings:     ratings,
+	}
+	if game.Series.bestOf() {
+		record.SeriesID = game.Series.ID
+	}
+	return record
+}
+
+// newSeriesRecord 끝난 다전제로 기록 생성 (players, ratings는 자리 번호 순, winner는 이긴 자리 번호)
+func newSeriesRecord(kind GameKind, series *Series, players [2]PlayerRecord, winner int, reason string, ratings [2]*RatingChange) *SeriesRecord {
+	record := &SeriesRecord{
+		ID:        series.ID,
+		Game:      kind,
+		BestOf:    series.BestOf,
+		Players:   players,
+		GameIDs:   series.GameIDs,
+		Wins:      series.Wins,
+		Draws:     series.Draws,
+		Winner:    winner,
+		Reason:    reason,
+		StartedAt: series.StartedAt,
+		EndedAt:   time.Now(),
+	}
+	if ratings[0] != nil && ratings[1] != nil {
+		record.Rated = true
+		record.Ratings = ratings[:]
+	}
+	return record
+}
+
+// ==================== Hub Integration ====================
+
+// SetRecordStore 종료된 게임을 저장할 저장소 지정 (nil이면 저장 안 함)
+func (h *gameHub[G]) SetRecordStore(store *RecordStore) {
+	h.records = store
+}
+
+// saveRecord 게임 기록 저장 (저장소가 없거나 시작 전 게임이면 무시)
+func (h *gameHub[G]) saveRecord(game G, winner Seat, reason string, ratings map[Seat]*RatingChange) {
+	if h.records == nil || game.table().StartedAt.IsZero() {
+		return
+	}
+	h.hooks.storeRecord(h.records, game, winner, reason, ratings)
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// 쓰기가 밀려도 저장 예약은 기다리지 않고, Close는 밀린 기록을 모두 순서대로 쓴 뒤 끝난다
+func TestRecordQueueKeepsEveryRecord(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "games", recordKindNineDragons), 0o755)
+	s := newRecordStore(dir)
+
+	// 쓰기 고루틴이 아직 돌지 않는 동안 예전 대기열 크기(256)보다 많이 저장
+	const count = 1000
+	saved := make(chan struct{})
+	go func() {
+		for i := 0; i < count; i++ {
+			s.enqueue(recordKindNineDragons, fmt.Sprintf("%04x", i), map[string]int{"round": i})
+		}
+		close(saved)
+	}()
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("saving records waited for the writer")
+	}
+
+	go s.run()
+	s.Close()
+
+	files, _ := os.ReadDir(filepath.Join(dir, "games", recordKindNineDragons))
+	if len(files) != count {
+		t.Fatalf("wrote %d records, want %d", len(files), count)
+	}
+
+	// 닫은 뒤 저장한 기록도 바로 쓴다
+	s.enqueue(recordKindNineDragons, "ffff", map[string]int{"round": count})
+	if _, err := os.Stat(s.path(recordKindNineDragons, "ffff")); err != nil {
+		t.Errorf("record saved after Close: %v", err)
+	}
+}
+
+// 허브 루프에서 끝난 다전제 기록도 허브를 멈추지 않고 저장된다
+func TestSeriesRecordSaved(t *testing.T) {
+	s, err := NewRecordStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, game, _, _ := testGame(t)
+	h.records = s
+	game.startSeries(3, game.Seats())
+	go h.Run()
+
+	ended := make(chan struct{})
+	go func() {
+		h.call(func() { h.forfeitSeries(game, Blue) })
+		close(ended)
+	}()
+	select {
+	case <-ended:
+	case <-time.After(time.Second):
+		t.Fatal("ending a series stalled the hub loop")
+	}
+
+	s.Close()
+	record, err := s.LoadSeries(game.Series.ID)
+	if err != nil {
+		t.Fatalf("series record: %v", err)
+	}
+	if record.Winner != game.SeriesSeat[Red] || record.Reason != seriesForfeit {
+		t.Errorf("series winner = %d, reason = %q", record.Winner, record.Reason)
+	}
+}
+
+// 프로필 저장소를 닫으면 마지막 변경까지 파일에 남는다
+func TestPlayerStoreClose(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewPlayerStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := s.Identify("", "alice")
+	s.Close()
+
+	reopened, err := NewPlayerStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if got := reopened.tokenOf(alice.ID); got != alice.Token {
+		t.Errorf("reopened token = %q, want %q", got, alice.Token)
+	}
+}
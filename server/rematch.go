@@ -0,0 +1,212 @@
+package server
+
+import (
+	"log"
+	"time"
+)
+
+// 재대결 설정
+const (
+	rematchWindow = 60 * time.Second // 게임이 끝난 뒤 재대결을 제안/수락할 수 있는 시간
+
+	// 재대결이 성사되지 않은 사유
+	rematchDeclined = "declined"
+	rematchLeft     = "left"
+	rematchExpired  = "expired"
+)
+
+// record 한 게임 결과 반영 (seat: 이긴 자리 번호, 무승부는 -1)
+func (s *Series) record(gameID string, seat int) {
+	s.Games++
+	s.GameIDs = append(s.GameIDs, gameID)
+	if seat < 0 {
+		s.Draws++
+		return
+	}
+	s.Wins[seat]++
+}
+
+// score 현재 게임의 색/팀 이름 기준 전적
+func (s *Series) score(seats map[string]int) *SeriesScore {
+	score := &SeriesScore{
+		ID:     s.ID,
+		BestOf: s.BestOf,
+		Games:  s.Games,
+		Wins:   make(map[string]int),
+		Draws:  s.Draws,
+	}
+	for side, seat := range seats {
+		score.Wins[side] = s.Wins[seat]
+	}
+	return score
+}
+
+// ==================== Hub Integration ====================
+
+// openRematch 끝난 게임의 두 플레이어가 모두 접속 중이면 재대결 제안을 받을 수 있게 함
+func (h *gameHub[G]) openRematch(game G, winner Seat) {
+	t := game.table()
+	players := make(map[Seat]*Client)
+	for _, seat := range h.seats {
+		player := t.Players[seat]
+		if player == nil || !h.connected(player) {
+			return
+		}
+		players[seat] = player
+	}
+
+	rematch := &Rematch{
+		Game:      game,
+		Winner:    winner,
+		Players:   players,
+		Offered:   make(map[Seat]bool),
+		ExpiresAt: time.Now().Add(rematchWindow),
+	}
+	h.rematches[t.ID] = rematch
+	for _, player := range players {
+		player.Rematch = rematch
+	}
+
+	gameID := t.ID
+	time.AfterFunc(rematchWindow, func() {
+		h.rematchExpired <- gameID
+	})
+}
+
+func (h *gameHub[G]) handleRematchOffer(client *Client) {
+	rematch := client.Rematch
+	if rematch == nil {
+		h.sendError(client, "재대결할 수 있는 게임이 없습니다")
+		return
+	}
+
+	seat := rematch.seatOf(client)
+	opponent := h.opponent(seat)
+
+	// 양쪽이 모두 제안하면 수락한 것으로 처리
+	if rematch.Offered[opponent] {
+		h.startRematch(rematch)
+		return
+	}
+	if rematch.Offered[seat] {
+		return
+	}
+	rematch.Offered[seat] = true
+	log.Printf("%sPlayer %s offered a rematch of game %s", h.logPrefix, client.ID, rematch.Game.table().ID)
+
+	h.sendToClient(rematch.Players[opponent], Message{
+		Type:    h.msg(MsgRematchOffered),
+		Payload: h.hooks.rematchNotice(seat, "", rematch.ExpiresAt.UnixMilli()),
+	})
+}
+
+func (h *gameHub[G]) handleRematchAccept(client *Client) {
+	rematch := client.Rematch
+	if rematch == nil || !rematch.Offered[h.opponent(rematch.seatOf(client))] {
+		h.sendError(client, "상대가 재대결을 제안하지 않았습니다")
+		return
+	}
+	h.startRematch(rematch)
+}
+
+func (h *gameHub[G]) handleRematchDecline(client *Client) {
+	rematch := client.Rematch
+	if rematch == nil {
+		h.sendError(client, "재대결할 수 있는 게임이 없습니다")
+		return
+	}
+	h.closeRematch(rematch, rematch.seatOf(client), rematchDeclined)
+}
+
+// handleRematchExpiry 응답 없이 시간이 지난 재대결 제안 정리
+func (h *gameHub[G]) handleRematchExpiry(gameID string) {
+	if rematch := h.rematches[gameID]; rematch != nil {
+		h.closeRematch(rematch, "", rematchExpired)
+	}
+}
+
+// leaveRematch 다른 게임으로 가거나 연결이 끊긴 플레이어의 재대결 기회 취소
+func (h *gameHub[G]) leaveRematch(client *Client) {
+	if rematch := client.Rematch; rematch != nil {
+		h.closeRematch(rematch, rematch.seatOf(client), rematchLeft)
+	}
+}
+
+// closeRematch 재대결 제안을 닫고 by가 아닌 플레이어에게 사유 알림
+func (h *gameHub[G]) closeRematch(rematch *Rematch, by Seat, reason string) {
+	prev := rematch.Game.(G)
+	delete(h.rematches, prev.table().ID)
+	for seat, player := range rematch.Players {
+		player.Rematch = nil
+		if seat == by || !h.connected(player) {
+			continue
+		}
+		h.sendToClient(player, Message{
+			Type:    h.msg(MsgRematchDeclined),
+			Payload: h.hooks.rematchNotice(by, reason, 0),
+		})
+	}
+
+	// 다전제 도중 다음 게임을 두지 않고 나가면 기권패
+	if series := prev.table().Series; series.bestOf() && !series.Over {
+		h.forfeitSeries(prev, by)
+	}
+}
+
+// startRematch 같은 설정으로 좌석을 바꿔 새 게임 시작 (같은 좌석이 먼저 두므로 선공도 바뀜)
+func (h *gameHub[G]) startRematch(rematch *Rematch) {
+	prev := rematch.Game.(G)
+	for seat, player := range rematch.Players {
+		if !h.connected(player) {
+			h.closeRematch(rematch, seat, rematchLeft)
+			return
+		}
+	}
+	p := prev.table()
+	delete(h.rematches, p.ID)
+	for _, player := range rematch.Players {
+		player.Rematch = nil
+	}
+
+	game := h.hooks.rematchGame(prev)
+	t := game.table()
+	t.Casual = p.Casual
+
+	// 첫 재대결이면 끝난 게임부터 전적 시작
+	t.Series = p.Series
+	prevSeat := p.SeriesSeat
+	if t.Series == nil {
+		t.Series = &Series{}
+		prevSeat = map[Seat]int{h.seats[0]: 0, h.seats[1]: 1}
+		p.SeriesSeat = prevSeat
+		p.Series = t.Series
+		p.recordSeries(rematch.Winner)
+	}
+	// 끝난 다전제의 재대결은 같은 판 수로 새 다전제 시작
+	if t.Series.Over {
+		t.Series = newBestOfSeries(t.Series.BestOf)
+	}
+	t.SeriesSeat = make(map[Seat]int)
+	swapped := make(map[Seat]*Client)
+	for _, seat := range h.seats {
+		t.SeriesSeat[seat] = prevSeat[h.opponent(seat)]
+		swapped[seat] = rematch.Players[h.opponent(seat)]
+	}
+
+	h.addGame(game)
+	log.Printf("%sRematch of game %s started as game %s (series game %d)", h.logPrefix, p.ID, t.ID, t.Series.Games+1)
+
+	if h.seatPlayers(game, swapped) {
+		h.startGame(game)
+	}
+}
+
+// seatOf 끝난 게임에서 client의 좌석
+func (r *Rematch) seatOf(client *Client) Seat {
+	for seat, player := range r.Players {
+		if player == client {
+			return seat
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// finishedGame 파랑이 이기고 끝나 재대결 제안을 받을 수 있는 게임
+func finishedGame(t *testing.T) (*Hub, *Game, *Client, *Client) {
+	t.Helper()
+	h, game, blue, red := testGame(t)
+	game.BlueWins = game.Rules.WinsNeeded
+	h.openRematch(game, Blue)
+	if blue.Rematch == nil || red.Rematch != blue.Rematch {
+		t.Fatal("rematch was not opened for both players")
+	}
+	sent(t, blue)
+	sent(t, red)
+	return h, game, blue, red
+}
+
+// stopStartedGame 재대결로 시작한 게임의 고루틴과 차례 타이머 정리
+func stopStartedGame(t *testing.T, h *Hub, game *Game) {
+	t.Cleanup(func() {
+		done := make(chan struct{})
+		game.actor.post(func() {
+			h.stopTurnTimer(game)
+			close(done)
+		})
+		<-done
+		game.actor.stop()
+	})
+}
+
+func TestRematchSwapsColors(t *testing.T) {
+	h, prev, blue, red := finishedGame(t)
+
+	h.handleRematchOffer(blue)
+	var offered []sentND
+	for _, msg := range sent(t, red) {
+		if msg.Type == MsgRematchOffered {
+			offered = append(offered, msg)
+		}
+	}
+	if len(offered) != 1 {
+		t.Fatalf("red got %d rematch offers, want 1", len(offered))
+	}
+	var offer RematchPayload
+	json.Unmarshal(offered[0].Payload, &offer)
+	if offer.Color != Blue || offer.ExpiresAt == 0 {
+		t.Errorf("offer = %+v", offer)
+	}
+
+	h.handleRematchAccept(red)
+	game := h.games[red.GameID]
+	if game == nil || game == prev {
+		t.Fatal("rematch did not start a new game")
+	}
+	stopStartedGame(t, h, game)
+
+	// 색을 바꾸고, 같은 색이 먼저 두므로 선공도 바뀜
+	if blue.Seat != Red || red.Seat != Blue || game.CurrentPlayer != prev.FirstPlayer {
+		t.Errorf("colors blue=%s red=%s first=%s", blue.Seat, red.Seat, game.CurrentPlayer)
+	}
+	if game.Rules.Name != prev.Rules.Name || game.TileReveal != prev.TileReveal || game.TimeoutPolicy != prev.TimeoutPolicy {
+		t.Error("rematch did not keep the previous settings")
+	}
+	// 끝난 게임부터 전적을 셈
+	score := game.seriesScore()
+	if score == nil || score.Games != 1 || score.Wins[string(Red)] != 1 || score.Wins[string(Blue)] != 0 {
+		t.Errorf("series score = %+v, want the previous blue win under red", score)
+	}
+	if blue.Rematch != nil || red.Rematch != nil || h.rematches[prev.ID] != nil {
+		t.Error("started rematch is still open")
+	}
+}
+
+// 둘 다 재대결을 제안하면 수락한 것으로 처리
+func TestRematchBothOffer(t *testing.T) {
+	h, prev, blue, red := finishedGame(t)
+
+	h.handleRematchOffer(blue)
+	h.handleRematchOffer(red)
+	game := h.games[blue.GameID]
+	if game == nil || game == prev || red.GameID != game.ID {
+		t.Fatal("mutual offers did not start a rematch")
+	}
+	stopStartedGame(t, h, game)
+}
+
+func TestRematchDecline(t *testing.T) {
+	h, prev, blue, red := finishedGame(t)
+
+	h.handleRematchOffer(blue)
+	h.handleRematchDecline(red)
+
+	var declined *RematchPayload
+	for _, msg := range sent(t, blue) {
+		if msg.Type == MsgRematchDeclined {
+			declined = &RematchPayload{}
+			json.Unmarshal(msg.Payload, declined)
+		}
+	}
+	if declined == nil || declined.Color != Red || declined.Reason != rematchDeclined {
+		t.Errorf("blue got decline %+v, want red %s", declined, rematchDeclined)
+	}
+	for _, msg := range sent(t, red) {
+		if msg.Type == MsgRematchDeclined {
+			t.Error("declining player was told about their own decline")
+		}
+	}
+	if blue.Rematch != nil || red.Rematch != nil || h.rematches[prev.ID] != nil {
+		t.Error("declined rematch is still open")
+	}
+
+	h.handleRematchAccept(blue)
+	if lastError(t, blue) != "상대가 재대결을 제안하지 않았습니다" {
+		t.Error("accepting a closed rematch did not fail")
+	}
+}
+
+func TestRematchExpiry(t *testing.T) {
+	h, prev, blue, red := finishedGame(t)
+
+	h.handleRematchExpiry(prev.ID)
+	for _, c := range []*Client{blue, red} {
+		var reason string
+		for _, msg := range sent(t, c) {
+			if msg.Type == MsgRematchDeclined {
+				var payload RematchPayload
+				json.Unmarshal(msg.Payload, &payload)
+				reason = payload.Reason
+			}
+		}
+		if reason != rematchExpired {
+			t.Errorf("%s got reason %q, want %q", c.ID, reason, rematchExpired)
+		}
+	}
+}
+
+// 한 플레이어가 이미 나갔으면 재대결을 열지 않음
+func TestRematchNeedsBothPlayers(t *testing.T) {
+	h, game, blue, red := testGame(t)
+	h.removeClient(red)
+
+	h.openRematch(game, Blue)
+	if blue.Rematch != nil || h.rematches[game.ID] != nil {
+		t.Error("rematch opened with a disconnected opponent")
+	}
+}
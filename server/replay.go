@@ -0,0 +1,487 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// 다시보기 설정
+const (
+	replayMinStep      = 400 * time.Millisecond // 기록된 간격이 짧아도 화면에서 구분되도록 두는 최소 간격
+	replayMaxStep      = 3 * time.Second        // 오래 고민한 차례도 이 이상 기다리지 않음
+	defaultReplaySpeed = 1.0
+	minReplaySpeed     = 0.25
+	maxReplaySpeed     = 8.0
+)
+
+// replayFrame 라이브 게임과 같은 형식으로 직렬화된 메시지 하나
+type replayFrame struct {
+	Round int
+	Delay time.Duration // 이전 프레임과의 기록상 간격 (배속 적용 전)
+	Data  []byte
+}
+
+// replayTick 다음 프레임 전송 예약 이벤트 (seq가 다르면 이미 조작된 재생이므로 무시)
+type replayTick struct {
+	Replay *Replay
+	Seq    int
+}
+
+func newReplay(gameID string) *Replay {
+	return &Replay{GameID: gameID, Speed: defaultReplaySpeed}
+}
+
+// addFrame 메시지를 직렬화해 프레임으로 추가
+func (r *Replay) addFrame(round int, delay time.Duration, message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling replay frame for game %s: %v", r.GameID, err)
+		return
+	}
+	r.Frames = append(r.Frames, replayFrame{Round: round, Delay: delay, Data: data})
+}
+
+// addSnapshot 라운드 시작 시점의 상태 메시지 추가
+func (r *Replay) addSnapshot(message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling replay snapshot for game %s: %v", r.GameID, err)
+		return
+	}
+	r.Snapshots = append(r.Snapshots, data)
+}
+
+// TotalRounds 이동할 수 있는 라운드 수
+func (r *Replay) TotalRounds() int {
+	return len(r.Snapshots)
+}
+
+// Round 다음 프레임이 속한 라운드 (끝까지 재생했으면 마지막 라운드)
+func (r *Replay) Round() int {
+	if r.Finished() {
+		return r.TotalRounds()
+	}
+	return r.Frames[r.Position].Round
+}
+
+// Finished 모든 프레임을 보냈는지 확인
+func (r *Replay) Finished() bool {
+	return r.Position >= len(r.Frames)
+}
+
+// Seek round 라운드 시작으로 이동하고 그 시점의 상태 메시지 반환
+func (r *Replay) Seek(round int) ([]byte, error) {
+	if round < 1 || round > r.TotalRounds() {
+		return nil, fmt.Errorf("라운드는 1-%d 사이여야 합니다", r.TotalRounds())
+	}
+	r.Position = len(r.Frames)
+	for i, frame := range r.Frames {
+		if frame.Round >= round {
+			r.Position = i
+			break
+		}
+	}
+	return r.Snapshots[round-1], nil
+}
+
+// SetSpeed 재생 배속 변경
+func (r *Replay) SetSpeed(speed float64) error {
+	if speed < minReplaySpeed || speed > maxReplaySpeed {
+		return fmt.Errorf("재생 배속은 %.2g-%.2g 사이여야 합니다", minReplaySpeed, maxReplaySpeed)
+	}
+	r.Speed = speed
+	return nil
+}
+
+// nextDelay 배속을 적용한 다음 프레임까지의 대기 시간
+func (r *Replay) nextDelay() time.Duration {
+	return time.Duration(float64(r.Frames[r.Position].Delay) / r.Speed)
+}
+
+// schedule 재생 중이면 다음 프레임 전송 예약 (이전 예약은 무효화)
+func (r *Replay) schedule(ticks chan<- replayTick) {
+	r.stop()
+	if !r.Playing || r.Finished() {
+		return
+	}
+
+	tick := replayTick{Replay: r, Seq: r.seq}
+	r.timer = time.AfterFunc(r.nextDelay(), func() {
+		ticks <- tick
+	})
+}
+
+// stop 예약된 프레임 전송 취소
+func (r *Replay) stop() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.seq++
+}
+
+// replayStep 기록된 두 시각 사이 간격을 재생 간격으로 변환
+func replayStep(from, to time.Time) time.Duration {
+	step := to.Sub(from)
+	if from.IsZero() || step < replayMinStep {
+		return replayMinStep
+	}
+	if step > replayMaxStep {
+		return replayMaxStep
+	}
+	return step
+}
+
+// ==================== Replay Builders ====================
+
+// buildReplay 구룡투 기록을 라이브 관전자가 받은 것과 같은 메시지 순서로 재구성
+func (h *Hub) buildReplay(record *GameRecord) *Replay {
+	replay := newReplay(record.ID)
+
+	game := NewGame(record.Rules)
+	game.ID = record.ID
+	game.Ready = true
+	game.CurrentPlayer = record.FirstPlayer
+	game.TurnLimit = time.Duration(record.TurnSeconds) * time.Second
+	game.TimeoutPolicy = record.TimeoutPolicy
+	game.TileReveal = record.TileReveal
+	game.UsedTiles[Blue] = []int{}
+	game.UsedTiles[Red] = []int{}
+	game.Sessions[Blue] = &Session{Name: record.Blue.Name}
+	game.Sessions[Red] = &Session{Name: record.Red.Name}
+
+	snapshot := func() {
+		state := game.State("")
+		state.BlueConnected, state.RedConnected = true, true
+		replay.addSnapshot(Message{Type: MsgGameState, Payload: state})
+	}
+
+	last := record.StartedAt
+	playMoves := func(moves []MoveRecord) {
+		for _, move := range moves {
+			delay := replayStep(last, move.At)
+			last = move.At
+
+			tile := move.Tile
+			game.RoundTiles[move.Color] = &tile
+			game.UsedTiles[move.Color] = append(game.UsedTiles[move.Color], tile)
+
+			if move.Auto {
+				replay.addFrame(move.Round, delay, Message{
+					Type: MsgTimeout,
+					Payload: TimeoutPayload{
+						Color:  move.Color,
+						Round:  move.Round,
+						Policy: record.TimeoutPolicy,
+					},
+				})
+				delay = 0
+
+				// 라운드 포기는 타일 공개 없이 바로 결과로 이어짐
+				if record.TimeoutPolicy == TimeoutForfeitRound {
+					continue
+				}
+			}
+
+			roundDone := game.RoundTiles[Blue] != nil && game.RoundTiles[Red] != nil
+			played := TilePlayedPayload{
+				Color:          move.Color,
+				Tile:           tile,
+				TileColor:      TileBand(tile),
+				Round:          move.Round,
+				NextPlayer:     game.GetNextPlayer(),
+				WaitingFor:     game.GetNextPlayer(),
+				BlueTilePlayed: game.RoundTiles[Blue] != nil,
+				RedTilePlayed:  game.RoundTiles[Red] != nil,
+			}
+			if !roundDone {
+				played = concealTile(played, spectatorReveal(game.TileReveal))
+			}
+			replay.addFrame(move.Round, delay, Message{Type: MsgTilePlayed, Payload: played})
+		}
+	}
+
+	next := 0
+	movesOf := func(round int) []MoveRecord {
+		start := next
+		for next < len(record.Moves) && record.Moves[next].Round == round {
+			next++
+		}
+		return record.Moves[start:next]
+	}
+
+	for _, round := range record.Rounds {
+		snapshot()
+		moves := movesOf(round.Round)
+		playMoves(moves)
+
+		game.awardRound(round.Winner)
+		delay := time.Duration(0)
+		if len(moves) == 0 {
+			delay = replayMinStep
+		}
+		replay.addFrame(round.Round, delay, Message{
+			Type: MsgRoundResult,
+			Payload: RoundResultPayload{
+				Round:      round.Round,
+				BlueTile:   round.BlueTile,
+				RedTile:    round.RedTile,
+				Winner:     round.Winner,
+				BlueWins:   game.BlueWins,
+				RedWins:    game.RedWins,
+				NextPlayer: game.CurrentPlayer,
+			},
+		})
+	}
+
+	// 라운드 도중 끝난 게임 (시간 초과 패배, 취소)
+	if next < len(record.Moves) || record.Reason == reasonTimeout || len(record.Rounds) == 0 {
+		snapshot()
+		playMoves(record.Moves[next:])
+	}
+
+	round := replay.TotalRounds()
+	delay := replayStep(last, record.EndedAt)
+	switch record.Reason {
+	case recordReasonAborted:
+		replay.addFrame(round, delay, Message{
+			Type:    MsgError,
+			Payload: ErrorPayload{Message: "게임이 종료되었습니다"},
+		})
+		return replay
+
+	case reasonTimeout:
+		replay.addFrame(round, delay, Message{
+			Type: MsgTimeout,
+			Payload: TimeoutPayload{
+				Color:  opponentColor(record.Winner),
+				Round:  game.CurrentRound,
+				Policy: record.TimeoutPolicy,
+			},
+		})
+		delay = 0
+	}
+
+	// 종료 사유가 나뉘기 전의 기록은 정상 종료에 사유가 없음
+	reason := record.Reason
+	if reason == recordReasonCompleted {
+		reason = ""
+	}
+	replay.addFrame(round, delay, Message{
+		Type: MsgGameOver,
+		Payload: GameOverPayload{
+			Winner:   record.Winner,
+			BlueWins: record.BlueWins,
+			RedWins:  record.RedWins,
+			Reason:   reason,
+			Ratings:  record.Ratings,
+		},
+	})
+
+	return replay
+}
+
+// ==================== Hub Integration ====================
+
+// replayLoad 디스크에서 읽어 재구성한 다시보기
+type replayLoad struct {
+	Client *Client
+	Replay *Replay
+	Paused bool
+	Speed  float64
+	Err    error
+}
+
+func (h *gameHub[G]) handleReplay(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload ReplayPayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	if _, playing := h.game(client.GameID); playing || client.Room != nil {
+		h.sendError(client, "게임 참가 중에는 다시보기를 할 수 없습니다")
+		return
+	}
+	if h.records == nil {
+		h.sendError(client, "게임 기록을 사용할 수 없습니다")
+		return
+	}
+
+	h.stopReplay(client)
+	h.removeSpectator(client)
+
+	// 기록 읽기와 재구성은 허브 루프 밖에서 처리
+	records := h.records
+	go func() {
+		load := replayLoad{Client: client, Paused: payload.Paused, Speed: payload.Speed}
+		load.Replay, load.Err = h.hooks.loadReplay(records, payload.GameID)
+		h.replayLoaded <- load
+	}()
+}
+
+// handleReplayLoaded 읽어온 기록으로 다시보기 시작
+func (h *gameHub[G]) handleReplayLoaded(load replayLoad) {
+	client := load.Client
+	if _, playing := h.game(client.GameID); !h.connected(client) || playing || client.Room != nil {
+		return
+	}
+	if load.Err != nil {
+		if !errors.Is(load.Err, ErrRecordNotFound) {
+			log.Printf("%sError loading game record for replay: %v", h.logPrefix, load.Err)
+		}
+		h.sendError(client, ErrRecordNotFound.Error())
+		return
+	}
+
+	replay := load.Replay
+	if load.Speed != 0 {
+		if err := replay.SetSpeed(load.Speed); err != nil {
+			h.sendError(client, err.Error())
+			return
+		}
+	}
+
+	h.stopReplay(client)
+	client.Replay = replay
+	h.replays[replay] = client
+	log.Printf("%sClient %s replaying game %s (%d frames)", h.logPrefix, client.ID, replay.GameID, len(replay.Frames))
+
+	snapshot, _ := replay.Seek(1)
+	h.sendData(client, snapshot)
+
+	replay.Playing = !load.Paused
+	replay.schedule(h.replayTick)
+	h.sendReplayState(client)
+}
+
+func (h *gameHub[G]) handleReplayControl(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload ReplayControlPayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	replay := client.Replay
+	if replay == nil {
+		h.sendError(client, "다시보기 중이 아닙니다")
+		return
+	}
+
+	switch payload.Action {
+	case ReplayPlay:
+		replay.Playing = true
+	case ReplayPause:
+		replay.Playing = false
+	case ReplaySeek:
+		snapshot, err := replay.Seek(payload.Round)
+		if err != nil {
+			h.sendError(client, err.Error())
+			return
+		}
+		h.sendData(client, snapshot)
+	case ReplaySpeed:
+		if err := replay.SetSpeed(payload.Speed); err != nil {
+			h.sendError(client, err.Error())
+			return
+		}
+	default:
+		h.sendError(client, "알 수 없는 다시보기 조작입니다")
+		return
+	}
+
+	replay.schedule(h.replayTick)
+	h.sendReplayState(client)
+}
+
+// handleReplayTick 예약된 다음 프레임 전송
+func (h *gameHub[G]) handleReplayTick(tick replayTick) {
+	replay := tick.Replay
+	if replay.seq != tick.Seq || !replay.Playing || replay.Finished() {
+		return
+	}
+
+	// 버퍼가 가득 차 연결이 정리된 클라이언트에는 더 보내지 않음
+	client := h.replays[replay]
+	if client == nil {
+		return
+	}
+	if !h.connected(client) {
+		h.stopReplay(client)
+		return
+	}
+
+	h.sendData(client, replay.Frames[replay.Position].Data)
+	replay.Position++
+
+	if replay.Finished() {
+		replay.Playing = false
+		h.sendReplayState(client)
+		return
+	}
+	replay.schedule(h.replayTick)
+}
+
+// stopReplay 진행 중인 다시보기 종료
+func (h *gameHub[G]) stopReplay(client *Client) {
+	if client.Replay == nil {
+		return
+	}
+	client.Replay.Playing = false
+	client.Replay.stop()
+	delete(h.replays, client.Replay)
+	client.Replay = nil
+}
+
+func (h *gameHub[G]) sendReplayState(client *Client) {
+	replay := client.Replay
+	h.sendToClient(client, Message{
+		Type: h.msg(MsgReplayState),
+		Payload: ReplayStatePayload{
+			GameID:      replay.GameID,
+			Round:       replay.Round(),
+			TotalRounds: replay.TotalRounds(),
+			Playing:     replay.Playing,
+			Speed:       replay.Speed,
+			Finished:    replay.Finished(),
+		},
+	})
+}
+
+// ==================== HTTP ====================
+
+// ServeGameRecord 구룡투 게임 기록 조회 (GET /api/games/ninedragons/{id})
+func ServeGameRecord(store *RecordStore, w http.ResponseWriter, r *http.Request) {
+	record, err := store.LoadGame(r.PathValue("id"))
+	writeRecord(w, record, err)
+}
+
+// ServeNCGameRecord 넘버체인지 게임 기록 조회 (GET /api/games/numberchange/{id})
+func ServeNCGameRecord(store *RecordStore, w http.ResponseWriter, r *http.Request) {
+	record, err := store.LoadNCGame(r.PathValue("id"))
+	writeRecord(w, record, err)
+}
+
+// ServeSeriesRecord 다전제 기록 조회 (GET /api/series/{id})
+func ServeSeriesRecord(store *RecordStore, w http.ResponseWriter, r *http.Request) {
+	record, err := store.LoadSeries(r.PathValue("id"))
+	writeRecord(w, record, err)
+}
+
+// writeRecord 기록을 JSON으로 응답 (종료된 게임 기록은 바뀌지 않으므로 캐시 허용)
+func writeRecord(w http.ResponseWriter, record interface{}, err error) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if errors.Is(err, ErrRecordNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error loading game record: %v", err)
+		http.Error(w, "기록을 읽을 수 없습니다", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=86400, immutable")
+	json.NewEncoder(w).Encode(record)
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testRecord 짧은 규칙으로 파랑이 3-0으로 이긴 게임 기록 (수마다 기록된 시각이 다름)
+func testRecord() *GameRecord {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	return &GameRecord{
+		ID:          "replay",
+		Blue:        PlayerRecord{Name: "blue"},
+		Red:         PlayerRecord{Name: "red"},
+		Rules:       ShortRules(),
+		TileReveal:  RevealBlind,
+		FirstPlayer: Blue,
+		StartedAt:   start,
+		EndedAt:     at(12500 * time.Millisecond),
+		Moves: []MoveRecord{
+			{Round: 1, Color: Blue, Tile: 5, At: at(1 * time.Second)},
+			{Round: 1, Color: Red, Tile: 3, At: at(2 * time.Second)},
+			{Round: 2, Color: Blue, Tile: 4, At: at(2100 * time.Millisecond)}, // 최소 간격으로 늘어남
+			{Round: 2, Color: Red, Tile: 1, At: at(10 * time.Second)},         // 최대 간격으로 줄어듦
+			{Round: 3, Color: Blue, Tile: 3, At: at(11 * time.Second)},
+			{Round: 3, Color: Red, Tile: 2, At: at(12 * time.Second)},
+		},
+		Rounds: []RoundRecord{
+			{Round: 1, Winner: Blue, BlueTile: 5, RedTile: 3},
+			{Round: 2, Winner: Blue, BlueTile: 4, RedTile: 1},
+			{Round: 3, Winner: Blue, BlueTile: 3, RedTile: 2},
+		},
+		Winner:   Blue,
+		BlueWins: 3,
+		Reason:   reasonScoreLimit,
+	}
+}
+
+// replayFrames 프레임마다 메시지 타입과 기록상 간격
+func replayFrames(t *testing.T, replay *Replay) ([]string, []time.Duration) {
+	t.Helper()
+	var types []string
+	var delays []time.Duration
+	for _, frame := range replay.Frames {
+		var msg struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(frame.Data, &msg); err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, msg.Type)
+		delays = append(delays, frame.Delay)
+	}
+	return types, delays
+}
+
+func TestBuildReplayFrames(t *testing.T) {
+	replay := NewHub().buildReplay(testRecord())
+
+	types, delays := replayFrames(t, replay)
+	wantTypes := []string{
+		"tile_played", "tile_played", "round_result",
+		"tile_played", "tile_played", "round_result",
+		"tile_played", "tile_played", "round_result",
+		"game_over",
+	}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Fatalf("frames = %v, want %v", types, wantTypes)
+	}
+	wantDelays := []time.Duration{
+		time.Second, time.Second, 0,
+		replayMinStep, replayMaxStep, 0,
+		time.Second, time.Second, 0,
+		500 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(delays, wantDelays) {
+		t.Errorf("delays = %v, want %v", delays, wantDelays)
+	}
+	if replay.TotalRounds() != 3 {
+		t.Errorf("TotalRounds = %d, want 3", replay.TotalRounds())
+	}
+
+	// 먼저 낸 타일은 관전자처럼 숨기고, 라운드가 끝나는 타일은 공개
+	var first, second TilePlayedPayload
+	decodePayload(t, replay.Frames[0].Data, &first)
+	decodePayload(t, replay.Frames[1].Data, &second)
+	if first.Tile != 0 || first.TileColor != "" || second.Tile != 3 {
+		t.Errorf("round 1 tiles = %+v, %+v", first, second)
+	}
+}
+
+func TestReplaySeek(t *testing.T) {
+	replay := NewHub().buildReplay(testRecord())
+
+	data, err := replay.Seek(2)
+	if err != nil {
+		t.Fatalf("Seek(2): %v", err)
+	}
+	var state GameStatePayload
+	decodePayload(t, data, &state)
+	if state.Round != 2 || state.BlueWins != 1 || state.RedWins != 0 {
+		t.Errorf("snapshot = round %d %d-%d, want round 2 1-0", state.Round, state.BlueWins, state.RedWins)
+	}
+	if replay.Position != 3 || replay.Round() != 2 {
+		t.Errorf("position %d round %d, want 3 2", replay.Position, replay.Round())
+	}
+
+	for _, round := range []int{0, 4} {
+		if _, err := replay.Seek(round); err == nil {
+			t.Errorf("Seek(%d) succeeded", round)
+		}
+	}
+	if replay.Position != 3 {
+		t.Errorf("failed seek moved position to %d", replay.Position)
+	}
+
+	// 끝까지 재생하면 마지막 라운드에 머묾
+	replay.Position = len(replay.Frames)
+	if !replay.Finished() || replay.Round() != 3 {
+		t.Errorf("finished %v round %d, want true 3", replay.Finished(), replay.Round())
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	replay := NewHub().buildReplay(testRecord())
+	replay.Seek(2)
+
+	if err := replay.SetSpeed(2); err != nil {
+		t.Fatalf("SetSpeed(2): %v", err)
+	}
+	if got := replay.nextDelay(); got != replayMinStep/2 {
+		t.Errorf("nextDelay at 2x = %v, want %v", got, replayMinStep/2)
+	}
+
+	for _, speed := range []float64{0, minReplaySpeed / 2, maxReplaySpeed * 2} {
+		if err := replay.SetSpeed(speed); err == nil {
+			t.Errorf("SetSpeed(%v) succeeded", speed)
+		}
+	}
+	if replay.Speed != 2 {
+		t.Errorf("rejected speed changed Speed to %v", replay.Speed)
+	}
+}
+
+func TestReplayStep(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{time.Time{}, base, replayMinStep},
+		{base, base.Add(time.Millisecond), replayMinStep},
+		{base, base.Add(time.Second), time.Second},
+		{base, base.Add(time.Hour), replayMaxStep},
+		{base, base.Add(-time.Second), replayMinStep},
+	}
+	for _, tt := range tests {
+		if got := replayStep(tt.from, tt.to); got != tt.want {
+			t.Errorf("replayStep(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// decodePayload 직렬화된 메시지의 payload를 out에 디코딩
+func decodePayload(t *testing.T, data []byte, out interface{}) {
+	t.Helper()
+	var msg struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(msg.Payload, out); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import "log"
+
+// 기권, 무승부 요청을 받을 수 없을 때 보내는 에러
+const (
+	noGameInProgress = "진행 중인 게임이 없습니다"
+	drawOpponentAway = "상대가 연결되어 있지 않아 무승부를 제안할 수 없습니다"
+)
+
+// OfferDraw color의 무승부 제안 (상대가 먼저 제안해 둔 상태면 합의로 true)
+func (g *Game) OfferDraw(color PlayerColor) (bool, error) {
+	return g.offerDraw(color, opponentColor(color), g.CurrentRound)
+}
+
+// OfferDraw team의 무승부 제안 (상대가 먼저 제안해 둔 상태면 합의로 true)
+func (g *NCGame) OfferDraw(team TeamColor) (bool, error) {
+	return g.offerDraw(team, opponentTeam(team), g.CurrentRound)
+}
+
+// ==================== Hub Integration ====================
+
+// inGame client가 시작한 game에 플레이어로 참가 중인지 (아니면 에러 전송 후 false)
+func (h *gameHub[G]) inGame(game G, client *Client) bool {
+	t := game.table()
+	if t.Players[client.Seat] != client || t.StartedAt.IsZero() {
+		h.sendError(client, noGameInProgress)
+		return false
+	}
+	return true
+}
+
+func (h *gameHub[G]) handleResign(game G, client *Client) {
+	if !h.inGame(game, client) {
+		return
+	}
+	log.Printf("%sPlayer %s resigned game %s", h.logPrefix, client.ID, game.table().ID)
+	h.endGame(game, h.opponent(client.Seat), reasonResigned)
+}
+
+func (h *gameHub[G]) handleOfferDraw(game G, client *Client) {
+	if !h.inGame(game, client) {
+		return
+	}
+	t := game.table()
+
+	// 연결이 끊긴 상대에게는 새 제안을 전할 수 없음 (상대가 남긴 제안을 받아들이는 것은 가능)
+	opponent := h.opponent(client.Seat)
+	if t.DrawOffer != opponent && !t.IsConnected(opponent) {
+		h.sendError(client, drawOpponentAway)
+		return
+	}
+
+	agreed, err := game.OfferDraw(client.Seat)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+	if agreed {
+		h.endGame(game, "", reasonDrawAgreed)
+		return
+	}
+	log.Printf("%sPlayer %s offered a draw in game %s", h.logPrefix, client.ID, t.ID)
+
+	// AI는 무승부 제안을 받지 않음
+	if t.Bots[opponent] != nil {
+		h.declineDraw(game, opponent)
+		return
+	}
+
+	h.sendToClient(t.Players[opponent], Message{
+		Type:    h.msg(MsgDrawOffered),
+		Payload: h.hooks.drawOffer(client.Seat),
+	})
+}
+
+func (h *gameHub[G]) handleAcceptDraw(game G, client *Client) {
+	if !h.inGame(game, client) {
+		return
+	}
+	if game.table().DrawOffer != h.opponent(client.Seat) {
+		h.sendError(client, "상대가 무승부를 제안하지 않았습니다")
+		return
+	}
+	h.endGame(game, "", reasonDrawAgreed)
+}
+
+func (h *gameHub[G]) handleDeclineDraw(game G, client *Client) {
+	if !h.inGame(game, client) {
+		return
+	}
+	if game.table().DrawOffer != h.opponent(client.Seat) {
+		h.sendError(client, "상대가 무승부를 제안하지 않았습니다")
+		return
+	}
+	h.declineDraw(game, client.Seat)
+}
+
+// declineDraw seat이 상대의 무승부 제안을 거절하고 제안한 플레이어에게 알림
+func (h *gameHub[G]) declineDraw(game G, seat Seat) {
+	t := game.table()
+	t.DrawOffer = ""
+	if offerer := t.Players[h.opponent(seat)]; offerer != nil {
+		h.sendToClient(offerer, Message{
+			Type:    h.msg(MsgDrawDeclined),
+			Payload: h.hooks.drawOffer(seat),
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOfferDraw(t *testing.T) {
+	rules, _ := RuleSetByName(RuleSetClassic)
+	game := NewGame(rules)
+
+	steps := []struct {
+		name    string
+		color   PlayerColor
+		decline bool // 제안 대신 상대 제안 거절
+		agreed  bool
+		wantErr bool
+	}{
+		{name: "파랑 제안", color: Blue},
+		{name: "같은 제안 반복", color: Blue, wantErr: true},
+		{name: "빨강 거절", color: Red, decline: true},
+		{name: "거절당한 라운드에 다시 제안", color: Blue, wantErr: true},
+		{name: "빨강 제안", color: Red},
+		{name: "파랑이 맞제안하면 합의", color: Blue, agreed: true},
+	}
+	for _, step := range steps {
+		if step.decline {
+			game.DrawOffer = ""
+			continue
+		}
+		agreed, err := game.OfferDraw(step.color)
+		if (err != nil) != step.wantErr || agreed != step.agreed {
+			t.Errorf("%s: OfferDraw = %v, %v, want agreed %v, error %v", step.name, agreed, err, step.agreed, step.wantErr)
+		}
+	}
+}
+
+func TestDrawOfferExpiresAtRoundEnd(t *testing.T) {
+	h, game, blue, red := testGame(t)
+
+	h.handleOfferDraw(game, blue)
+	if game.DrawOffer != Blue {
+		t.Fatalf("DrawOffer = %q, want %q", game.DrawOffer, Blue)
+	}
+	game.PlayTile(Blue, 5)
+	game.PlayTile(Red, 3)
+	game.ProcessRound()
+	if game.DrawOffer != "" {
+		t.Fatalf("DrawOffer = %q after the round ended, want none", game.DrawOffer)
+	}
+
+	sent(t, red)
+	h.handleAcceptDraw(game, red)
+	if got := lastError(t, red); got != "상대가 무승부를 제안하지 않았습니다" {
+		t.Errorf("accepting an expired offer: error = %q", got)
+	}
+
+	// 다음 라운드에는 다시 제안할 수 있음
+	if _, err := game.OfferDraw(Blue); err != nil {
+		t.Errorf("offering again next round = %v", err)
+	}
+}
+
+func TestOfferDrawToAbsentOpponent(t *testing.T) {
+	h, game, blue, red := testGame(t)
+	delete(game.Players, Red) // 빨강 연결 끊김 (좌석은 유지)
+
+	h.handleOfferDraw(game, blue)
+	if got := lastError(t, blue); got != drawOpponentAway {
+		t.Errorf("error = %q, want %q", got, drawOpponentAway)
+	}
+	if game.DrawOffer != "" || game.DrawOfferedAt[Blue] != 0 {
+		t.Error("rejected offer was recorded")
+	}
+
+	// 끊기기 전에 상대가 남긴 제안은 받아들일 수 있음
+	game.Players[Red] = red
+	h.handleOfferDraw(game, red)
+	delete(game.Players, Red)
+	h.handleOfferDraw(game, blue)
+	for _, msg := range sent(t, blue) {
+		if msg.Type == MsgGameOver {
+			return
+		}
+	}
+	t.Error("accepting the absent opponent's standing offer did not end the game")
+}
+
+func TestDrawOfferInResumeState(t *testing.T) {
+	h, game, _, red := testGame(t)
+	h.handleOfferDraw(game, red)
+
+	if got := game.State(Blue).DrawOffer; got != Red {
+		t.Errorf("Blue's state DrawOffer = %q, want %q", got, Red)
+	}
+	if got := game.State("").DrawOffer; got != "" {
+		t.Errorf("spectator state DrawOffer = %q, want none", got)
+	}
+}
+
+// gameOver client가 받은 게임 종료 알림 (없으면 nil)
+func gameOver(t *testing.T, client *Client) *GameOverPayload {
+	t.Helper()
+	for _, msg := range sent(t, client) {
+		if msg.Type == MsgGameOver {
+			var payload GameOverPayload
+			json.Unmarshal(msg.Payload, &payload)
+			return &payload
+		}
+	}
+	return nil
+}
+
+func TestResignAndAgreedDraw(t *testing.T) {
+	tests := []struct {
+		name       string
+		act        func(h *Hub, game *Game, blue, red *Client)
+		wantWinner PlayerColor
+		wantReason string
+	}{
+		{
+			name:       "파랑 기권",
+			act:        func(h *Hub, game *Game, blue, red *Client) { h.handleResign(game, blue) },
+			wantWinner: Red,
+			wantReason: reasonResigned,
+		},
+		{
+			name: "빨강이 파랑 제안 수락",
+			act: func(h *Hub, game *Game, blue, red *Client) {
+				h.handleOfferDraw(game, blue)
+				h.handleAcceptDraw(game, red)
+			},
+			wantReason: reasonDrawAgreed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, game, blue, red := testGame(t)
+			tt.act(h, game, blue, red)
+
+			for _, c := range []*Client{blue, red} {
+				over := gameOver(t, c)
+				if over == nil {
+					t.Fatalf("%s got no game over", c.ID)
+				}
+				if over.Winner != tt.wantWinner || over.Reason != tt.wantReason {
+					t.Errorf("%s game over = %q %q, want %q %q", c.ID, over.Winner, over.Reason, tt.wantWinner, tt.wantReason)
+				}
+			}
+			if h.games[game.ID] != nil {
+				t.Error("finished game is still open")
+			}
+		})
+	}
+}
+
+func TestDeclineDraw(t *testing.T) {
+	h, game, blue, red := testGame(t)
+
+	h.handleDeclineDraw(game, red)
+	if got := lastError(t, red); got != "상대가 무승부를 제안하지 않았습니다" {
+		t.Errorf("declining without an offer: error = %q", got)
+	}
+
+	h.handleOfferDraw(game, blue)
+	var offered bool
+	for _, msg := range sent(t, red) {
+		offered = offered || msg.Type == MsgDrawOffered
+	}
+	if !offered {
+		t.Fatal("red was not told about the offer")
+	}
+
+	h.handleDeclineDraw(game, red)
+	var declined bool
+	for _, msg := range sent(t, blue) {
+		declined = declined || msg.Type == MsgDrawDeclined
+	}
+	if !declined || game.DrawOffer != "" {
+		t.Errorf("declined %v, DrawOffer %q", declined, game.DrawOffer)
+	}
+}
+
+// AI는 무승부 제안을 바로 거절함
+func TestBotDeclinesDraw(t *testing.T) {
+	h, game, blue, red := testGame(t)
+	delete(game.Players, Red)
+	h.removeClient(red)
+	bot, _ := NewBot(Red, BotGreedy)
+	game.Bots[Red] = bot
+
+	h.handleOfferDraw(game, blue)
+	var declined bool
+	for _, msg := range sent(t, blue) {
+		declined = declined || msg.Type == MsgDrawDeclined
+	}
+	if !declined || game.DrawOffer != "" {
+		t.Errorf("declined %v, DrawOffer %q", declined, game.DrawOffer)
+	}
+}
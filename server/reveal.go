@@ -0,0 +1,59 @@
+package server
+
+// 타일 색 구분 (실물 구룡투와 동일하게 홀수는 검정, 짝수는 흰색)
+const (
+	tileBandBlack = "black"
+	tileBandWhite = "white"
+)
+
+// TileBand 타일 숫자의 색 구분
+func TileBand(tile int) string {
+	if tile%2 == 1 {
+		return tileBandBlack
+	}
+	return tileBandWhite
+}
+
+// tileRevealOrDefault 알 수 없는 공개 범위는 기본값(blind)으로 대체
+func tileRevealOrDefault(reveal TileReveal) TileReveal {
+	switch reveal {
+	case RevealBlind, RevealColor, RevealOpen:
+		return reveal
+	}
+	return RevealBlind
+}
+
+// spectatorReveal 관전자 공개 범위 (관전자가 숫자를 상대에게 전달할 수 없도록 open은 color로 제한)
+func spectatorReveal(reveal TileReveal) TileReveal {
+	if reveal == RevealOpen {
+		return RevealColor
+	}
+	return reveal
+}
+
+// concealTile 라운드가 끝나기 전 공개 범위를 넘는 타일 정보 제거
+func concealTile(played TilePlayedPayload, reveal TileReveal) TilePlayedPayload {
+	switch reveal {
+	case RevealOpen:
+	case RevealColor:
+		played.Tile = 0
+	default:
+		played.Tile = 0
+		played.TileColor = ""
+	}
+	return played
+}
+
+// visibleUsedTiles viewer에게 보이는 color의 사용 타일 목록
+// 자신의 타일과 open 모드의 상대 타일을 제외하면 이번 라운드에 낸 타일은 숨긴다
+func visibleUsedTiles(game *Game, color, viewer PlayerColor) []int {
+	if color == viewer || (viewer != "" && game.TileReveal == RevealOpen) {
+		return game.UsedTiles[color]
+	}
+
+	used := game.UsedTiles[color]
+	if game.RoundTiles[color] != nil && len(used) > 0 {
+		return used[:len(used)-1]
+	}
+	return used
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 라운드가 끝나기 전 상대와 관전자가 보는 타일 정보 (관전자는 open에서도 색까지만)
+func TestConcealTile(t *testing.T) {
+	tests := []struct {
+		reveal        TileReveal
+		spectator     bool
+		wantTile      int
+		wantTileColor string
+	}{
+		{reveal: RevealBlind},
+		{reveal: RevealBlind, spectator: true},
+		{reveal: RevealColor, wantTileColor: tileBandBlack},
+		{reveal: RevealColor, spectator: true, wantTileColor: tileBandBlack},
+		{reveal: RevealOpen, wantTile: 7, wantTileColor: tileBandBlack},
+		{reveal: RevealOpen, spectator: true, wantTileColor: tileBandBlack},
+	}
+
+	played := TilePlayedPayload{Color: Blue, Tile: 7, TileColor: TileBand(7), Round: 1, BlueTilePlayed: true}
+	for _, tt := range tests {
+		reveal := tt.reveal
+		if tt.spectator {
+			reveal = spectatorReveal(reveal)
+		}
+		got := concealTile(played, reveal)
+		if got.Tile != tt.wantTile || got.TileColor != tt.wantTileColor {
+			t.Errorf("%s (spectator %v): tile %d %q, want %d %q",
+				tt.reveal, tt.spectator, got.Tile, got.TileColor, tt.wantTile, tt.wantTileColor)
+		}
+		if got.Color != Blue || got.Round != 1 || !got.BlueTilePlayed {
+			t.Errorf("%s (spectator %v): concealed more than the tile: %+v", tt.reveal, tt.spectator, got)
+		}
+	}
+}
+
+// 재접속이나 관전으로 받는 상태의 사용 타일 목록은 이번 라운드에 낸 타일을 공개 범위만큼만 보여준다
+func TestVisibleUsedTiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal TileReveal
+		viewer PlayerColor // 비어 있으면 관전자
+		want   []int
+	}{
+		{name: "blind 본인", reveal: RevealBlind, viewer: Blue, want: []int{3, 7}},
+		{name: "blind 상대", reveal: RevealBlind, viewer: Red, want: []int{3}},
+		{name: "blind 관전자", reveal: RevealBlind, want: []int{3}},
+		{name: "color 본인", reveal: RevealColor, viewer: Blue, want: []int{3, 7}},
+		{name: "color 상대", reveal: RevealColor, viewer: Red, want: []int{3}},
+		{name: "color 관전자", reveal: RevealColor, want: []int{3}},
+		{name: "open 본인", reveal: RevealOpen, viewer: Blue, want: []int{3, 7}},
+		{name: "open 상대", reveal: RevealOpen, viewer: Red, want: []int{3, 7}},
+		{name: "open 관전자", reveal: RevealOpen, want: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, game, _, _ := testGame(t)
+			game.TileReveal = tt.reveal
+
+			// 첫 라운드를 끝내고 다음 라운드에 파랑만 타일을 냄
+			for _, color := range []PlayerColor{game.CurrentPlayer, opponentColor(game.CurrentPlayer)} {
+				tile := 3
+				if color == Red {
+					tile = 5
+				}
+				if err := game.PlayTile(color, tile); err != nil {
+					t.Fatal(err)
+				}
+			}
+			game.ProcessRound()
+			game.CurrentPlayer = Blue
+			if err := game.PlayTile(Blue, 7); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := visibleUsedTiles(game, Blue, tt.viewer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("visibleUsedTiles = %v, want %v", got, tt.want)
+			}
+			if got := game.State(tt.viewer).BlueUsedTiles; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("State().BlueUsedTiles = %v, want %v", got, tt.want)
+			}
+			// 아직 내지 않은 쪽의 목록은 누구에게나 그대로
+			if got := game.State(tt.viewer).RedUsedTiles; !reflect.DeepEqual(got, []int{5}) {
+				t.Errorf("State().RedUsedTiles = %v, want [5]", got)
+			}
+
+			// 라운드가 끝나면 모두에게 공개
+			game.PlayTile(Red, 1)
+			game.ProcessRound()
+			if got := visibleUsedTiles(game, Blue, tt.viewer); !reflect.DeepEqual(got, []int{3, 7}) {
+				t.Errorf("after the round: visibleUsedTiles = %v, want [3 7]", got)
+			}
+		})
+	}
+}
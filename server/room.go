@@ -0,0 +1,329 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+)
+
+// 방 코드 및 만료 설정
+const (
+	roomCodeLength   = 6
+	roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 헷갈리는 문자(0, O, 1, I) 제외
+	roomTTL          = 30 * time.Minute
+)
+
+// 방 취소 사유
+const (
+	roomCancelledByOwner = "cancelled"
+	roomExpired          = "expired"
+	roomOwnerLeft        = "owner_left"
+)
+
+// newRoomCode 사람이 읽기 쉬운 방 코드 생성 (이미 사용 중인 코드는 다시 생성)
+func newRoomCode(taken func(string) bool) string {
+	buf := make([]byte, roomCodeLength)
+	for {
+		rand.Read(buf)
+		for i, b := range buf {
+			buf[i] = roomCodeAlphabet[int(b)%len(roomCodeAlphabet)]
+		}
+		if code := string(buf); !taken(code) {
+			return code
+		}
+	}
+}
+
+// normalizeRoomCode 입력된 방 코드 정규화
+func normalizeRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// roomPasswordMatches 방 비밀번호 확인
+func roomPasswordMatches(password, given string) bool {
+	return subtle.ConstantTimeCompare([]byte(password), []byte(given)) == 1
+}
+
+// roomRequest 게임 종류와 상관없는 방 만들기 요청 항목
+type roomRequest struct {
+	PlayerName string `json:"playerName"`
+	Password   string `json:"password,omitempty"`
+	BestOf     int    `json:"bestOf,omitempty"`
+}
+
+// roomConfig 게임 종류와 상관없는 방 설정 변경 항목 (비어있는 항목은 유지)
+type roomConfig struct {
+	Password *string `json:"password,omitempty"`
+	BestOf   *int    `json:"bestOf,omitempty"`
+}
+
+// ==================== Hub Integration ====================
+
+func (h *gameHub[G]) handleCreateRoom(client *Client, msg Message) {
+	data, _ := json.Marshal(msg.Payload)
+	var payload roomRequest
+	json.Unmarshal(data, &payload)
+
+	if _, playing := h.game(client.GameID); playing || client.Room != nil || h.queue.Contains(client.ID) {
+		h.sendError(client, "이미 다른 게임에 참가 중입니다")
+		return
+	}
+	h.leaveRematch(client)
+
+	bestOf, err := normalizeBestOf(payload.BestOf)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+
+	setup, err := h.hooks.roomSetup(data)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+
+	seat := h.seats[0]
+	if setup.Seat == h.seats[1] {
+		seat = setup.Seat
+	}
+
+	room := &Room{
+		Code:      newRoomCode(func(code string) bool { return h.rooms[code] != nil }),
+		Owner:     client,
+		Password:  payload.Password,
+		Members:   map[Seat]*Client{seat: client},
+		Settings:  setup.Settings,
+		BestOf:    bestOf,
+		ExpiresAt: time.Now().Add(roomTTL),
+	}
+
+	code := room.Code
+	room.expiryTimer = time.AfterFunc(roomTTL, func() {
+		h.roomExpired <- code
+	})
+
+	h.rooms[room.Code] = room
+	client.Name = payload.PlayerName
+	client.Room = room
+
+	log.Printf("%sPlayer %s created room %s as %s", h.logPrefix, client.ID, room.Code, seat)
+
+	h.sendToClient(client, Message{
+		Type:    h.msg(MsgRoomCreated),
+		Payload: h.hooks.roomPayload(room, seat),
+	})
+}
+
+func (h *gameHub[G]) handleJoinRoom(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload JoinRoomPayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	if _, playing := h.game(client.GameID); playing || client.Room != nil || h.queue.Contains(client.ID) {
+		h.sendError(client, "이미 다른 게임에 참가 중입니다")
+		return
+	}
+	h.leaveRematch(client)
+
+	room := h.rooms[normalizeRoomCode(payload.Code)]
+	if room == nil {
+		h.sendError(client, "방을 찾을 수 없습니다")
+		return
+	}
+	if time.Now().After(room.ExpiresAt) {
+		h.cancelRoom(room, roomExpired)
+		h.sendError(client, "만료된 방입니다")
+		return
+	}
+	if len(room.Members) >= len(h.seats) {
+		h.sendError(client, "방이 가득 찼습니다")
+		return
+	}
+	if room.Password != "" && !roomPasswordMatches(room.Password, payload.Password) {
+		h.sendError(client, "비밀번호가 일치하지 않습니다")
+		return
+	}
+
+	seat := h.seats[0]
+	if room.Members[seat] != nil {
+		seat = h.seats[1]
+	}
+
+	room.Members[seat] = client
+	client.Name = payload.PlayerName
+	client.Room = room
+
+	log.Printf("%sPlayer %s joined room %s as %s", h.logPrefix, client.ID, room.Code, seat)
+
+	h.broadcastRoom(room)
+}
+
+func (h *gameHub[G]) handleStartRoom(client *Client) {
+	room := h.ownedRoom(client)
+	if room == nil {
+		return
+	}
+	if len(room.Members) < len(h.seats) {
+		h.sendError(client, "상대방이 아직 입장하지 않았습니다")
+		return
+	}
+
+	h.removeRoom(room)
+
+	game := h.hooks.newGame(room.Settings)
+	t := game.table()
+	t.Casual = true // 비공개 방은 친선 게임
+	t.startSeries(room.BestOf, h.seats)
+	h.addGame(game)
+	log.Printf("%sRoom %s started game %s", h.logPrefix, room.Code, t.ID)
+
+	if h.seatPlayers(game, room.Members) {
+		h.startGame(game)
+	}
+}
+
+func (h *gameHub[G]) handleCancelRoom(client *Client) {
+	room := client.Room
+	if room == nil {
+		h.sendError(client, "참가 중인 방이 없습니다")
+		return
+	}
+
+	// 방장이 아니면 방에서 나가기
+	if room.Owner != client {
+		h.leaveRoom(client)
+		return
+	}
+
+	h.cancelRoom(room, roomCancelledByOwner)
+}
+
+func (h *gameHub[G]) handleConfigureRoom(client *Client, msg Message) {
+	room := h.ownedRoom(client)
+	if room == nil {
+		return
+	}
+
+	data, _ := json.Marshal(msg.Payload)
+	var payload roomConfig
+	json.Unmarshal(data, &payload)
+
+	settings, err := h.hooks.configureRoom(room.Settings, data)
+	if err != nil {
+		h.sendError(client, err.Error())
+		return
+	}
+	bestOf := room.BestOf
+	if payload.BestOf != nil {
+		if bestOf, err = normalizeBestOf(*payload.BestOf); err != nil {
+			h.sendError(client, err.Error())
+			return
+		}
+	}
+
+	room.Settings = settings
+	room.BestOf = bestOf
+	if payload.Password != nil {
+		room.Password = *payload.Password
+	}
+
+	log.Printf("%sRoom %s reconfigured", h.logPrefix, room.Code)
+
+	h.broadcastRoom(room)
+}
+
+// handleRoomExpiry 시작되지 않은 채 만료된 방 정리
+func (h *gameHub[G]) handleRoomExpiry(code string) {
+	if room := h.rooms[code]; room != nil {
+		h.cancelRoom(room, roomExpired)
+	}
+}
+
+// ownedRoom 방장이 참가 중인 방 (방장이 아니면 에러 전송 후 nil)
+func (h *gameHub[G]) ownedRoom(client *Client) *Room {
+	room := client.Room
+	if room == nil {
+		h.sendError(client, "참가 중인 방이 없습니다")
+		return nil
+	}
+	if room.Owner != client {
+		h.sendError(client, "방장만 할 수 있습니다")
+		return nil
+	}
+	return room
+}
+
+// leaveRoom 방에서 나가기 (방장이 나가면 방 취소)
+func (h *gameHub[G]) leaveRoom(client *Client) {
+	room := client.Room
+	for seat, member := range room.Members {
+		if member == client {
+			delete(room.Members, seat)
+		}
+	}
+	client.Room = nil
+
+	if room.Owner == client {
+		h.cancelRoom(room, roomOwnerLeft)
+		return
+	}
+
+	log.Printf("%sPlayer %s left room %s", h.logPrefix, client.ID, room.Code)
+
+	h.broadcastRoom(room)
+}
+
+// cancelRoom 방 참가자에게 알리고 방 삭제
+func (h *gameHub[G]) cancelRoom(room *Room, reason string) {
+	h.removeRoom(room)
+
+	for _, member := range room.Members {
+		h.sendToClient(member, Message{
+			Type: h.msg(MsgRoomCancelled),
+			Payload: map[string]string{
+				"code":   room.Code,
+				"reason": reason,
+			},
+		})
+	}
+
+	log.Printf("%sRoom %s cancelled (%s)", h.logPrefix, room.Code, reason)
+}
+
+// removeRoom 방 목록에서 제거하고 만료 타이머 중지
+func (h *gameHub[G]) removeRoom(room *Room) {
+	if room.expiryTimer != nil {
+		room.expiryTimer.Stop()
+	}
+	delete(h.rooms, room.Code)
+
+	for _, member := range room.Members {
+		member.Room = nil
+	}
+}
+
+// broadcastRoom 방 참가자 모두에게 현재 방 상태 전송
+func (h *gameHub[G]) broadcastRoom(room *Room) {
+	for seat, member := range room.Members {
+		h.sendToClient(member, Message{
+			Type:    h.msg(MsgRoomUpdated),
+			Payload: h.hooks.roomPayload(room, seat),
+		})
+	}
+}
+
+// seatNames 방장의 좌석과 좌석별 참가자 이름
+func (room *Room) seatNames() (Seat, map[Seat]string) {
+	var owner Seat
+	names := make(map[Seat]string)
+	for seat, member := range room.Members {
+		if member == room.Owner {
+			owner = seat
+		}
+		names[seat] = member.Name
+	}
+	return owner, names
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRoom 방장이 비밀번호를 걸어 만든 방과 아직 입장하지 않은 손님
+func testRoom(t *testing.T) (*Hub, *Room, *Client, *Client) {
+	t.Helper()
+	h := NewHub()
+	owner := testClient("owner", 64)
+	guest := testClient("guest", 64)
+	h.addClient(owner)
+	h.addClient(guest)
+
+	h.handleCreateRoom(owner, Message{Payload: CreateRoomPayload{PlayerName: "방장", Password: "secret"}})
+	room := owner.Room
+	if room == nil {
+		t.Fatalf("room not created: %q", lastError(t, owner))
+	}
+	t.Cleanup(func() { room.expiryTimer.Stop() })
+	sent(t, owner)
+	return h, room, owner, guest
+}
+
+// joinTestRoom 손님을 방에 입장시킴
+func joinTestRoom(t *testing.T, h *Hub, room *Room, guest *Client) {
+	t.Helper()
+	h.handleJoinRoom(guest, Message{Payload: JoinRoomPayload{PlayerName: "손님", Code: room.Code, Password: "secret"}})
+	if guest.Room != room {
+		t.Fatalf("guest did not join: %q", lastError(t, guest))
+	}
+	sent(t, room.Owner)
+	sent(t, guest)
+}
+
+// roomCancelled client가 받은 방 취소 사유 (없으면 빈 값)
+func roomCancelled(t *testing.T, client *Client) string {
+	t.Helper()
+	reason := ""
+	for _, msg := range sent(t, client) {
+		if msg.Type == MsgRoomCancelled {
+			var payload map[string]string
+			json.Unmarshal(msg.Payload, &payload)
+			reason = payload["reason"]
+		}
+	}
+	return reason
+}
+
+func TestJoinRoom(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(room *Room)
+		code       func(room *Room) string
+		password   string
+		wantErr    string
+		wantCancel string // 방장이 받는 방 취소 사유
+	}{
+		{name: "입장", password: "secret"},
+		{
+			name:     "소문자 코드로 입장",
+			code:     func(room *Room) string { return " " + strings.ToLower(room.Code) + " " },
+			password: "secret",
+		},
+		{
+			name:     "없는 방",
+			code:     func(room *Room) string { return "ZZZZZZ" },
+			password: "secret",
+			wantErr:  "방을 찾을 수 없습니다",
+		},
+		{
+			name:       "만료된 방",
+			setup:      func(room *Room) { room.ExpiresAt = time.Now().Add(-time.Second) },
+			password:   "secret",
+			wantErr:    "만료된 방입니다",
+			wantCancel: roomExpired,
+		},
+		{
+			name:     "가득 찬 방",
+			setup:    func(room *Room) { room.Members[Red] = testClient("other", 64) },
+			password: "secret",
+			wantErr:  "방이 가득 찼습니다",
+		},
+		{name: "틀린 비밀번호", password: "wrong", wantErr: "비밀번호가 일치하지 않습니다"},
+		{name: "비밀번호 없이", wantErr: "비밀번호가 일치하지 않습니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, room, owner, guest := testRoom(t)
+			if tt.setup != nil {
+				tt.setup(room)
+			}
+			code := room.Code
+			if tt.code != nil {
+				code = tt.code(room)
+			}
+
+			h.handleJoinRoom(guest, Message{Payload: JoinRoomPayload{PlayerName: "손님", Code: code, Password: tt.password}})
+
+			if got := lastError(t, guest); got != tt.wantErr {
+				t.Fatalf("error = %q, want %q", got, tt.wantErr)
+			}
+			if got := roomCancelled(t, owner); got != tt.wantCancel {
+				t.Errorf("owner room cancelled = %q, want %q", got, tt.wantCancel)
+			}
+			if tt.wantCancel != "" && (h.rooms[room.Code] != nil || owner.Room != nil) {
+				t.Error("cancelled room was kept")
+			}
+			joined := tt.wantErr == ""
+			if (guest.Room == room) != joined || (room.Members[Red] == guest) != joined {
+				t.Errorf("guest joined = %v, want %v", guest.Room == room, joined)
+			}
+		})
+	}
+}
+
+// 방 시작, 설정, 취소는 방장만 할 수 있고 손님이 취소하면 방에서 나가기만 한다
+func TestRoomOwnerOnly(t *testing.T) {
+	bestOf := 3
+	tests := []struct {
+		name        string
+		act         func(h *Hub, client *Client)
+		byOwner     bool
+		wantErr     string
+		wantRoom    bool   // 방이 남아 있는지
+		wantCancel  string // 손님이 받는 방 취소 사유
+		wantStarted bool
+		wantLeft    bool // 손님이 방에서 나갔는지
+		wantBestOf  int
+	}{
+		{
+			name:     "손님이 시작",
+			act:      func(h *Hub, client *Client) { h.handleStartRoom(client) },
+			wantErr:  "방장만 할 수 있습니다",
+			wantRoom: true,
+		},
+		{
+			name: "손님이 설정 변경",
+			act: func(h *Hub, client *Client) {
+				h.handleConfigureRoom(client, Message{Payload: ConfigureRoomPayload{BestOf: &bestOf}})
+			},
+			wantErr:  "방장만 할 수 있습니다",
+			wantRoom: true,
+		},
+		{
+			name:     "손님이 취소하면 나가기",
+			act:      func(h *Hub, client *Client) { h.handleCancelRoom(client) },
+			wantRoom: true,
+			wantLeft: true,
+		},
+		{
+			name: "방장이 설정 변경",
+			act: func(h *Hub, client *Client) {
+				h.handleConfigureRoom(client, Message{Payload: ConfigureRoomPayload{BestOf: &bestOf}})
+			},
+			byOwner:    true,
+			wantRoom:   true,
+			wantBestOf: bestOf,
+		},
+		{
+			name:       "방장이 취소",
+			act:        func(h *Hub, client *Client) { h.handleCancelRoom(client) },
+			byOwner:    true,
+			wantCancel: roomCancelledByOwner,
+		},
+		{
+			name:        "방장이 시작",
+			act:         func(h *Hub, client *Client) { h.handleStartRoom(client) },
+			byOwner:     true,
+			wantStarted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, room, owner, guest := testRoom(t)
+			joinTestRoom(t, h, room, guest)
+
+			actor := guest
+			if tt.byOwner {
+				actor = owner
+			}
+			tt.act(h, actor)
+
+			if game := h.games[guest.GameID]; game != nil {
+				stopStartedGame(t, h, game)
+			}
+			if got := lastError(t, actor); got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+			if (h.rooms[room.Code] != nil) != tt.wantRoom {
+				t.Errorf("room kept = %v, want %v", h.rooms[room.Code] != nil, tt.wantRoom)
+			}
+			if got := roomCancelled(t, guest); got != tt.wantCancel {
+				t.Errorf("guest room cancelled = %q, want %q", got, tt.wantCancel)
+			}
+			wantIn := tt.wantRoom && !tt.wantLeft
+			if inRoom := guest.Room == room; inRoom != wantIn || (room.Members[Red] == guest) == tt.wantLeft {
+				t.Errorf("guest in room = %v, members %v", inRoom, room.Members)
+			}
+			if started := h.games[owner.GameID] != nil; started != tt.wantStarted {
+				t.Errorf("game started = %v, want %v", started, tt.wantStarted)
+			}
+			if room.BestOf != tt.wantBestOf {
+				t.Errorf("best of = %d, want %d", room.BestOf, tt.wantBestOf)
+			}
+		})
+	}
+}
+
+// 시작 전에 방장의 연결이 끊기면 방을 정리하고, 손님이 끊기면 방장만 남긴다
+func TestRoomDisconnect(t *testing.T) {
+	t.Run("방장", func(t *testing.T) {
+		h, room, owner, guest := testRoom(t)
+		joinTestRoom(t, h, room, guest)
+
+		h.handleDisconnect(owner)
+		if h.rooms[room.Code] != nil || owner.Room != nil || guest.Room != nil {
+			t.Fatal("room was kept after the owner left")
+		}
+		if got := roomCancelled(t, guest); got != roomOwnerLeft {
+			t.Errorf("guest room cancelled = %q, want %q", got, roomOwnerLeft)
+		}
+	})
+
+	t.Run("손님", func(t *testing.T) {
+		h, room, owner, guest := testRoom(t)
+		joinTestRoom(t, h, room, guest)
+
+		h.handleDisconnect(guest)
+		if h.rooms[room.Code] != room || owner.Room != room || guest.Room != nil || len(room.Members) != 1 {
+			t.Fatalf("room after guest left: members %v", room.Members)
+		}
+		var updated bool
+		for _, msg := range sent(t, owner) {
+			updated = updated || msg.Type == MsgRoomUpdated
+		}
+		if !updated {
+			t.Error("owner was not told the guest left")
+		}
+	})
+}
+
+// seatTakenHooks 방 게임을 만들 때 빨강 좌석에 AI를 미리 앉혀 손님이 앉을 자리를 없앰
+type seatTakenHooks struct{ *Hub }
+
+func (h seatTakenHooks) newGame(settings interface{}) *Game {
+	game := h.Hub.newGame(settings)
+	bot, _ := NewBot(Red, BotRandom)
+	game.AddBot(bot)
+	return game
+}
+
+// 방 멤버를 모두 앉히지 못하면 게임을 시작하지 않고 취소한다
+func TestStartRoomSeatError(t *testing.T) {
+	h, room, owner, guest := testRoom(t)
+	joinTestRoom(t, h, room, guest)
+	h.hooks = seatTakenHooks{h}
+
+	h.handleStartRoom(owner)
+
+	if len(h.games) != 0 {
+		t.Fatalf("games = %v, want the room game closed", h.games)
+	}
+	if h.rooms[room.Code] != nil {
+		t.Error("room kept after starting")
+	}
+	if got := lastError(t, owner); got != opponentLeft {
+		t.Errorf("owner error = %q, want %q", got, opponentLeft)
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 구룡투 규칙 프리셋 이름
+const (
+	RuleSetClassic  = "classic"
+	RuleSetShort    = "short"
+	RuleSetExtended = "extended"
+)
+
+// 사용자 지정 규칙 제한
+const (
+	maxRuleTile = 15
+)
+
+// ClassicRules 기본 규칙: 1-9 타일, 5승 먼저 또는 9라운드, 1이 9를 이김
+func ClassicRules() RuleSet {
+	return RuleSet{
+		Name:       RuleSetClassic,
+		MinTile:    1,
+		MaxTile:    9,
+		WinsNeeded: 5,
+		MaxRounds:  9,
+		Upsets:     []UpsetPair{{Low: 1, High: 9}},
+		DrawLeader: DrawLeaderKeep,
+		TieBreak:   TieBreakDraw,
+	}
+}
+
+// ShortRules 짧은 규칙: 1-5 타일, 3승 먼저 또는 5라운드, 1이 5를 이김
+func ShortRules() RuleSet {
+	return RuleSet{
+		Name:       RuleSetShort,
+		MinTile:    1,
+		MaxTile:    5,
+		WinsNeeded: 3,
+		MaxRounds:  5,
+		Upsets:     []UpsetPair{{Low: 1, High: 5}},
+		DrawLeader: DrawLeaderKeep,
+		TieBreak:   TieBreakDraw,
+	}
+}
+
+// ExtendedRules 확장 규칙: 1-13 타일, 7승 먼저 또는 13라운드, 1이 13을, 2가 12를 이김
+func ExtendedRules() RuleSet {
+	return RuleSet{
+		Name:       RuleSetExtended,
+		MinTile:    1,
+		MaxTile:    13,
+		WinsNeeded: 7,
+		MaxRounds:  13,
+		Upsets:     []UpsetPair{{Low: 1, High: 13}, {Low: 2, High: 12}},
+		DrawLeader: DrawLeaderSwap,
+		TieBreak:   TieBreakLastWinner,
+	}
+}
+
+// RuleSetByName 이름으로 프리셋 조회 (빈 이름은 classic)
+func RuleSetByName(name string) (RuleSet, error) {
+	switch name {
+	case "", RuleSetClassic:
+		return ClassicRules(), nil
+	case RuleSetShort:
+		return ShortRules(), nil
+	case RuleSetExtended:
+		return ExtendedRules(), nil
+	}
+	return RuleSet{}, fmt.Errorf("알 수 없는 규칙입니다: %s", name)
+}
+
+// resolveRuleSet 직접 지정한 규칙이 있으면 검증 후 사용, 없으면 프리셋 사용
+func resolveRuleSet(name string, custom *RuleSet) (RuleSet, error) {
+	if custom == nil {
+		return RuleSetByName(name)
+	}
+
+	rules := *custom
+	rules.Upsets = append([]UpsetPair{}, custom.Upsets...)
+	if rules.Name == "" {
+		rules.Name = "custom"
+	}
+	if rules.DrawLeader == "" {
+		rules.DrawLeader = DrawLeaderKeep
+	}
+	if rules.TieBreak == "" {
+		rules.TieBreak = TieBreakDraw
+	}
+
+	if err := rules.Validate(); err != nil {
+		return RuleSet{}, err
+	}
+	return rules, nil
+}
+
+// Validate 규칙 유효성 검사
+func (r RuleSet) Validate() error {
+	if r.MinTile < 1 || r.MaxTile > maxRuleTile || r.MinTile >= r.MaxTile {
+		return fmt.Errorf("타일 범위는 1-%d 사이여야 합니다", maxRuleTile)
+	}
+	if r.MaxRounds < 1 || r.MaxRounds > r.TileCount() {
+		return errors.New("라운드 수는 타일 개수를 넘을 수 없습니다")
+	}
+	if r.WinsNeeded < 1 || r.WinsNeeded > r.MaxRounds {
+		return errors.New("필요 승수는 라운드 수를 넘을 수 없습니다")
+	}
+	for _, upset := range r.Upsets {
+		if upset.Low < r.MinTile || upset.High > r.MaxTile || upset.Low >= upset.High {
+			return errors.New("특수 조합은 타일 범위 안의 작은 수와 큰 수여야 합니다")
+		}
+	}
+	switch r.DrawLeader {
+	case DrawLeaderKeep, DrawLeaderSwap:
+	default:
+		return errors.New("알 수 없는 무승부 선공 규칙입니다")
+	}
+	switch r.TieBreak {
+	case TieBreakDraw, TieBreakLastWinner:
+	default:
+		return errors.New("알 수 없는 동점 처리 규칙입니다")
+	}
+	return nil
+}
+
+// TileCount 플레이어당 타일 개수
+func (r RuleSet) TileCount() int {
+	return r.MaxTile - r.MinTile + 1
+}
+
+// IsUpset low가 high를 이기는 특수 조합인지 확인
+func (r RuleSet) IsUpset(low, high int) bool {
+	for _, upset := range r.Upsets {
+		if upset.Low == low && upset.High == high {
+			return true
+		}
+	}
+	return false
+}
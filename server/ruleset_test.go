@@ -0,0 +1,84 @@
+package server
+
+import "testing"
+
+func TestRuleSetPresetsValid(t *testing.T) {
+	for _, name := range []string{"", RuleSetClassic, RuleSetShort, RuleSetExtended} {
+		rules, err := RuleSetByName(name)
+		if err != nil {
+			t.Fatalf("RuleSetByName(%q): %v", name, err)
+		}
+		if err := rules.Validate(); err != nil {
+			t.Errorf("preset %q is invalid: %v", rules.Name, err)
+		}
+	}
+	if _, err := RuleSetByName("blitz"); err == nil {
+		t.Error("unknown preset was accepted")
+	}
+}
+
+func TestRuleSetValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *RuleSet)
+		want   string // 빈 값이면 통과
+	}{
+		{name: "기본 규칙", modify: func(r *RuleSet) {}},
+		{name: "최대 타일", modify: func(r *RuleSet) { r.MaxTile = maxRuleTile }},
+		{name: "타일 0", modify: func(r *RuleSet) { r.MinTile = 0 }, want: "타일 범위는 1-15 사이여야 합니다"},
+		{name: "타일 범위 초과", modify: func(r *RuleSet) { r.MaxTile = maxRuleTile + 1 }, want: "타일 범위는 1-15 사이여야 합니다"},
+		{name: "타일 하나", modify: func(r *RuleSet) { r.MinTile, r.MaxTile = 5, 5 }, want: "타일 범위는 1-15 사이여야 합니다"},
+		{name: "타일보다 많은 라운드", modify: func(r *RuleSet) { r.MaxRounds = 10 }, want: "라운드 수는 타일 개수를 넘을 수 없습니다"},
+		{name: "라운드 0", modify: func(r *RuleSet) { r.MaxRounds = 0 }, want: "라운드 수는 타일 개수를 넘을 수 없습니다"},
+		{name: "라운드보다 많은 승수", modify: func(r *RuleSet) { r.MaxRounds, r.WinsNeeded = 5, 6 }, want: "필요 승수는 라운드 수를 넘을 수 없습니다"},
+		{name: "승수 0", modify: func(r *RuleSet) { r.WinsNeeded = 0 }, want: "필요 승수는 라운드 수를 넘을 수 없습니다"},
+		{name: "범위 밖 특수 조합", modify: func(r *RuleSet) { r.Upsets = []UpsetPair{{Low: 1, High: 10}} }, want: "특수 조합은 타일 범위 안의 작은 수와 큰 수여야 합니다"},
+		{name: "거꾸로 된 특수 조합", modify: func(r *RuleSet) { r.Upsets = []UpsetPair{{Low: 9, High: 1}} }, want: "특수 조합은 타일 범위 안의 작은 수와 큰 수여야 합니다"},
+		{name: "알 수 없는 선공 규칙", modify: func(r *RuleSet) { r.DrawLeader = "coin" }, want: "알 수 없는 무승부 선공 규칙입니다"},
+		{name: "알 수 없는 동점 처리", modify: func(r *RuleSet) { r.TieBreak = "sudden_death" }, want: "알 수 없는 동점 처리 규칙입니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := ClassicRules()
+			tt.modify(&rules)
+			err := rules.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRuleSet(t *testing.T) {
+	// 직접 지정한 규칙이 없으면 프리셋
+	rules, err := resolveRuleSet(RuleSetShort, nil)
+	if err != nil || rules.Name != RuleSetShort {
+		t.Fatalf("resolveRuleSet(short, nil) = %s, %v", rules.Name, err)
+	}
+
+	// 빈 이름과 규칙은 기본값으로 채우고, 호출한 쪽의 특수 조합과 공유하지 않음
+	custom := &RuleSet{MinTile: 1, MaxTile: 7, WinsNeeded: 4, MaxRounds: 7, Upsets: []UpsetPair{{Low: 1, High: 7}}}
+	rules, err = resolveRuleSet(RuleSetExtended, custom)
+	if err != nil {
+		t.Fatalf("resolveRuleSet(custom): %v", err)
+	}
+	if rules.Name != "custom" || rules.DrawLeader != DrawLeaderKeep || rules.TieBreak != TieBreakDraw {
+		t.Errorf("defaults = %s %s %s", rules.Name, rules.DrawLeader, rules.TieBreak)
+	}
+	custom.Upsets[0].High = 6
+	if !rules.IsUpset(1, 7) {
+		t.Error("resolved rules share the caller's upset list")
+	}
+
+	// 잘못된 규칙은 거절
+	if _, err := resolveRuleSet("", &RuleSet{MinTile: 1, MaxTile: 3, WinsNeeded: 2, MaxRounds: 5}); err == nil {
+		t.Error("invalid custom rules were accepted")
+	}
+}
@@ -0,0 +1,301 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 512
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true // 개발 환경에서는 모든 origin 허용
+	},
+}
+
+// slowConsumerReason 전송 버퍼가 가득 차 연결을 끊을 때 종료 프레임에 담는 사유
+const slowConsumerReason = "메시지를 제때 받지 못해 연결을 끊습니다"
+
+// connState 연결 수명 단계 (connected -> draining -> closed 순서로만 진행)
+type connState int
+
+const (
+	connConnected connState = iota // 메시지를 주고받는 중
+	connDraining                   // 끊기로 해서 새 메시지는 받지 않고 쌓인 메시지와 종료 프레임만 보내는 중
+	connClosed                     // 허브가 연결 해제를 처리함
+)
+
+// link 허브 런타임이 맡는 연결 하나의 수명 상태
+// 전송 버퍼(outbox)는 connected에서 벗어날 때 한 번만 닫으므로 두 번 닫거나 닫힌 버퍼에 보내는 일이 없다
+type link struct {
+	state     connState
+	closeCode int // 종료 프레임 코드
+	closeText string
+}
+
+// advance 다음 단계로 진행 (같거나 이전 단계로는 돌아가지 않음)
+func (l *link) advance(to connState) bool {
+	if to <= l.state {
+		return false
+	}
+	l.state = to
+	return true
+}
+
+// peer 허브 런타임에 연결된 웹소켓 클라이언트 (Client)
+type peer interface {
+	comparable
+	connection() *websocket.Conn
+	outbox() chan []byte
+	clientID() string
+}
+
+// hubRuntime 모든 허브가 함께 쓰는 연결 처리
+// 연결 등록/해제, 웹소켓 읽기/쓰기, 메시지 전송을 맡고 게임 규칙은 모른다
+// M은 읽은 메시지를 허브 이벤트 루프로 넘기는 봉투 타입
+// 메시지는 허브 루프와 게임 고루틴 어디서든 보낼 수 있어 등록된 클라이언트는 잠금으로 보호한다
+//
+// 연결이 끊기는 길은 하나뿐이다. 느린 클라이언트는 draining으로 바꿔 쓰기 고루틴이 종료 프레임을 보내고 연결을 닫게 하고,
+// 그러면 읽기 고루틴이 끝나면서 unregister로 허브에 알리므로 게임은 보통의 연결 끊김(handleDisconnect)으로 처리한다.
+type hubRuntime[C peer, M any] struct {
+	// 등록된 클라이언트와 연결별 수명 상태
+	mu      sync.Mutex
+	clients map[C]*link
+
+	// 클라이언트 등록
+	register chan C
+
+	// 클라이언트 등록 해제
+	unregister chan C
+
+	// 게임 메시지
+	gameMessage chan M
+
+	// 게임 고루틴이 허브 루프에서 실행을 요청한 일
+	calls chan func()
+
+	// 로그 앞에 붙일 허브 이름 (예: "[NC] ")
+	logPrefix string
+}
+
+func newHubRuntime[C peer, M any](logPrefix string) hubRuntime[C, M] {
+	return hubRuntime[C, M]{
+		clients:     make(map[C]*link),
+		register:    make(chan C),
+		unregister:  make(chan C),
+		gameMessage: make(chan M),
+		calls:       make(chan func()),
+		logPrefix:   logPrefix,
+	}
+}
+
+// addClient 연결 등록 (허브 이벤트 루프에서 호출)
+func (r *hubRuntime[C, M]) addClient(client C) {
+	r.mu.Lock()
+	r.clients[client] = &link{closeCode: websocket.CloseNormalClosure}
+	r.mu.Unlock()
+	log.Printf("%sClient registered: %s", r.logPrefix, client.clientID())
+}
+
+// removeClient 등록된 연결이면 closed로 바꿔 해제하고 true (허브 이벤트 루프에서 호출)
+// 끊기로 한(draining) 연결도 여기서 한 번 true를 돌려주므로 연결 끊김 처리가 빠지지 않는다
+func (r *hubRuntime[C, M]) removeClient(client C) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.clients[client]
+	if !ok {
+		return false
+	}
+	if l.state == connConnected {
+		close(client.outbox())
+	}
+	l.advance(connClosed)
+	delete(r.clients, client)
+	return true
+}
+
+// connected 메시지를 받을 수 있는 연결인지 (끊는 중인 연결은 false)
+func (r *hubRuntime[C, M]) connected(client C) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l := r.clients[client]
+	return l != nil && l.state == connConnected
+}
+
+// drainLocked 연결을 끊기로 하고 전송 버퍼를 닫음 (mu를 잡은 채 호출)
+// 쓰기 고루틴은 쌓인 메시지와 code를 담은 종료 프레임을 보낸 뒤 연결을 닫는다
+func (r *hubRuntime[C, M]) drainLocked(client C, l *link, code int, text string) {
+	if !l.advance(connDraining) {
+		return
+	}
+	l.closeCode, l.closeText = code, text
+	close(client.outbox())
+}
+
+// closeFrame 종료 프레임에 담을 코드와 사유
+func (r *hubRuntime[C, M]) closeFrame(client C) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l := r.clients[client]; l != nil {
+		return websocket.FormatCloseMessage(l.closeCode, l.closeText)
+	}
+	return websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+}
+
+// call 게임 고루틴에서 허브 상태를 다룰 때 허브 루프에서 f를 실행하고 끝날 때까지 기다림
+// 그동안 게임 고루틴은 멈춰 있으므로 f는 그 게임의 상태도 다룰 수 있다
+// 허브 루프는 게임 고루틴을 기다리지 않으므로 교착 상태가 생기지 않는다 (허브 루프에서 부르면 안 됨)
+func (r *hubRuntime[C, M]) call(f func()) {
+	done := make(chan struct{})
+	r.calls <- func() {
+		f()
+		close(done)
+	}
+	<-done
+}
+
+// serve HTTP 연결을 웹소켓으로 전환하고 읽기/쓰기 고루틴 시작
+// decode는 읽은 메시지를 허브로 넘길 봉투로 바꾼다 (false면 버림)
+func (r *hubRuntime[C, M]) serve(w http.ResponseWriter, req *http.Request, newClient func(conn *websocket.Conn) C, decode func(client C, data []byte) (M, bool)) {
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		log.Println(r.logPrefix+"Error upgrading connection:", err)
+		return
+	}
+
+	client := newClient(conn)
+	r.register <- client
+
+	go r.writePump(client)
+	go r.readPump(client, decode)
+}
+
+func (r *hubRuntime[C, M]) readPump(client C, decode func(client C, data []byte) (M, bool)) {
+	conn := client.connection()
+	defer func() {
+		r.unregister <- client
+		conn.Close()
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("%serror: %v", r.logPrefix, err)
+			}
+			break
+		}
+
+		if msg, ok := decode(client, message); ok {
+			r.gameMessage <- msg
+		}
+	}
+}
+
+func (r *hubRuntime[C, M]) writePump(client C) {
+	conn := client.connection()
+	send := client.outbox()
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-send:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, r.closeFrame(client))
+				return
+			}
+
+			w, err := conn.NextWriter(websocket.TextMessage)
+			if err != nil {
+				return
+			}
+			w.Write(message)
+
+			// 대기 중인 메시지 추가
+			n := len(send)
+			for i := 0; i < n; i++ {
+				w.Write([]byte{'\n'})
+				w.Write(<-send)
+			}
+
+			if err := w.Close(); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendJSON 메시지를 직렬화해서 전송
+func (r *hubRuntime[C, M]) sendJSON(client C, message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("%sError marshaling message: %v", r.logPrefix, err)
+		return
+	}
+	r.sendData(client, data)
+}
+
+// sendData 이미 직렬화된 메시지 전송 (끊는 중이거나 끊긴 연결이면 버림)
+// 버퍼가 가득 찬 느린 클라이언트는 정책 위반 코드로 연결을 끊는다
+func (r *hubRuntime[C, M]) sendData(client C, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := r.clients[client]
+	if l == nil || l.state != connConnected {
+		return
+	}
+	select {
+	case client.outbox() <- data:
+	default:
+		log.Printf("%sClient %s is not keeping up, disconnecting", r.logPrefix, client.clientID())
+		r.drainLocked(client, l, websocket.ClosePolicyViolation, slowConsumerReason)
+	}
+}
+
+// sendSeats 좌석에 앉은 클라이언트 모두에게 전송 (빈 좌석은 건너뜀)
+func sendSeats[S comparable, C peer, M any](r *hubRuntime[C, M], seats map[S]C, message interface{}) {
+	var empty C
+	for _, client := range seats {
+		if client != empty {
+			r.sendJSON(client, message)
+		}
+	}
+}
+
+// sendAll 집합의 클라이언트 모두에게 전송
+func (r *hubRuntime[C, M]) sendAll(clients map[C]bool, message interface{}) {
+	for client := range clients {
+		r.sendJSON(client, message)
+	}
+}
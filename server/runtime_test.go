@@ -0,0 +1,260 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testClient 연결 없이 전송 버퍼만 가진 클라이언트
+func testClient(id string, buffer int) *Client {
+	return &Client{ID: id, Send: make(chan []byte, buffer)}
+}
+
+func TestRuntimeSendWhileUnregistering(t *testing.T) {
+	for _, buffer := range []int{1, 256} {
+		t.Run(fmt.Sprintf("buffer=%d", buffer), func(t *testing.T) {
+			r := newHubRuntime[*Client, GameMessage]("")
+			clients := make([]*Client, 50)
+			for i := range clients {
+				clients[i] = testClient(fmt.Sprintf("c%d", i), buffer)
+				r.addClient(clients[i])
+			}
+
+			var wg sync.WaitGroup
+			for _, c := range clients {
+				// 쓰기 고루틴 대신 버퍼가 닫힐 때까지 비움
+				wg.Add(1)
+				go func(c *Client) {
+					defer wg.Done()
+					for range c.Send {
+					}
+				}(c)
+
+				// 게임 고루틴 여러 개가 동시에 보냄
+				for s := 0; s < 4; s++ {
+					wg.Add(1)
+					go func(c *Client) {
+						defer wg.Done()
+						for i := 0; i < 100; i++ {
+							r.sendJSON(c, Message{Type: MsgTilePlayed})
+						}
+					}(c)
+				}
+			}
+
+			// 그 사이 허브 루프가 연결을 해제함
+			removed := 0
+			for _, c := range clients {
+				if r.removeClient(c) {
+					removed++
+				}
+				if r.removeClient(c) {
+					t.Errorf("removeClient(%s) returned true twice", c.ID)
+				}
+			}
+			wg.Wait()
+
+			if removed != len(clients) {
+				t.Errorf("removed %d clients, want %d", removed, len(clients))
+			}
+			if len(r.clients) != 0 {
+				t.Errorf("%d clients still registered", len(r.clients))
+			}
+		})
+	}
+}
+
+func TestRuntimeSlowConsumerDrains(t *testing.T) {
+	r := newHubRuntime[*Client, GameMessage]("")
+	c := testClient("slow", 2)
+	r.addClient(c)
+
+	for i := 0; i < 3; i++ {
+		r.sendData(c, []byte{byte('0' + i)})
+	}
+	if r.connected(c) {
+		t.Fatal("client with a full outbox is still connected")
+	}
+
+	// 쌓여 있던 메시지는 그대로 보내고 버퍼는 닫힘
+	var got []string
+	for data := range c.Send {
+		got = append(got, string(data))
+	}
+	if strings.Join(got, ",") != "0,1" {
+		t.Errorf("drained %v, want [0 1]", got)
+	}
+
+	code, text := parseCloseFrame(t, r.closeFrame(c))
+	if code != websocket.ClosePolicyViolation || text != slowConsumerReason {
+		t.Errorf("close frame = %d %q, want %d %q", code, text, websocket.ClosePolicyViolation, slowConsumerReason)
+	}
+
+	// 끊는 중인 연결도 해제는 한 번 처리함
+	if !r.removeClient(c) {
+		t.Error("removeClient of a draining client returned false")
+	}
+}
+
+func TestRuntimeDrainTwice(t *testing.T) {
+	r := newHubRuntime[*Client, GameMessage]("")
+	c := testClient("twice", 1)
+	r.addClient(c)
+
+	r.mu.Lock()
+	l := r.clients[c]
+	r.drainLocked(c, l, websocket.ClosePolicyViolation, slowConsumerReason)
+	r.drainLocked(c, l, websocket.CloseNormalClosure, "")
+	r.mu.Unlock()
+
+	// 두 번째 drain은 종료 프레임도 바꾸지 않음
+	code, text := parseCloseFrame(t, r.closeFrame(c))
+	if code != websocket.ClosePolicyViolation || text != slowConsumerReason {
+		t.Errorf("close frame = %d %q after second drain, want %d %q", code, text, websocket.ClosePolicyViolation, slowConsumerReason)
+	}
+
+	// 닫힌 버퍼로 보내거나 다시 닫지 않음
+	r.sendData(c, []byte("late"))
+	if !r.removeClient(c) {
+		t.Fatal("removeClient after drain returned false")
+	}
+	r.sendData(c, []byte("later"))
+	if r.removeClient(c) {
+		t.Error("removeClient of a closed client returned true")
+	}
+	if _, ok := <-c.Send; ok {
+		t.Error("outbox received a message after draining")
+	}
+}
+
+func TestHubSlowConsumerDisconnects(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(h, w, r)
+	}))
+	defer srv.Close()
+
+	slowConn := dialTest(t, srv)
+	defer slowConn.Close()
+	slow := waitForClients(t, h, 1)[0]
+	opponentConn := dialTest(t, srv)
+	defer opponentConn.Close()
+	waitForClients(t, h, 2)
+
+	joinTest(t, slowConn, "slow")
+	joinTest(t, opponentConn, "opponent")
+	readUntil(t, opponentConn, MsgGameStart)
+
+	// 읽지 않는 클라이언트에게 버퍼가 넘칠 때까지 보냄
+	flood := []byte(`{"type":"flood","payload":"` + strings.Repeat("x", 64<<10) + `"}`)
+	deadline := time.Now().Add(10 * time.Second)
+	for h.connected(slow) {
+		if time.Now().After(deadline) {
+			t.Fatal("slow client was never disconnected")
+		}
+		h.sendData(slow, flood)
+	}
+
+	// 쌓인 메시지 뒤에 정책 위반 종료 프레임이 옴
+	slowConn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		_, _, err := slowConn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("read error = %v, want a close frame", err)
+		}
+		if closeErr.Code != websocket.ClosePolicyViolation || closeErr.Text != slowConsumerReason {
+			t.Fatalf("close frame = %d %q, want %d %q", closeErr.Code, closeErr.Text, websocket.ClosePolicyViolation, slowConsumerReason)
+		}
+		break
+	}
+
+	// 허브는 보통의 연결 끊김으로 처리해 상대에게 알림
+	readUntil(t, opponentConn, MsgOpponentDisconnected)
+	h.mu.Lock()
+	_, registered := h.clients[slow]
+	h.mu.Unlock()
+	if registered {
+		t.Error("slow client is still registered after disconnecting")
+	}
+}
+
+// parseCloseFrame 종료 프레임의 코드와 사유
+func parseCloseFrame(t *testing.T, frame []byte) (int, string) {
+	t.Helper()
+	if len(frame) < 2 {
+		t.Fatalf("close frame too short: %v", frame)
+	}
+	return int(frame[0])<<8 | int(frame[1]), string(frame[2:])
+}
+
+func dialTest(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	return conn
+}
+
+// waitForClients 허브에 n개 연결이 등록될 때까지 기다린 뒤 등록된 클라이언트 반환
+func waitForClients(t *testing.T, h *Hub, n int) []*Client {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		h.mu.Lock()
+		clients := make([]*Client, 0, len(h.clients))
+		for c := range h.clients {
+			clients = append(clients, c)
+		}
+		h.mu.Unlock()
+		if len(clients) == n {
+			return clients
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d clients registered, want %d", len(clients), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func joinTest(t *testing.T, conn *websocket.Conn, name string) {
+	t.Helper()
+	err := conn.WriteJSON(Message{Type: MsgJoinGame, Payload: JoinGamePayload{PlayerName: name}})
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+}
+
+// readUntil typ 메시지가 올 때까지 읽음 (쓰기 고루틴이 줄바꿈으로 묶어 보낸 메시지도 나눠 봄)
+func readUntil(t *testing.T, conn *websocket.Conn, typ MessageType) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for %s: %v", typ, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			var msg struct {
+				Type MessageType `json:"type"`
+			}
+			if json.Unmarshal([]byte(line), &msg) == nil && msg.Type == typ {
+				return
+			}
+		}
+	}
+}
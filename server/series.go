@@ -0,0 +1,154 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 다전제 설정
+const (
+	seriesMaxBestOf     = 7
+	seriesNextGameDelay = 5 * time.Second // 게임이 끝나고 다음 게임을 시작하기까지 결과를 보여주는 시간
+
+	// 다전제 종료 사유
+	seriesCompleted = "completed"
+	seriesForfeit   = "forfeit" // 한 플레이어가 다음 게임 전에 나감
+	seriesAborted   = "aborted" // 게임이 취소되었거나 두 플레이어 모두 나감
+)
+
+// ErrInvalidBestOf 지원하지 않는 다전제 판 수
+var ErrInvalidBestOf = errors.New("다전제는 3, 5, 7판 중에서 고를 수 있습니다")
+
+// normalizeBestOf 요청한 다전제 판 수 확인 (0 또는 1은 단판이므로 0)
+func normalizeBestOf(bestOf int) (int, error) {
+	if bestOf <= 1 {
+		return 0, nil
+	}
+	if bestOf%2 == 0 || bestOf > seriesMaxBestOf {
+		return 0, ErrInvalidBestOf
+	}
+	return bestOf, nil
+}
+
+// newBestOfSeries bestOf판 다전제 생성
+func newBestOfSeries(bestOf int) *Series {
+	return &Series{
+		ID:        uuid.New().String(),
+		BestOf:    bestOf,
+		StartedAt: time.Now(),
+	}
+}
+
+// bestOf 판 수가 정해진 다전제인지 (nil이거나 재대결로 이어진 전적이면 false)
+func (s *Series) bestOf() bool {
+	return s != nil && s.BestOf > 0
+}
+
+// decided 다전제 승부가 났는지 (seat: 이긴 자리 번호, 무승부는 -1)
+// 과반을 먼저 이기면 끝나고, 무승부 때문에 판 수를 다 채우면 더 많이 이긴 쪽이 이긴다
+func (s *Series) decided() (int, bool) {
+	for seat, wins := range s.Wins {
+		if wins > s.BestOf/2 {
+			return seat, true
+		}
+	}
+	if s.Games < s.BestOf {
+		return -1, false
+	}
+	switch {
+	case s.Wins[0] > s.Wins[1]:
+		return 0, true
+	case s.Wins[1] > s.Wins[0]:
+		return 1, true
+	}
+	return -1, true
+}
+
+// ==================== Hub Integration ====================
+
+// advanceSeries 다전제 게임이 끝난 뒤 다음 게임을 예약하거나 다전제 종료
+func (h *gameHub[G]) advanceSeries(game G, winner Seat) {
+	t := game.table()
+	if seat, over := t.Series.decided(); over {
+		h.endSeries(game, t.seriesSeatOf(seat), seriesCompleted)
+		h.openRematch(game, winner)
+		return
+	}
+
+	// 다음 게임은 재대결처럼 좌석을 바꿔 시작 (둘 다 재대결을 제안하면 기다리지 않고 시작)
+	h.openRematch(game, winner)
+	if h.rematches[t.ID] == nil {
+		for _, seat := range h.seats {
+			if player := t.Players[seat]; player == nil || !h.connected(player) {
+				h.forfeitSeries(game, seat)
+				return
+			}
+		}
+	}
+
+	nextGameAt := time.Now().Add(seriesNextGameDelay)
+	h.broadcastPublic(game, Message{
+		Type: h.msg(MsgSeriesUpdate),
+		Payload: SeriesUpdatePayload{
+			Series:     t.seriesScore(),
+			NextGameAt: nextGameAt.UnixMilli(),
+		},
+	})
+
+	gameID := t.ID
+	time.AfterFunc(seriesNextGameDelay, func() {
+		h.seriesNext <- gameID
+	})
+}
+
+// handleSeriesNext 결과 확인 시간이 지난 다전제의 다음 게임 시작
+func (h *gameHub[G]) handleSeriesNext(gameID string) {
+	if rematch := h.rematches[gameID]; rematch != nil {
+		h.startRematch(rematch)
+	}
+}
+
+// forfeitSeries 다음 게임 전에 by가 나가 상대의 기권승으로 다전제 종료 (둘 다 나갔으면 취소)
+func (h *gameHub[G]) forfeitSeries(game G, by Seat) {
+	opponent := h.opponent(by)
+	if player := game.table().Players[opponent]; by == "" || player == nil || !h.connected(player) {
+		h.endSeries(game, "", seriesAborted)
+		return
+	}
+	h.endSeries(game, opponent, seriesForfeit)
+}
+
+// endSeries 다전제 결과를 레이팅과 기록에 한 단위로 반영하고 플레이어에게 알림
+func (h *gameHub[G]) endSeries(game G, winner Seat, reason string) {
+	t := game.table()
+	series := t.Series
+	series.Over = true
+
+	var ratings map[Seat]*RatingChange
+	if reason != seriesAborted {
+		ratings = h.rateResult(game, series.ID, winner)
+	}
+	if h.records != nil {
+		h.records.SaveSeries(t.seriesRecord(h.kind, winner, reason, ratings))
+	}
+
+	message := Message{
+		Type: h.msg(MsgSeriesOver),
+		Payload: SeriesOverPayload{
+			Series:  t.seriesScore(),
+			Winner:  winner,
+			Reason:  reason,
+			Ratings: ratings,
+		},
+	}
+	for _, player := range t.Players {
+		if player != nil && h.connected(player) {
+			h.sendToClient(player, message)
+		}
+	}
+
+	log.Printf("%sSeries %s ended after %d games. Winner: %s, Reason: %s", h.logPrefix, series.ID, series.Games, winner, reason)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeBestOf(t *testing.T) {
+	tests := []struct {
+		bestOf  int
+		want    int
+		wantErr bool
+	}{
+		{bestOf: 0, want: 0},
+		{bestOf: 1, want: 0},
+		{bestOf: -3, want: 0},
+		{bestOf: 3, want: 3},
+		{bestOf: 7, want: 7},
+		{bestOf: 4, wantErr: true},
+		{bestOf: 9, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeBestOf(tt.bestOf)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("normalizeBestOf(%d) = %d, %v; want %d, err %v", tt.bestOf, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSeriesDecided(t *testing.T) {
+	tests := []struct {
+		name     string
+		bestOf   int
+		results  []int // 게임마다 이긴 자리 번호 (무승부 -1)
+		wantSeat int
+		wantOver bool
+	}{
+		{name: "첫 게임", bestOf: 3, results: []int{0}, wantSeat: -1},
+		{name: "3판 중 2승", bestOf: 3, results: []int{0, 0}, wantSeat: 0, wantOver: true},
+		{name: "3판 1-1", bestOf: 3, results: []int{0, 1}, wantSeat: -1},
+		{name: "3판 역전", bestOf: 3, results: []int{0, 1, 1}, wantSeat: 1, wantOver: true},
+		{name: "5판 중 과반 전 무승부", bestOf: 5, results: []int{0, -1, 0}, wantSeat: -1},
+		{name: "5판 과반", bestOf: 5, results: []int{1, -1, 1, 1}, wantSeat: 1, wantOver: true},
+		{name: "무승부로 판 수를 채우면 많이 이긴 쪽", bestOf: 3, results: []int{0, -1, -1}, wantSeat: 0, wantOver: true},
+		{name: "무승부로 판 수를 채우고 승수도 같음", bestOf: 3, results: []int{0, 1, -1}, wantSeat: -1, wantOver: true},
+		{name: "모두 무승부", bestOf: 3, results: []int{-1, -1, -1}, wantSeat: -1, wantOver: true},
+		{name: "7판 무승부 섞여 판 수 채움", bestOf: 7, results: []int{0, 1, -1, 0, 1, -1, 1}, wantSeat: 1, wantOver: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := newBestOfSeries(tt.bestOf)
+			for i, seat := range tt.results {
+				series.record(fmt.Sprintf("g%d", i+1), seat)
+			}
+			seat, over := series.decided()
+			if seat != tt.wantSeat || over != tt.wantOver {
+				t.Errorf("decided = %d, %v; want %d, %v", seat, over, tt.wantSeat, tt.wantOver)
+			}
+		})
+	}
+}
+
+func TestSeriesScore(t *testing.T) {
+	series := newBestOfSeries(5)
+	series.record("g1", 0)
+	series.record("g2", -1)
+	series.record("g3", 1)
+	series.record("g4", 0)
+
+	// 두 번째 게임부터 색을 바꿔 두므로 자리 0이 지금은 빨강
+	score := series.score(map[string]int{string(Blue): 1, string(Red): 0})
+	if score.Games != 4 || score.Draws != 1 || score.BestOf != 5 {
+		t.Errorf("score = %+v", score)
+	}
+	if score.Wins[string(Red)] != 2 || score.Wins[string(Blue)] != 1 {
+		t.Errorf("wins = %v, want red 2 blue 1", score.Wins)
+	}
+	if len(series.GameIDs) != 4 || series.GameIDs[3] != "g4" {
+		t.Errorf("game ids = %v", series.GameIDs)
+	}
+}
+
+func TestSeriesColor(t *testing.T) {
+	game := NewGame(ClassicRules())
+	game.startSeries(3, game.Seats())
+	if !game.Series.bestOf() || game.seriesSeatOf(0) != Blue || game.seriesSeatOf(1) != Red {
+		t.Errorf("series %+v seats %v", game.Series, game.SeriesSeat)
+	}
+	if game.seriesSeatOf(-1) != "" {
+		t.Error("a draw has no series color")
+	}
+
+	single := NewGame(ClassicRules())
+	single.startSeries(0, game.Seats())
+	if single.Series.bestOf() {
+		t.Error("single game started a series")
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newSession 플레이어에게 재접속 세션 발급
+func (h *gameHub[G]) newSession(game G, client *Client) *Session {
+	t := game.table()
+	session := &Session{
+		Token:    uuid.New().String(),
+		GameID:   t.ID,
+		Seat:     client.Seat,
+		Name:     client.Name,
+		PlayerID: client.PlayerID,
+		Addr:     client.Addr,
+	}
+	h.sessions[session.Token] = session
+	t.Sessions[client.Seat] = session
+	client.Token = session.Token
+	return session
+}
+
+// suspendPlayer 연결이 끊긴 플레이어의 좌석을 유예 시간 동안 보존
+func (h *gameHub[G]) suspendPlayer(game G, client *Client) {
+	t := game.table()
+	session := t.Sessions[client.Seat]
+	if session == nil {
+		return
+	}
+
+	delete(t.Players, client.Seat)
+	session.Disconnected = true
+	session.DisconnectedAt = time.Now()
+
+	disconnectedAt := session.DisconnectedAt
+	time.AfterFunc(h.disconnect.Grace, func() {
+		t.actor.post(func() { h.handleSessionExpiry(game, session, disconnectedAt) })
+	})
+
+	log.Printf("%sPlayer %s (%s) disconnected from game %s, holding seat for %v", h.logPrefix, client.ID, client.Seat, t.ID, h.disconnect.Grace)
+
+	h.broadcastPublic(game, Message{
+		Type:    h.msg(MsgOpponentDisconnected),
+		Payload: h.hooks.opponentStatus(client.Seat, int(h.disconnect.Grace/time.Second)),
+	})
+}
+
+// handleSessionExpiry 유예 시간 내에 돌아오지 않은 플레이어 처리 (그 사이 재접속했으면 무시)
+func (h *gameHub[G]) handleSessionExpiry(game G, session *Session, disconnectedAt time.Time) {
+	if !session.Disconnected || !session.DisconnectedAt.Equal(disconnectedAt) {
+		return
+	}
+
+	log.Printf("%sReconnect grace expired for %s in game %s", h.logPrefix, session.Seat, session.GameID)
+
+	// 상대도 연결이 끊겨 있으면 승자 없이 취소
+	if opponent := game.table().Sessions[h.opponent(session.Seat)]; opponent != nil && opponent.Disconnected {
+		h.abortGame(game, opponentLeft)
+		return
+	}
+	h.abandonGame(game, session.Seat)
+}
+
+// handleResume 새 연결을 기존 좌석에 다시 연결
+func (h *gameHub[G]) handleResume(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload ResumePayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	session := h.sessions[payload.SessionToken]
+	if session == nil {
+		h.sendError(client, "세션을 찾을 수 없습니다")
+		return
+	}
+
+	game, ok := h.game(session.GameID)
+	if !ok {
+		delete(h.sessions, session.Token)
+		h.sendError(client, "게임을 찾을 수 없습니다")
+		return
+	}
+
+	if _, playing := h.game(client.GameID); playing && client.GameID != session.GameID {
+		h.sendError(client, "이미 다른 게임에 참가 중입니다")
+		return
+	}
+
+	// 방에서 기다리던 연결이면 방에서 나온 뒤 좌석으로 돌아감
+	if client.Room != nil {
+		h.leaveRoom(client)
+	}
+
+	// 대기열에 서 있던 연결이면 대기열에서 빼서 두 게임에 동시에 앉지 않게 함
+	if h.queue.Cancel(client.ID) != nil {
+		log.Printf("%sPlayer %s left the queue to resume game %s", h.logPrefix, client.ID, session.GameID)
+	}
+	// 관전하거나 다시보기를 보던 연결이면 그만두게 해서 다른 게임 메시지가 섞이지 않게 함
+	h.removeSpectator(client)
+	h.stopReplay(client)
+
+	client.Name = session.Name
+	client.GameID = session.GameID
+	client.Seat = session.Seat
+	client.Token = session.Token
+	client.PlayerID = session.PlayerID
+	h.onGame(game, func() { h.resumePlayer(game, client, session) })
+}
+
+// resumePlayer 재접속한 연결을 좌석에 앉히고 현재 상태 전송
+func (h *gameHub[G]) resumePlayer(game G, client *Client, session *Session) {
+	t := game.table()
+
+	// 이전 연결이 아직 살아있으면 새 연결이 좌석을 넘겨받음
+	if old := t.Players[session.Seat]; old != nil && old != client {
+		h.onHub(game, func() { old.GameID = "" })
+		log.Printf("%sPlayer %s replaced by %s in game %s", h.logPrefix, old.ID, client.ID, t.ID)
+	}
+
+	wasDisconnected := session.Disconnected
+	session.Disconnected = false
+	session.DisconnectedAt = time.Time{}
+	t.Players[session.Seat] = client
+
+	log.Printf("%sPlayer %s resumed %s in game %s", h.logPrefix, client.ID, session.Seat, t.ID)
+
+	h.sendToClient(client, Message{
+		Type:    h.msg(MsgResumed),
+		Payload: game.View(session.Seat),
+	})
+
+	if wasDisconnected {
+		reconnected := Message{
+			Type:    h.msg(MsgOpponentReconnected),
+			Payload: h.hooks.opponentStatus(session.Seat, 0),
+		}
+		if opponent := t.Players[h.opponent(session.Seat)]; opponent != nil {
+			h.sendToClient(opponent, reconnected)
+		}
+		h.broadcastToSpectators(game, reconnected)
+	}
+}
+
+// State 플레이어 시점의 게임 상태 스냅샷 생성 (viewer가 비어있으면 관전자 시점)
+func (g *Game) State(viewer PlayerColor) GameStatePayload {
+	state := GameStatePayload{
+		GameID:         g.ID,
+		YourColor:      viewer,
+		Round:          g.CurrentRound,
+		BlueWins:       g.BlueWins,
+		RedWins:        g.RedWins,
+		CurrentPlayer:  g.CurrentPlayer,
+		WaitingFor:     g.GetNextPlayer(),
+		BlueUsedTiles:  visibleUsedTiles(g, Blue, viewer),
+		RedUsedTiles:   visibleUsedTiles(g, Red, viewer),
+		BlueTilePlayed: g.RoundTiles[Blue] != nil,
+		RedTilePlayed:  g.RoundTiles[Red] != nil,
+		BlueName:       g.PlayerName(Blue),
+		RedName:        g.PlayerName(Red),
+		BlueConnected:  g.IsConnected(Blue),
+		RedConnected:   g.IsConnected(Red),
+		Deadline:       deadlineMillis(g.TurnDeadline),
+		TileReveal:     g.TileReveal,
+		Rules:          g.Rules,
+	}
+
+	if s := g.Sessions[viewer]; s != nil {
+		state.SessionToken = s.Token
+	}
+	if viewer != "" {
+		state.DrawOffer = g.DrawOffer
+	}
+
+	return state
+}
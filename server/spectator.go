@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+)
+
+// 게임당 최대 관전자 수
+const maxSpectators = 20
+
+func (h *gameHub[G]) handleSpectate(client *Client, msg Message) {
+	payloadBytes, _ := json.Marshal(msg.Payload)
+	var payload SpectatePayload
+	json.Unmarshal(payloadBytes, &payload)
+
+	if _, playing := h.game(client.GameID); playing || client.Room != nil {
+		h.sendError(client, "게임 참가 중에는 관전할 수 없습니다")
+		return
+	}
+
+	game, ok := h.game(payload.GameID)
+	if !ok || game.table().actor == nil {
+		h.sendError(client, "관전할 수 있는 게임이 아닙니다")
+		return
+	}
+
+	// 다른 게임을 관전 중이었거나 다시보기 중이었다면 먼저 해제
+	h.removeSpectator(client)
+	h.stopReplay(client)
+
+	client.Spectating = payload.GameID
+	game.table().actor.post(func() { h.addSpectator(game, client) })
+}
+
+// addSpectator 관전자를 추가하고 현재 상태 전송 (인원이 가득 차면 관전 취소)
+func (h *gameHub[G]) addSpectator(game G, client *Client) {
+	t := game.table()
+	if len(t.Spectators) >= maxSpectators {
+		h.sendError(client, "관전 인원이 가득 찼습니다")
+		h.onHub(game, func() {
+			if client.Spectating == t.ID {
+				client.Spectating = ""
+			}
+		})
+		return
+	}
+
+	t.Spectators[client] = true
+	log.Printf("%sClient %s spectating game %s (%d spectators)", h.logPrefix, client.ID, t.ID, len(t.Spectators))
+
+	h.sendToClient(client, Message{
+		Type:    h.msg(MsgGameState),
+		Payload: game.View(""),
+	})
+}
+
+func (h *gameHub[G]) handleStopSpectating(client *Client) {
+	h.removeSpectator(client)
+}
+
+// removeSpectator 관전 중인 게임에서 관전자 제거
+func (h *gameHub[G]) removeSpectator(client *Client) {
+	if client.Spectating == "" {
+		return
+	}
+	if game, ok := h.game(client.Spectating); ok {
+		h.onGame(game, func() { delete(game.table().Spectators, client) })
+	}
+	client.Spectating = ""
+}
+
+// broadcastToSpectators 관전자에게만 전송 (플레이어에게 숨겨진 정보를 담으면 안 됨)
+func (h *gameHub[G]) broadcastToSpectators(game G, message Message) {
+	h.sendAll(game.table().Spectators, message)
+}
+
+// broadcastPublic 플레이어와 관전자 모두에게 공개 정보 전송
+func (h *gameHub[G]) broadcastPublic(game G, message Message) {
+	h.broadcastToGame(game, message)
+	h.broadcastToSpectators(game, message)
+}
@@ -0,0 +1,434 @@
+package server
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 통계 API 설정
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	periodAll     = "all"
+	periodMonthly = "monthly"
+	monthLayout   = "2006-01"
+
+	// 응답 캐시 시간 (초)
+	leaderboardMaxAge = 60
+	playerStatsMaxAge = 30
+	tileStatsMaxAge   = 300
+)
+
+// ==================== Tile Stats ====================
+
+// tileStats 구룡투 규칙별 타일 성적 집계 (사람끼리 둔 게임만)
+type tileStats struct {
+	mu      sync.Mutex
+	byRules map[string]*tileTally
+}
+
+// tileTally 한 규칙의 집계
+type tileTally struct {
+	games  int
+	rounds int
+	tiles  map[int]*TileStat
+	upsets map[UpsetPair]*UpsetStat
+}
+
+func newTileStats() *tileStats {
+	return &tileStats{byRules: make(map[string]*tileTally)}
+}
+
+// add 게임 기록 하나를 집계에 반영
+func (t *tileStats) add(record *GameRecord) {
+	if record.Blue.Bot != "" || record.Red.Bot != "" || len(record.Rounds) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := tileRulesKey(record.Rules)
+	tally := t.byRules[key]
+	if tally == nil {
+		tally = &tileTally{
+			tiles:  make(map[int]*TileStat),
+			upsets: make(map[UpsetPair]*UpsetStat),
+		}
+		t.byRules[key] = tally
+	}
+	tally.games++
+
+	for _, round := range record.Rounds {
+		// 시간 초과로 몰수된 라운드는 타일로 승부가 나지 않았으므로 타일 성적에 넣지 않음
+		// (forfeit 표시가 없는 이전 기록은 타일이 0으로 남은 라운드로 구분)
+		if round.Forfeit || !record.Rules.hasTile(round.BlueTile) || !record.Rules.hasTile(round.RedTile) {
+			continue
+		}
+		tally.rounds++
+		tally.addTile(round.BlueTile, round.Winner, Blue)
+		tally.addTile(round.RedTile, round.Winner, Red)
+
+		for _, pair := range record.Rules.Upsets {
+			lowColor := Blue
+			switch {
+			case round.BlueTile == pair.Low && round.RedTile == pair.High:
+			case round.RedTile == pair.Low && round.BlueTile == pair.High:
+				lowColor = Red
+			default:
+				continue
+			}
+			stat := tally.upsets[pair]
+			if stat == nil {
+				stat = &UpsetStat{Low: pair.Low, High: pair.High}
+				tally.upsets[pair] = stat
+			}
+			stat.Meetings++
+			if round.Winner == lowColor {
+				stat.LowWins++
+			}
+		}
+	}
+}
+
+func (t *tileTally) addTile(tile int, winner, color PlayerColor) {
+	stat := t.tiles[tile]
+	if stat == nil {
+		stat = &TileStat{Tile: tile}
+		t.tiles[tile] = stat
+	}
+	stat.Played++
+	switch winner {
+	case color:
+		stat.Wins++
+	case "":
+		stat.Draws++
+	default:
+		stat.Losses++
+	}
+}
+
+// tileRulesKey 집계를 묶는 키
+// 프리셋과 내용이 같으면 프리셋 이름, 직접 지정한 규칙은 이름이 같아도 내용이 같을 때만 함께 집계한다
+func tileRulesKey(rules RuleSet) string {
+	if preset, err := RuleSetByName(rules.Name); err == nil && reflect.DeepEqual(preset, rules) {
+		return rules.Name
+	}
+	data, _ := json.Marshal(rules)
+	return "custom:" + string(data)
+}
+
+// hasTile 규칙에 있는 타일인지
+func (r RuleSet) hasTile(tile int) bool {
+	return tile >= r.MinTile && tile <= r.MaxTile
+}
+
+// snapshot 규칙의 현재 집계 (기록이 없어도 타일 목록은 채움)
+func (t *tileStats) snapshot(rules RuleSet) TileStatsPayload {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	payload := TileStatsPayload{RuleSet: rules.Name, Tiles: []TileStat{}, Upsets: []UpsetStat{}}
+	tally := t.byRules[tileRulesKey(rules)]
+	if tally == nil {
+		tally = &tileTally{}
+	} else {
+		payload.Games, payload.Rounds = tally.games, tally.rounds
+	}
+
+	for tile := rules.MinTile; tile <= rules.MaxTile; tile++ {
+		stat := TileStat{Tile: tile}
+		if s := tally.tiles[tile]; s != nil {
+			stat = *s
+		}
+		stat.WinRate = ratio(stat.Wins, stat.Played)
+		payload.Tiles = append(payload.Tiles, stat)
+	}
+	for _, pair := range rules.Upsets {
+		stat := UpsetStat{Low: pair.Low, High: pair.High}
+		if s := tally.upsets[pair]; s != nil {
+			stat = *s
+		}
+		stat.Rate = ratio(stat.LowWins, stat.Meetings)
+		payload.Upsets = append(payload.Upsets, stat)
+	}
+	return payload
+}
+
+// loadTileStats 저장된 구룡투 기록으로 타일 집계 초기화
+func (s *RecordStore) loadTileStats() {
+	dir := filepath.Join(s.dir, "games", recordKindNineDragons)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Printf("Error reading game records for stats: %v", err)
+		return
+	}
+
+	count := 0
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		record, err := s.LoadGame(id)
+		if err != nil {
+			log.Printf("Skipping game record %s for stats: %v", id, err)
+			continue
+		}
+		s.tiles.add(record)
+		count++
+	}
+	log.Printf("Loaded tile stats from %d game records", count)
+}
+
+// TileStats 규칙별 구룡투 타일 통계
+func (s *RecordStore) TileStats(rules RuleSet) TileStatsPayload {
+	return s.tiles.snapshot(rules)
+}
+
+// ==================== Player Stats ====================
+
+// Leaderboard 게임 종류별 순위 (month가 0이면 전체 기간, 아니면 해당 월)
+// 전체 기간은 잠정 레이팅을 제외하고 레이팅 순, 월간은 그 달 집계로 그 달에 오른 레이팅 순으로 정렬한다
+// (월간 레이팅은 그 달 마지막 게임 뒤 레이팅)
+func (s *PlayerStore) Leaderboard(kind GameKind, month time.Time) []LeaderboardEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := []LeaderboardEntry{}
+	for _, p := range s.byID {
+		r := p.Ratings[kind]
+		if r == nil || r.Games == 0 {
+			continue
+		}
+
+		entry := LeaderboardEntry{
+			PlayerID:    p.ID,
+			Name:        p.Name,
+			Rating:      int(math.Round(r.Rating)),
+			RD:          int(math.Round(r.RD)),
+			Provisional: r.Provisional(),
+		}
+
+		if month.IsZero() {
+			if entry.Provisional {
+				continue
+			}
+			entry.Games, entry.Wins, entry.Losses, entry.Draws = r.Games, r.Wins, r.Losses, r.Draws
+		} else {
+			m := r.Monthly[month.Format(monthLayout)]
+			if m == nil || m.Games == 0 {
+				continue
+			}
+			entry.Rating = m.Rating
+			entry.RatingChange = m.Rating - m.StartRating
+			entry.Games, entry.Wins, entry.Losses, entry.Draws = m.Games, m.Wins, m.Losses, m.Draws
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.RatingChange != b.RatingChange {
+			return a.RatingChange > b.RatingChange
+		}
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.PlayerID < b.PlayerID
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries
+}
+
+// PlayerStats 플레이어 프로필과 게임 종류별 통계 (레이팅 기록은 최근 순으로 page 쪽만)
+func (s *PlayerStore) PlayerStats(playerID string, page, pageSize int) (PlayerStatsPayload, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.byID[playerID]
+	if p == nil {
+		return PlayerStatsPayload{}, false
+	}
+
+	payload := PlayerStatsPayload{
+		PlayerID:  p.ID,
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+		Page:      page,
+		PageSize:  pageSize,
+		Games:     make(map[GameKind]*PlayerGameStats),
+	}
+	for _, kind := range []GameKind{GameNineDragons, GameNumberChange} {
+		r := p.Ratings[kind]
+		if r == nil {
+			r = newRating()
+		}
+
+		stats := &PlayerGameStats{
+			Rating:       r.View(),
+			Games:        r.Games,
+			Wins:         r.Wins,
+			Losses:       r.Losses,
+			Draws:        r.Draws,
+			WinRate:      ratio(r.Wins, r.Games),
+			Streak:       r.Streak,
+			History:      []RatingPoint{},
+			HistoryTotal: len(r.History),
+		}
+		start, end := pageBounds(len(r.History), page, pageSize)
+		for i := start; i < end; i++ {
+			stats.History = append(stats.History, r.History[len(r.History)-1-i])
+		}
+		payload.Games[kind] = stats
+	}
+	return payload, true
+}
+
+// ratio 0으로 나누지 않는 비율 (소수 셋째 자리까지)
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*1000) / 1000
+}
+
+// ==================== HTTP ====================
+
+// ServeLeaderboard 리더보드 조회 (GET /api/leaderboard/{game}?period=all|monthly&month=2006-01&page=1&pageSize=20)
+func ServeLeaderboard(store *PlayerStore, w http.ResponseWriter, r *http.Request) {
+	kind := GameKind(r.PathValue("game"))
+	if kind != GameNineDragons && kind != GameNumberChange {
+		writeAPIError(w, http.StatusNotFound, "알 수 없는 게임입니다")
+		return
+	}
+
+	query := r.URL.Query()
+	payload := LeaderboardPayload{Game: kind, Period: periodAll}
+	var month time.Time
+	switch query.Get("period") {
+	case "", periodAll:
+	case periodMonthly:
+		payload.Period = periodMonthly
+		now := time.Now().UTC()
+		month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if m := query.Get("month"); m != "" {
+			parsed, err := time.Parse(monthLayout, m)
+			if err != nil {
+				writeAPIError(w, http.StatusBadRequest, "월은 YYYY-MM 형식이어야 합니다")
+				return
+			}
+			month = parsed
+		}
+		payload.Month = month.Format(monthLayout)
+	default:
+		writeAPIError(w, http.StatusBadRequest, "알 수 없는 기간입니다")
+		return
+	}
+
+	entries := store.Leaderboard(kind, month)
+	payload.Page, payload.PageSize = pageParams(r)
+	payload.Total = len(entries)
+	start, end := pageBounds(len(entries), payload.Page, payload.PageSize)
+	payload.Entries = entries[start:end]
+
+	writeCachedJSON(w, r, payload, leaderboardMaxAge)
+}
+
+// ServePlayerStats 플레이어 프로필 조회 (GET /api/players/{id}?page=1&pageSize=20)
+func ServePlayerStats(store *PlayerStore, w http.ResponseWriter, r *http.Request) {
+	page, pageSize := pageParams(r)
+	payload, ok := store.PlayerStats(r.PathValue("id"), page, pageSize)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "플레이어를 찾을 수 없습니다")
+		return
+	}
+	writeCachedJSON(w, r, payload, playerStatsMaxAge)
+}
+
+// ServeTileStats 구룡투 타일 통계 조회 (GET /api/stats/ninedragons/tiles?ruleSet=classic)
+func ServeTileStats(store *RecordStore, w http.ResponseWriter, r *http.Request) {
+	rules, err := RuleSetByName(r.URL.Query().Get("ruleSet"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeCachedJSON(w, r, store.TileStats(rules), tileStatsMaxAge)
+}
+
+// pageParams page, pageSize 쿼리 (잘못된 값은 기본값, 크기는 최대값으로 제한)
+func pageParams(r *http.Request) (int, int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+// pageBounds total개 중 page 쪽의 [start, end) 범위
+func pageBounds(total, page, pageSize int) (int, int) {
+	// 범위를 넘는 쪽은 곱하기 전에 걸러 아주 큰 page에도 넘치지 않게 함
+	if page < 1 || pageSize < 1 || page-1 > total/pageSize {
+		return total, total
+	}
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// writeCachedJSON 짧게 캐시 가능한 JSON 응답 (내용이 같으면 304)
+func writeCachedJSON(w http.ResponseWriter, r *http.Request, v interface{}, maxAge int) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error marshaling API response: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "응답을 만들 수 없습니다")
+		return
+	}
+	sum := sha1.Sum(data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	http.Error(w, message, status)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTileStatsSkipsForfeitedRounds(t *testing.T) {
+	rules, _ := RuleSetByName(RuleSetClassic)
+
+	// 1라운드: 파랑 9 대 빨강 1로 정상 진행 (특수 규칙으로 1이 이김)
+	game := NewGame(rules)
+	game.AddPlayer(&Client{ID: "blue"}, Blue)
+	game.AddPlayer(&Client{ID: "red"}, Red)
+	game.PlayTile(Blue, 9)
+	game.PlayTile(Red, 1)
+	game.ProcessRound()
+
+	// 2라운드: 빨강이 8을 낸 뒤 파랑이 시간 초과로 라운드 포기 (남은 가장 작은 1을 대신 소모)
+	game.PlayTile(Red, 8)
+	game.ForfeitRound(Blue)
+	if forfeit := game.Rounds[1]; !forfeit.Forfeit || forfeit.BlueTile != 1 || forfeit.RedTile != 8 {
+		t.Fatalf("forfeited round = %+v", forfeit)
+	}
+
+	stats := newTileStats()
+	stats.add(&GameRecord{Rules: rules, Rounds: game.Rounds})
+	// forfeit 표시가 없는 이전 기록은 타일이 0인 라운드로 구분
+	stats.add(&GameRecord{Rules: rules, Rounds: []RoundRecord{{Round: 1, BlueTile: 0, RedTile: 9, Winner: Red}}})
+
+	got := stats.snapshot(rules)
+	if got.Games != 2 || got.Rounds != 1 {
+		t.Errorf("games/rounds = %d/%d, want 2/1", got.Games, got.Rounds)
+	}
+	for _, stat := range got.Tiles {
+		want := TileStat{Tile: stat.Tile}
+		switch stat.Tile {
+		case 1:
+			want = TileStat{Tile: 1, Played: 1, Wins: 1, WinRate: 1}
+		case 9:
+			want = TileStat{Tile: 9, Played: 1, Losses: 1}
+		}
+		if stat != want {
+			t.Errorf("tile %d = %+v, want %+v", stat.Tile, stat, want)
+		}
+	}
+	if len(got.Upsets) != 1 || got.Upsets[0].Meetings != 1 || got.Upsets[0].LowWins != 1 {
+		t.Errorf("upsets = %+v, want one 1-vs-9 meeting won by 1", got.Upsets)
+	}
+}
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		total, page, size int
+		start, end        int
+	}{
+		{total: 45, page: 1, size: 20, start: 0, end: 20},
+		{total: 45, page: 3, size: 20, start: 40, end: 45},
+		{total: 45, page: 4, size: 20, start: 45, end: 45},
+		{total: 45, page: 0, size: 20, start: 45, end: 45},
+		{total: 45, page: 1 << 62, size: 100, start: 45, end: 45},
+	}
+	for _, tt := range tests {
+		start, end := pageBounds(tt.total, tt.page, tt.size)
+		if start != tt.start || end != tt.end {
+			t.Errorf("pageBounds(%d, %d, %d) = %d, %d, want %d, %d", tt.total, tt.page, tt.size, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+// 월간 리더보드와 연속 기록은 보관 한도를 넘겨 잘린 레이팅 기록과 상관없이 집계한다
+func TestMonthlyAggregates(t *testing.T) {
+	s := testPlayerStore()
+	a, _ := s.Identify("", "a")
+	r := s.byID[a.ID].rating(GameNineDragons)
+
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := march.AddDate(0, 1, 0)
+
+	// 3월: 한도를 넘는 판 수, 마지막 두 판은 패배
+	marchGames := maxHistory + 10
+	for i := 0; i < marchGames; i++ {
+		score, rating := 1.0, 1600.0
+		if i >= marchGames-2 {
+			score, rating = 0, 1580
+		}
+		r.apply(a.ID, "m", rating, 60, score, march.Add(time.Duration(i)*time.Minute))
+	}
+	// 4월: 한 판 승리
+	r.apply(a.ID, "a", 1620, 60, 1, april.Add(time.Hour))
+
+	if len(r.History) != maxHistory {
+		t.Fatalf("history = %d, want capped at %d", len(r.History), maxHistory)
+	}
+
+	entries := s.Leaderboard(GameNineDragons, march)
+	if len(entries) != 1 {
+		t.Fatalf("march entries = %+v", entries)
+	}
+	got := entries[0]
+	if got.Games != marchGames || got.Wins != marchGames-2 || got.Losses != 2 {
+		t.Errorf("march record = %d games %d-%d, want %d games %d-2", got.Games, got.Wins, got.Losses, marchGames, marchGames-2)
+	}
+	if got.Rating != 1580 || got.RatingChange != 80 {
+		t.Errorf("march rating = %d (%+d), want 1580 (+80)", got.Rating, got.RatingChange)
+	}
+
+	if entries := s.Leaderboard(GameNineDragons, april); len(entries) != 1 || entries[0].Games != 1 || entries[0].Rating != 1620 || entries[0].RatingChange != 40 {
+		t.Errorf("april entries = %+v", entries)
+	}
+	if entries := s.Leaderboard(GameNineDragons, april.AddDate(0, 1, 0)); len(entries) != 0 {
+		t.Errorf("may entries = %+v, want none", entries)
+	}
+
+	stats, _ := s.PlayerStats(a.ID, 1, 10)
+	nd := stats.Games[GameNineDragons]
+	if nd.Games != marchGames+1 || nd.Streak != (Streak{Result: resultWin, Count: 1}) {
+		t.Errorf("stats = %d games, streak %+v", nd.Games, nd.Streak)
+	}
+}
+
+// 월별 집계가 생기기 전에 저장한 레이팅은 불러올 때 남은 기록으로 채운다
+func TestRatingBackfill(t *testing.T) {
+	at := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	r := &Rating{History: []RatingPoint{
+		{At: at, Rating: 1520, Delta: 20, Result: resultWin},
+		{At: at.Add(time.Hour), Rating: 1510, Delta: -10, Result: resultLoss},
+		{At: at.Add(2 * time.Hour), Rating: 1500, Delta: -10, Result: resultLoss},
+	}}
+
+	r.backfill()
+
+	m := r.Monthly["2026-03"]
+	if m == nil || m.Games != 3 || m.Wins != 1 || m.Losses != 2 || m.StartRating != 1500 || m.Rating != 1500 {
+		t.Errorf("march = %+v", m)
+	}
+	if r.Streak != (Streak{Result: resultLoss, Count: 2}) {
+		t.Errorf("streak = %+v", r.Streak)
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import "errors"
+
+// newTable 빈 좌석 상태 생성
+func newTable(id string) Table {
+	return Table{
+		ID:            id,
+		Players:       make(map[Seat]*Client),
+		Bots:          make(map[Seat]*Bot),
+		Sessions:      make(map[Seat]*Session),
+		Spectators:    make(map[*Client]bool),
+		DrawOfferedAt: make(map[Seat]int),
+	}
+}
+
+// table 허브가 다루는 좌석 상태 (Game, NCGame에 포함된 Table)
+func (t *Table) table() *Table {
+	return t
+}
+
+// PlayerName 좌석의 플레이어 이름 (연결이 끊긴 플레이어는 세션 이름)
+func (t *Table) PlayerName(seat Seat) string {
+	if p := t.Players[seat]; p != nil {
+		return p.Name
+	}
+	if bot := t.Bots[seat]; bot != nil {
+		return bot.Name
+	}
+	if s := t.Sessions[seat]; s != nil {
+		return s.Name
+	}
+	return ""
+}
+
+// IsConnected 좌석에 플레이어가 연결되어 있는지 확인 (AI는 항상 연결)
+func (t *Table) IsConnected(seat Seat) bool {
+	return t.Players[seat] != nil || t.Bots[seat] != nil
+}
+
+// playerRecord 기록에 남길 좌석의 플레이어
+func (t *Table) playerRecord(seat Seat) PlayerRecord {
+	record := PlayerRecord{Name: t.PlayerName(seat)}
+	if s := t.Sessions[seat]; s != nil {
+		record.ID = s.PlayerID
+	}
+	if bot := t.Bots[seat]; bot != nil {
+		record.Bot = bot.Level
+	}
+	return record
+}
+
+// offerDraw seat의 무승부 제안 (상대가 먼저 제안해 둔 상태면 합의로 true)
+// 같은 라운드에 두 번 제안할 수 없어 거절당한 제안을 반복해 보낼 수 없다
+// 받아들이지 않은 제안은 라운드가 끝나면 사라진다
+func (t *Table) offerDraw(seat, opponent Seat, round int) (bool, error) {
+	if t.DrawOffer == opponent {
+		return true, nil
+	}
+	if t.DrawOffer == seat {
+		return false, errors.New("이미 무승부를 제안했습니다")
+	}
+	if t.DrawOfferedAt[seat] == round {
+		return false, errors.New("무승부는 한 라운드에 한 번만 제안할 수 있습니다")
+	}
+	t.DrawOffer = seat
+	t.DrawOfferedAt[seat] = round
+	return false, nil
+}
+
+// startSeries 첫 게임부터 bestOf판 다전제 시작 (0이면 단판, 좌석 순서가 자리 번호)
+func (t *Table) startSeries(bestOf int, seats []Seat) {
+	if bestOf == 0 {
+		return
+	}
+	t.Series = newBestOfSeries(bestOf)
+	t.SeriesSeat = make(map[Seat]int)
+	for i, seat := range seats {
+		t.SeriesSeat[seat] = i
+	}
+}
+
+// seriesSeatOf 전적 자리 번호의 현재 게임 좌석 (없으면 빈 값)
+func (t *Table) seriesSeatOf(index int) Seat {
+	for seat, i := range t.SeriesSeat {
+		if i == index {
+			return seat
+		}
+	}
+	return ""
+}
+
+// seriesScore 재대결 전적 (첫 게임이면 nil)
+func (t *Table) seriesScore() *SeriesScore {
+	if t.Series == nil {
+		return nil
+	}
+	seats := make(map[string]int)
+	for seat, i := range t.SeriesSeat {
+		seats[string(seat)] = i
+	}
+	return t.Series.score(seats)
+}
+
+// recordSeries 끝난 게임 결과를 재대결 전적에 반영
+func (t *Table) recordSeries(winner Seat) {
+	if t.Series == nil {
+		return
+	}
+	if winner == "" {
+		t.Series.record(t.ID, -1)
+		return
+	}
+	t.Series.record(t.ID, t.SeriesSeat[winner])
+}
+
+// seriesRecord 다전제 마지막 게임으로 다전제 기록 생성
+func (t *Table) seriesRecord(kind GameKind, winner Seat, reason string, ratings map[Seat]*RatingChange) *SeriesRecord {
+	var players [2]PlayerRecord
+	var seatRatings [2]*RatingChange
+	for seat, i := range t.SeriesSeat {
+		players[i] = t.playerRecord(seat)
+		seatRatings[i] = ratings[seat]
+	}
+	winnerSeat := -1
+	if winner != "" {
+		winnerSeat = t.SeriesSeat[winner]
+	}
+	return newSeriesRecord(kind, t.Series, players, winnerSeat, reason, seatRatings)
+}
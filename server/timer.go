@@ -0,0 +1,132 @@
+package server
+
+import (
+	"log"
+	"time"
+)
+
+// 차례 제한 시간 기본값 및 허용 범위
+const (
+	defaultTurnLimit = 30 * time.Second
+	minTurnSeconds   = 5
+	maxTurnSeconds   = 300
+)
+
+// configureTurnTimer 게임 생성 시 요청된 차례 제한 시간과 처리 방식 적용
+func configureTurnTimer(game *Game, turnSeconds int, policy TimeoutPolicy) {
+	turnSeconds, policy = turnSettings(turnSeconds, policy)
+	game.TurnLimit = time.Duration(turnSeconds) * time.Second
+	game.TimeoutPolicy = policy
+}
+
+// turnSettings 요청된 차례 설정을 검증하고 잘못된 값은 기본값으로 대체
+func turnSettings(turnSeconds int, policy TimeoutPolicy) (int, TimeoutPolicy) {
+	if turnSeconds < minTurnSeconds || turnSeconds > maxTurnSeconds {
+		turnSeconds = int(defaultTurnLimit / time.Second)
+	}
+
+	switch policy {
+	case TimeoutAutoPlay, TimeoutForfeitRound, TimeoutForfeitGame:
+	default:
+		policy = TimeoutAutoPlay
+	}
+
+	return turnSeconds, policy
+}
+
+// startTurnTimer 현재 차례의 제한 시간 시작 (이전 타이머는 무효화)
+func (h *Hub) startTurnTimer(game *Game) {
+	h.stopTurnTimer(game)
+
+	seq := game.turnSeq
+	game.TurnDeadline = time.Now().Add(game.TurnLimit)
+	game.turnTimer = time.AfterFunc(game.TurnLimit, func() {
+		game.actor.post(func() { h.handleTurnTimeout(game, seq) })
+	})
+
+	// AI 차례면 제한 시간 안에 수를 두도록 예약
+	h.scheduleBotMove(game)
+}
+
+// stopTurnTimer 진행 중인 차례 타이머 중지
+func (h *Hub) stopTurnTimer(game *Game) {
+	if game.turnTimer != nil {
+		game.turnTimer.Stop()
+		game.turnTimer = nil
+	}
+	game.turnSeq++
+	game.TurnDeadline = time.Time{}
+}
+
+// handleTurnTimeout 제한 시간을 넘긴 플레이어에게 게임 설정에 따른 처리 적용 (seq가 지난 차례면 무시)
+func (h *Hub) handleTurnTimeout(game *Game, seq int) {
+	if !game.Ready || game.turnSeq != seq {
+		return
+	}
+
+	color := game.GetNextPlayer()
+	round := game.CurrentRound
+	log.Printf("Turn timeout in game %s: %s (round %d, policy %s)", game.ID, color, round, game.TimeoutPolicy)
+
+	switch game.TimeoutPolicy {
+	case TimeoutForfeitGame:
+		h.broadcastPublic(game, Message{
+			Type: MsgTimeout,
+			Payload: TimeoutPayload{
+				Color:  color,
+				Round:  round,
+				Policy: game.TimeoutPolicy,
+			},
+		})
+		h.endGame(game, opponentColor(color), reasonTimeout)
+
+	case TimeoutForfeitRound:
+		h.broadcastPublic(game, Message{
+			Type: MsgTimeout,
+			Payload: TimeoutPayload{
+				Color:  color,
+				Round:  round,
+				Policy: game.TimeoutPolicy,
+			},
+		})
+		winner, blueTile, redTile := game.ForfeitRound(color)
+		h.finishRound(game, round, winner, blueTile, redTile)
+
+	default:
+		tile := game.LowestUnusedTile(color)
+		timeout := TimeoutPayload{
+			Color:  color,
+			Round:  round,
+			Policy: game.TimeoutPolicy,
+			Tile:   tile,
+		}
+		for playerColor, player := range game.Players {
+			view := timeout
+			if playerColor != color && game.TileReveal != RevealOpen {
+				view.Tile = 0
+			}
+			h.sendToClient(player, Message{
+				Type:    MsgTimeout,
+				Payload: view,
+			})
+		}
+
+		// 자동으로 낸 타일은 이어지는 tile_played/round_result로 관전자에게 공개
+		timeout.Tile = 0
+		h.broadcastToSpectators(game, Message{
+			Type:    MsgTimeout,
+			Payload: timeout,
+		})
+		if err := h.playTile(game, color, tile, true); err != nil {
+			log.Printf("Error auto-playing tile %d for %s: %v", tile, color, err)
+		}
+	}
+}
+
+// deadlineMillis 마감 시각을 unix ms로 변환 (없으면 0)
+func deadlineMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// timeoutOf client가 받은 시간 초과 알림 (없으면 nil)
+func timeoutOf(msgs []sentND) *TimeoutPayload {
+	for _, msg := range msgs {
+		if msg.Type == MsgTimeout {
+			var payload TimeoutPayload
+			json.Unmarshal(msg.Payload, &payload)
+			return &payload
+		}
+	}
+	return nil
+}
+
+func TestHandleTurnTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     TimeoutPolicy
+		setup      func(game *Game)
+		stale      bool // 지난 차례의 타이머
+		wantColor  PlayerColor
+		wantTile   int // 시간을 넘긴 플레이어에게 알린 자동 타일
+		wantBlue   int // 처리 후 파랑 승수
+		wantRed    int // 처리 후 빨강 승수
+		wantRound  int // 처리 후 현재 라운드
+		wantOver   bool
+		wantWinner PlayerColor
+		wantReason string
+	}{
+		{
+			name:      "자동 제출",
+			policy:    TimeoutAutoPlay,
+			wantColor: Blue,
+			wantTile:  1,
+			wantRound: 1,
+		},
+		{
+			name:   "자동 제출로 라운드 완료",
+			policy: TimeoutAutoPlay,
+			setup: func(game *Game) {
+				game.PlayTile(Blue, 5)
+			},
+			wantColor: Red,
+			wantTile:  1,
+			wantBlue:  1,
+			wantRound: 2,
+		},
+		{
+			name:      "라운드 몰수",
+			policy:    TimeoutForfeitRound,
+			wantColor: Blue,
+			wantRed:   1,
+			wantRound: 2,
+		},
+		{
+			name:   "라운드 몰수로 필요 승수 달성",
+			policy: TimeoutForfeitRound,
+			setup: func(game *Game) {
+				game.RedWins = game.Rules.WinsNeeded - 1
+			},
+			wantColor:  Blue,
+			wantRed:    5,
+			wantRound:  2,
+			wantOver:   true,
+			wantWinner: Red,
+			wantReason: reasonScoreLimit,
+		},
+		{
+			name:       "게임 몰수",
+			policy:     TimeoutForfeitGame,
+			wantColor:  Blue,
+			wantRound:  1,
+			wantOver:   true,
+			wantWinner: Red,
+			wantReason: reasonTimeout,
+		},
+		{
+			name:      "지난 차례 타이머는 무시",
+			policy:    TimeoutForfeitGame,
+			stale:     true,
+			wantRound: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, game, blue, red := testGame(t)
+			t.Cleanup(func() { h.stopTurnTimer(game) })
+			game.TimeoutPolicy = tt.policy
+			if tt.setup != nil {
+				tt.setup(game)
+			}
+			h.startTurnTimer(game)
+			seq := game.turnSeq
+			if tt.stale {
+				h.startTurnTimer(game)
+			}
+			sent(t, blue)
+			sent(t, red)
+
+			h.handleTurnTimeout(game, seq)
+
+			blueMsgs, redMsgs := sent(t, blue), sent(t, red)
+			timedOut, opponentMsgs := blueMsgs, redMsgs
+			if tt.wantColor == Red {
+				timedOut, opponentMsgs = redMsgs, blueMsgs
+			}
+			notice := timeoutOf(timedOut)
+			if tt.stale {
+				if notice != nil || len(redMsgs) > 0 || h.games[game.ID] == nil {
+					t.Fatalf("stale timer acted: %+v", notice)
+				}
+				return
+			}
+			if notice == nil || notice.Color != tt.wantColor || notice.Policy != tt.policy || notice.Tile != tt.wantTile {
+				t.Fatalf("timeout notice = %+v", notice)
+			}
+			// 가려진 게임에서 상대는 자동으로 낸 타일을 모름
+			if other := timeoutOf(opponentMsgs); other == nil || other.Tile != 0 {
+				t.Errorf("opponent timeout notice = %+v", other)
+			}
+
+			if game.BlueWins != tt.wantBlue || game.RedWins != tt.wantRed || game.CurrentRound != tt.wantRound {
+				t.Errorf("wins %d-%d round %d, want %d-%d round %d",
+					game.BlueWins, game.RedWins, game.CurrentRound, tt.wantBlue, tt.wantRed, tt.wantRound)
+			}
+			if tt.policy == TimeoutForfeitRound && !game.Rounds[0].Forfeit {
+				t.Error("forfeited round is not marked")
+			}
+			if tt.policy == TimeoutAutoPlay {
+				last := game.Moves[len(game.Moves)-1]
+				if last.Color != tt.wantColor || last.Tile != tt.wantTile || !last.Auto {
+					t.Errorf("last move = %+v", last)
+				}
+			}
+
+			var over *GameOverPayload
+			for _, msg := range blueMsgs {
+				if msg.Type == MsgGameOver {
+					over = &GameOverPayload{}
+					json.Unmarshal(msg.Payload, over)
+				}
+			}
+			if (over != nil) != tt.wantOver {
+				t.Fatalf("game over = %+v, want %v", over, tt.wantOver)
+			}
+			if over != nil && (over.Winner != tt.wantWinner || over.Reason != tt.wantReason) {
+				t.Errorf("game over = %q %q, want %q %q", over.Winner, over.Reason, tt.wantWinner, tt.wantReason)
+			}
+			if closed := h.games[game.ID] == nil; closed != tt.wantOver {
+				t.Errorf("closed = %v, want %v", closed, tt.wantOver)
+			}
+		})
+	}
+}
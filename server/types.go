@@ -1,15 +1,110 @@
 package server
 
-import "github.com/gorilla/websocket"
+import (
+	"time"
 
-// Player 색상
-type PlayerColor string
+	"github.com/gorilla/websocket"
+)
+
+// PlayerColor 구룡투 좌석 (색)
+type PlayerColor = Seat
 
 const (
 	Blue PlayerColor = "blue"
 	Red  PlayerColor = "red"
 )
 
+// TimeoutPolicy 차례 시간 초과 시 처리 방식
+type TimeoutPolicy string
+
+const (
+	TimeoutAutoPlay     TimeoutPolicy = "auto_play"     // 가장 작은 타일 자동 제출
+	TimeoutForfeitRound TimeoutPolicy = "forfeit_round" // 라운드 패배
+	TimeoutForfeitGame  TimeoutPolicy = "forfeit_game"  // 게임 패배
+)
+
+// TileReveal 라운드가 끝나기 전 상대에게 공개되는 타일 정보 범위
+type TileReveal string
+
+const (
+	RevealBlind TileReveal = "blind" // 타일을 냈다는 사실만 공개 (기본값)
+	RevealColor TileReveal = "color" // 타일 색(홀수 검정, 짝수 흰색)까지 공개
+	RevealOpen  TileReveal = "open"  // 타일 숫자까지 즉시 공개
+)
+
+// DrawLeader 무승부 라운드 다음 선공
+type DrawLeader string
+
+const (
+	DrawLeaderKeep DrawLeader = "keep" // 이전 선공 유지
+	DrawLeaderSwap DrawLeader = "swap" // 상대가 선공
+)
+
+// TieBreak 마지막 라운드까지 승수가 같을 때 처리
+type TieBreak string
+
+const (
+	TieBreakDraw       TieBreak = "draw"        // 무승부
+	TieBreakLastWinner TieBreak = "last_winner" // 마지막으로 라운드를 이긴 플레이어 승리
+)
+
+// BotLevel AI 상대 난이도
+type BotLevel string
+
+const (
+	BotRandom    BotLevel = "random"    // 남은 타일 중 무작위
+	BotGreedy    BotLevel = "greedy"    // 이번 라운드 승률이 가장 높은 타일
+	BotStrategic BotLevel = "strategic" // 양쪽 남은 타일로 계산한 혼합 전략
+)
+
+// Bot 웹소켓 연결 없이 좌석을 차지하는 서버 AI 플레이어
+type Bot struct {
+	Seat  Seat
+	Level BotLevel
+	Name  string
+}
+
+// UpsetPair 작은 타일이 큰 타일을 이기는 특수 조합
+type UpsetPair struct {
+	Low  int `json:"low"`
+	High int `json:"high"`
+}
+
+// RuleSet 구룡투 규칙
+type RuleSet struct {
+	Name       string      `json:"name"`
+	MinTile    int         `json:"minTile"`
+	MaxTile    int         `json:"maxTile"`
+	WinsNeeded int         `json:"winsNeeded"`
+	MaxRounds  int         `json:"maxRounds"`
+	Upsets     []UpsetPair `json:"upsets"`
+	DrawLeader DrawLeader  `json:"drawLeader"`
+	TieBreak   TieBreak    `json:"tieBreak"`
+}
+
+// ReplayAction 다시보기 조작 종류
+type ReplayAction string
+
+const (
+	ReplayPlay  ReplayAction = "play"
+	ReplayPause ReplayAction = "pause"
+	ReplaySeek  ReplayAction = "seek"  // round 라운드 시작으로 이동
+	ReplaySpeed ReplayAction = "speed" // 재생 배속 변경
+)
+
+// Replay 한 클라이언트가 보고 있는 게임 기록 재생 상태
+type Replay struct {
+	GameID    string
+	Frames    []replayFrame
+	Snapshots [][]byte // 라운드 시작 시점의 상태 메시지 (인덱스 = 라운드-1)
+	Position  int      // 다음에 보낼 프레임
+	Playing   bool
+	Speed     float64
+
+	seq   int
+	timer *time.Timer
+}
+
 // 메시지 타입
 type MessageType string
 
@@ -24,6 +119,52 @@ const (
 	MsgError         MessageType = "error"
 	MsgPlayerJoined  MessageType = "player_joined"
 	MsgWaitingPlayer MessageType = "waiting_player"
+
+	MsgResume               MessageType = "resume"
+	MsgResumed              MessageType = "resumed"
+	MsgOpponentDisconnected MessageType = "opponent_disconnected"
+	MsgOpponentReconnected  MessageType = "opponent_reconnected"
+
+	MsgCreateRoom    MessageType = "create_room"
+	MsgJoinRoom      MessageType = "join_room"
+	MsgStartRoom     MessageType = "start_room"
+	MsgCancelRoom    MessageType = "cancel_room"
+	MsgConfigureRoom MessageType = "configure_room"
+	MsgRoomCreated   MessageType = "room_created"
+	MsgRoomUpdated   MessageType = "room_updated"
+	MsgRoomCancelled MessageType = "room_cancelled"
+
+	MsgSpectate       MessageType = "spectate"
+	MsgStopSpectating MessageType = "stop_spectating"
+	MsgGameState      MessageType = "game_state"
+
+	MsgReplay        MessageType = "replay"
+	MsgReplayControl MessageType = "replay_control"
+	MsgStopReplay    MessageType = "stop_replay"
+	MsgReplayState   MessageType = "replay_state"
+
+	MsgIdentify MessageType = "identify"
+	MsgIdentity MessageType = "identity"
+
+	MsgCancelQueue    MessageType = "cancel_queue"
+	MsgQueueStatus    MessageType = "queue_status"
+	MsgQueueCancelled MessageType = "queue_cancelled"
+
+	MsgRematchOffer    MessageType = "rematch_offer"
+	MsgRematchAccept   MessageType = "rematch_accept"
+	MsgRematchDecline  MessageType = "rematch_decline"
+	MsgRematchOffered  MessageType = "rematch_offered"
+	MsgRematchDeclined MessageType = "rematch_declined"
+
+	MsgSeriesUpdate MessageType = "series_update"
+	MsgSeriesOver   MessageType = "series_over"
+
+	MsgResign       MessageType = "resign"
+	MsgOfferDraw    MessageType = "offer_draw"
+	MsgAcceptDraw   MessageType = "accept_draw"
+	MsgDeclineDraw  MessageType = "decline_draw"
+	MsgDrawOffered  MessageType = "draw_offered"
+	MsgDrawDeclined MessageType = "draw_declined"
 )
 
 // Client 구조체
@@ -31,23 +172,93 @@ type Client struct {
 	ID     string
 	Name   string
 	Conn   *websocket.Conn
-	Hub    *Hub
 	Send   chan []byte
 	GameID string
-	Color  PlayerColor
+	Seat   Seat
+	Token  string
+	Room   *Room
+
+	// 관전 중인 게임 ID
+	Spectating string
+
+	// 재생 중인 게임 기록
+	Replay *Replay
+
+	// 레이팅 프로필 ID (identify 전에는 비어있음)
+	PlayerID string
+
+	// 연결 주소 (프로필 없는 플레이어의 기권패를 세는 데 사용)
+	Addr string
+
+	// 끝난 게임의 재대결 제안 (응답 대기 중)
+	Rematch *Rematch
+}
+
+// Room 참가 코드로 입장하는 비공개 방
+type Room struct {
+	Code      string
+	Owner     *Client
+	Password  string
+	Members   map[Seat]*Client
+	Settings  interface{} // 게임 종류별 설정 (규칙, 제한 시간 등)
+	BestOf    int         // 다전제 판 수 (0이면 단판)
+	ExpiresAt time.Time
+
+	expiryTimer *time.Timer
+}
+
+// Session 재접속용 세션 정보
+type Session struct {
+	Token          string
+	GameID         string
+	Seat           Seat
+	Name           string
+	PlayerID       string
+	Addr           string // 좌석에 앉을 때의 연결 주소 (기권패 기록)
+	Disconnected   bool
+	DisconnectedAt time.Time
+}
+
+// Table 게임 종류와 상관없이 허브가 좌석 단위로 다루는 상태 (Game, NCGame에 포함)
+type Table struct {
+	ID            string
+	Players       map[Seat]*Client
+	Bots          map[Seat]*Bot
+	Sessions      map[Seat]*Session
+	Spectators    map[*Client]bool
+	Ready         bool
+	StartedAt     time.Time
+	Casual        bool         // 레이팅에 반영하지 않는 친선 게임
+	Rated         bool         // 시작 시 결정된 레이팅 반영 여부
+	Series        *Series      // 재대결이나 다전제로 이어진 게임들의 전적 (단판 첫 게임은 nil)
+	SeriesSeat    map[Seat]int // 좌석 -> 전적 자리 번호
+	DrawOffer     Seat         // 응답을 기다리는 무승부 제안 (없으면 빈 값)
+	DrawOfferedAt map[Seat]int // 좌석 -> 마지막으로 무승부를 제안한 라운드
+
+	actor *gameActor // 시작한 뒤 게임 상태를 맡는 고루틴 (시작 전에는 nil)
 }
 
 // Game 구조체
 type Game struct {
-	ID            string
-	Players       map[PlayerColor]*Client
+	Table
 	CurrentRound  int
 	BlueWins      int
 	RedWins       int
 	UsedTiles     map[PlayerColor][]int
 	CurrentPlayer PlayerColor
 	RoundTiles    map[PlayerColor]*int
-	Ready         bool
+	TurnLimit     time.Duration
+	TimeoutPolicy TimeoutPolicy
+	TurnDeadline  time.Time
+	TileReveal    TileReveal
+	Rules         RuleSet
+	RoundWinners  []PlayerColor // 라운드별 승자 (무승부는 빈 값)
+	FirstPlayer   PlayerColor
+	Moves         []MoveRecord
+	Rounds        []RoundRecord
+
+	turnSeq   int
+	turnTimer *time.Timer
 }
 
 // 메시지 구조체들
@@ -57,8 +268,15 @@ type Message struct {
 }
 
 type JoinGamePayload struct {
-	PlayerName string      `json:"playerName"`
-	Color      PlayerColor `json:"color"`
+	PlayerName    string        `json:"playerName"`
+	Color         PlayerColor   `json:"color"`
+	TurnSeconds   int           `json:"turnSeconds,omitempty"`
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy,omitempty"`
+	TileReveal    TileReveal    `json:"tileReveal,omitempty"`
+	RuleSet       string        `json:"ruleSet,omitempty"` // 프리셋 이름 (classic, short, extended)
+	Bot           BotLevel      `json:"bot,omitempty"`     // 지정하면 대기열 없이 AI와 대전
+	Casual        bool          `json:"casual,omitempty"`  // 레이팅에 반영하지 않는 게임
+	BestOf        int           `json:"bestOf,omitempty"`  // 다전제 판 수 (3, 5, 7)
 }
 
 type PlayTilePayload struct {
@@ -73,19 +291,51 @@ type RoundResultPayload struct {
 	BlueWins   int         `json:"blueWins"`
 	RedWins    int         `json:"redWins"`
 	NextPlayer PlayerColor `json:"nextPlayer"`
+	Deadline   int64       `json:"deadline,omitempty"` // 다음 차례 마감 시각 (unix ms)
 }
 
 type GameOverPayload struct {
-	Winner   PlayerColor `json:"winner"`
-	BlueWins int         `json:"blueWins"`
-	RedWins  int         `json:"redWins"`
+	Winner   PlayerColor                   `json:"winner"`
+	BlueWins int                           `json:"blueWins"`
+	RedWins  int                           `json:"redWins"`
+	Reason   string                        `json:"reason,omitempty"`  // score_limit, rounds_complete, timeout, resigned, draw_agreed, abandoned
+	Ratings  map[PlayerColor]*RatingChange `json:"ratings,omitempty"` // 레이팅 게임의 레이팅 변화
+	Series   *SeriesScore                  `json:"series,omitempty"`  // 재대결 전적 (이번 게임 포함)
+}
+
+// 게임 종료 사유 (게임 종료 메시지와 게임 기록의 reason)
+const (
+	reasonScoreLimit     = "score_limit"     // 필요 승수(목표 점수)를 먼저 채움
+	reasonRoundsComplete = "rounds_complete" // 마지막 라운드까지 진행
+	reasonOvertime       = "overtime"        // 넘버체인지 연장전 종료
+	reasonTimeout        = "timeout"         // 구룡투 시간 초과 패배
+	reasonResigned       = "resigned"        // 기권
+	reasonDrawAgreed     = "draw_agreed"     // 무승부 합의
+	reasonAbandoned      = "abandoned"       // 연결이 끊긴 뒤 돌아오지 않아 기권패
+)
+
+// DrawOfferPayload 무승부 제안/거절 알림
+type DrawOfferPayload struct {
+	Color PlayerColor `json:"color"` // 제안하거나 거절한 플레이어
+}
+
+// NCDrawOfferPayload 무승부 제안/거절 알림
+type NCDrawOfferPayload struct {
+	Team TeamColor `json:"team"`
 }
 
 type GameStartPayload struct {
-	FirstPlayer PlayerColor `json:"firstPlayer"`
-	YourColor   PlayerColor `json:"yourColor"`
-	BlueName    string      `json:"blueName"`
-	RedName     string      `json:"redName"`
+	FirstPlayer   PlayerColor   `json:"firstPlayer"`
+	YourColor     PlayerColor   `json:"yourColor"`
+	BlueName      string        `json:"blueName"`
+	RedName       string        `json:"redName"`
+	TurnSeconds   int           `json:"turnSeconds"`
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy"`
+	TileReveal    TileReveal    `json:"tileReveal"`
+	Rules         RuleSet       `json:"rules"`
+	Deadline      int64         `json:"deadline,omitempty"` // 첫 차례 마감 시각 (unix ms)
+	Rated         bool          `json:"rated"`
+	Series        *SeriesScore  `json:"series,omitempty"` // 재대결 전적 (이번 게임 전까지)
 }
 
 type ErrorPayload struct {
@@ -93,56 +343,299 @@ type ErrorPayload struct {
 }
 
 type TilePlayedPayload struct {
+	Color          PlayerColor `json:"color"`
+	Tile           int         `json:"tile"`                // 공개되지 않은 경우 0
+	TileColor      string      `json:"tileColor,omitempty"` // "black"(홀수) 또는 "white"(짝수)
+	Round          int         `json:"round"`
+	NextPlayer     PlayerColor `json:"nextPlayer"`
+	WaitingFor     PlayerColor `json:"waitingFor"`
+	BlueTilePlayed bool        `json:"blueTilePlayed"`
+	RedTilePlayed  bool        `json:"redTilePlayed"`
+	Deadline       int64       `json:"deadline,omitempty"` // 다음 차례 마감 시각 (unix ms)
+}
+
+// TimeoutPayload 차례 시간 초과 알림
+type TimeoutPayload struct {
+	Color  PlayerColor   `json:"color"`
+	Round  int           `json:"round"`
+	Policy TimeoutPolicy `json:"policy"`
+	Tile   int           `json:"tile,omitempty"` // 자동으로 낸 타일
+}
+
+// CreateRoomPayload 비공개 방 생성
+type CreateRoomPayload struct {
+	PlayerName    string        `json:"playerName"`
+	Color         PlayerColor   `json:"color"`
+	Password      string        `json:"password,omitempty"`
+	TurnSeconds   int           `json:"turnSeconds,omitempty"`
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy,omitempty"`
+	TileReveal    TileReveal    `json:"tileReveal,omitempty"`
+	RuleSet       string        `json:"ruleSet,omitempty"` // 프리셋 이름
+	Rules         *RuleSet      `json:"rules,omitempty"`   // 직접 지정한 규칙 (프리셋보다 우선)
+	BestOf        int           `json:"bestOf,omitempty"`  // 다전제 판 수 (3, 5, 7)
+}
+
+// JoinRoomPayload 참가 코드로 방 입장
+type JoinRoomPayload struct {
+	PlayerName string `json:"playerName"`
+	Code       string `json:"code"`
+	Password   string `json:"password,omitempty"`
+}
+
+// ConfigureRoomPayload 방 설정 변경 (방장 전용, 비어있는 항목은 유지)
+type ConfigureRoomPayload struct {
+	Password      *string       `json:"password,omitempty"`
+	TurnSeconds   int           `json:"turnSeconds,omitempty"`
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy,omitempty"`
+	TileReveal    TileReveal    `json:"tileReveal,omitempty"`
+	RuleSet       string        `json:"ruleSet,omitempty"`
+	Rules         *RuleSet      `json:"rules,omitempty"`
+	BestOf        *int          `json:"bestOf,omitempty"` // 0 또는 1이면 단판
+}
+
+// RoomPayload 방 상태
+type RoomPayload struct {
+	Code          string        `json:"code"`
+	YourColor     PlayerColor   `json:"yourColor"`
+	OwnerColor    PlayerColor   `json:"ownerColor"`
+	BlueName      string        `json:"blueName"`
+	RedName       string        `json:"redName"`
+	HasPassword   bool          `json:"hasPassword"`
+	TurnSeconds   int           `json:"turnSeconds"`
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy"`
+	TileReveal    TileReveal    `json:"tileReveal"`
+	Rules         RuleSet       `json:"rules"`
+	BestOf        int           `json:"bestOf,omitempty"`
+	ExpiresAt     int64         `json:"expiresAt"` // 방 만료 시각 (unix ms)
+}
+
+// SpectatePayload 게임 관전 요청
+type SpectatePayload struct {
+	GameID string `json:"gameId"`
+}
+
+// ReplayPayload 종료된 게임 다시보기 요청
+type ReplayPayload struct {
+	GameID string  `json:"gameId"`
+	Speed  float64 `json:"speed,omitempty"`  // 재생 배속 (기본 1)
+	Paused bool    `json:"paused,omitempty"` // 멈춘 상태로 시작
+}
+
+// ReplayControlPayload 다시보기 조작
+type ReplayControlPayload struct {
+	Action ReplayAction `json:"action"`
+	Round  int          `json:"round,omitempty"` // seek: 이동할 라운드
+	Speed  float64      `json:"speed,omitempty"` // speed: 재생 배속
+}
+
+// ReplayStatePayload 다시보기 진행 상태
+type ReplayStatePayload struct {
+	GameID      string  `json:"gameId"`
+	Round       int     `json:"round"`
+	TotalRounds int     `json:"totalRounds"`
+	Playing     bool    `json:"playing"`
+	Speed       float64 `json:"speed"`
+	Finished    bool    `json:"finished"`
+}
+
+// ResumePayload 재접속 요청
+type ResumePayload struct {
+	SessionToken string `json:"sessionToken"`
+}
+
+// GameStatePayload 현재 게임 상태 스냅샷
+type GameStatePayload struct {
+	GameID         string      `json:"gameId"`
+	YourColor      PlayerColor `json:"yourColor,omitempty"`
+	SessionToken   string      `json:"sessionToken,omitempty"`
+	Round          int         `json:"round"`
+	BlueWins       int         `json:"blueWins"`
+	RedWins        int         `json:"redWins"`
+	BlueName       string      `json:"blueName"`
+	RedName        string      `json:"redName"`
+	CurrentPlayer  PlayerColor `json:"currentPlayer"`
+	WaitingFor     PlayerColor `json:"waitingFor"`
+	BlueUsedTiles  []int       `json:"blueUsedTiles"`
+	RedUsedTiles   []int       `json:"redUsedTiles"`
+	BlueTilePlayed bool        `json:"blueTilePlayed"`
+	RedTilePlayed  bool        `json:"redTilePlayed"`
+	BlueConnected  bool        `json:"blueConnected"`
+	RedConnected   bool        `json:"redConnected"`
+	Deadline       int64       `json:"deadline,omitempty"` // 현재 차례 마감 시각 (unix ms)
+	TileReveal     TileReveal  `json:"tileReveal"`
+	Rules          RuleSet     `json:"rules"`
+	DrawOffer      PlayerColor `json:"drawOffer,omitempty"` // 응답을 기다리는 무승부 제안 (플레이어 시점에만)
+}
+
+// OpponentStatusPayload 상대방 연결 상태 변경
+type OpponentStatusPayload struct {
 	Color        PlayerColor `json:"color"`
-	Tile         int         `json:"tile"`
-	Round        int         `json:"round"`
-	NextPlayer   PlayerColor `json:"nextPlayer"`
-	WaitingFor   PlayerColor `json:"waitingFor"`
-	BlueTilePlayed bool      `json:"blueTilePlayed"`
-	RedTilePlayed  bool      `json:"redTilePlayed"`
+	GraceSeconds int         `json:"graceSeconds,omitempty"`
+}
+
+// MoveRecord 기록된 타일 한 수
+type MoveRecord struct {
+	Round int         `json:"round"`
+	Color PlayerColor `json:"color"`
+	Tile  int         `json:"tile"`
+	At    time.Time   `json:"at"`
+	Auto  bool        `json:"auto,omitempty"` // 시간 초과 등으로 서버가 대신 낸 타일
+}
+
+// RoundRecord 기록된 라운드 결과
+type RoundRecord struct {
+	Round    int         `json:"round"`
+	BlueTile int         `json:"blueTile"`
+	RedTile  int         `json:"redTile"`
+	Winner   PlayerColor `json:"winner"`
+	Forfeit  bool        `json:"forfeit,omitempty"` // 시간 초과로 몰수되어 타일과 상관없이 승자가 정해진 라운드
+}
+
+// PlayerRecord 기록된 플레이어 정보
+type PlayerRecord struct {
+	ID   string   `json:"id,omitempty"` // 레이팅 프로필 ID
+	Name string   `json:"name"`
+	Bot  BotLevel `json:"bot,omitempty"`
+}
+
+// GameRecord 종료된 구룡투 게임 기록
+type GameRecord struct {
+	ID            string                        `json:"id"`
+	Blue          PlayerRecord                  `json:"blue"`
+	Red           PlayerRecord                  `json:"red"`
+	Rules         RuleSet                       `json:"rules"`
+	TurnSeconds   int                           `json:"turnSeconds"`
+	TimeoutPolicy TimeoutPolicy                 `json:"timeoutPolicy"`
+	TileReveal    TileReveal                    `json:"tileReveal"`
+	FirstPlayer   PlayerColor                   `json:"firstPlayer"`
+	StartedAt     time.Time                     `json:"startedAt"`
+	EndedAt       time.Time                     `json:"endedAt"`
+	Moves         []MoveRecord                  `json:"moves"`
+	Rounds        []RoundRecord                 `json:"rounds"`
+	Winner        PlayerColor                   `json:"winner"`
+	BlueWins      int                           `json:"blueWins"`
+	RedWins       int                           `json:"redWins"`
+	Reason        string                        `json:"reason"`
+	Rated         bool                          `json:"rated"`
+	Ratings       map[PlayerColor]*RatingChange `json:"ratings,omitempty"`
+	SeriesID      string                        `json:"seriesId,omitempty"` // 다전제 게임이면 다전제 기록 ID
 }
 
 // ==================== NumberChange Game Types ====================
 
-// TeamColor 팀 색상
-type TeamColor string
+// TeamColor 넘버체인지 좌석 (팀)
+type TeamColor = Seat
 
 const (
 	Team1 TeamColor = "team1"
 	Team2 TeamColor = "team2"
 )
 
-// NCMessageType 넘버체인지 메시지 타입
-type NCMessageType string
+// ExchangeRule 라운드 후 상대 블록을 받는 방식
+type ExchangeRule string
+
+const (
+	ExchangeLarger ExchangeRule = "larger" // 상대 블록 중 가장 큰 블록 (상대가 히든 사용 시 선택)
+	ExchangeChosen ExchangeRule = "chosen" // 매 라운드 상대 블록 중 하나를 선택
+)
+
+// NCPhase 넘버체인지 게임 진행 단계
+type NCPhase string
 
 const (
-	NCMsgJoinGame       NCMessageType = "nc_join_game"
-	NCMsgGameStart      NCMessageType = "nc_game_start"
-	NCMsgSubmitBlocks   NCMessageType = "nc_submit_blocks"
-	NCMsgSelectBlock    NCMessageType = "nc_select_block"
-	NCMsgRoundResult    NCMessageType = "nc_round_result"
-	NCMsgGameOver       NCMessageType = "nc_game_over"
-	NCMsgError          NCMessageType = "nc_error"
-	NCMsgPlayerJoined   NCMessageType = "nc_player_joined"
-	NCMsgWaitingPlayer  NCMessageType = "nc_waiting_player"
-	NCMsgUseHidden      NCMessageType = "nc_use_hidden"
+	NCPhaseWaiting           NCPhase = "waiting"            // 팀이 모이기를 기다리는 중
+	NCPhaseSubmitting        NCPhase = "submitting"         // 양 팀이 블록을 제출하는 중
+	NCPhaseAwaitingSelection NCPhase = "awaiting_selection" // AwaitingTeams가 받을 블록을 고르는 중
+	NCPhaseResolving         NCPhase = "resolving"          // 라운드 결과를 처리하는 중
+	NCPhaseOvertime          NCPhase = "overtime"           // 연장전(서든데스) 라운드 블록을 제출하는 중
+	NCPhaseFinished          NCPhase = "finished"           // 게임이 끝남
 )
 
-// NCClient 넘버체인지 클라이언트
-type NCClient struct {
-	ID         string
-	Name       string
-	Conn       *websocket.Conn
-	Hub        *NCHub
-	Send       chan []byte
-	GameID     string
-	Team       TeamColor
+// 넘버체인지 AI 난이도
+const (
+	NCBotEasy   BotLevel = "easy"   // 무작위 제출
+	NCBotNormal BotLevel = "normal" // 이번 라운드 합계 최대화
+	NCBotHard   BotLevel = "hard"   // 라운드 승률과 블록 교환 손익을 함께 계산
+)
+
+// NCRuleSet 넘버체인지 규칙
+type NCRuleSet struct {
+	Name            string       `json:"name"`
+	StartingBlocks  []int        `json:"startingBlocks"`  // 각 팀의 시작 블록 (중복 허용)
+	BlocksPerSubmit int          `json:"blocksPerSubmit"` // 라운드마다 제출하는 블록 수
+	ScoreTarget     int          `json:"scoreTarget"`
+	MaxRounds       int          `json:"maxRounds"`
+	HiddenChances   int          `json:"hiddenChances"` // 팀당 히든 찬스 횟수
+	Exchange        ExchangeRule `json:"exchange"`
 }
 
+// 넘버체인지 메시지 타입 (공통 메시지는 구룡투 메시지 타입 앞에 nc_를 붙임)
+
+const (
+	NCMsgJoinGame      MessageType = "nc_join_game"
+	NCMsgGameStart     MessageType = "nc_game_start"
+	NCMsgSubmitBlocks  MessageType = "nc_submit_blocks"
+	NCMsgSelectBlock   MessageType = "nc_select_block"
+	NCMsgRoundResult   MessageType = "nc_round_result"
+	NCMsgGameOver      MessageType = "nc_game_over"
+	NCMsgError         MessageType = "nc_error"
+	NCMsgPlayerJoined  MessageType = "nc_player_joined"
+	NCMsgWaitingPlayer MessageType = "nc_waiting_player"
+	NCMsgUseHidden     MessageType = "nc_use_hidden"
+
+	NCMsgResume               MessageType = "nc_resume"
+	NCMsgResumed              MessageType = "nc_resumed"
+	NCMsgOpponentDisconnected MessageType = "nc_opponent_disconnected"
+	NCMsgOpponentReconnected  MessageType = "nc_opponent_reconnected"
+	NCMsgTimeout              MessageType = "nc_timeout"
+	NCMsgSelectionPhase       MessageType = "nc_selection_phase"
+	NCMsgOvertimeStart        MessageType = "nc_overtime_start"
+
+	NCMsgCreateRoom    MessageType = "nc_create_room"
+	NCMsgJoinRoom      MessageType = "nc_join_room"
+	NCMsgStartRoom     MessageType = "nc_start_room"
+	NCMsgCancelRoom    MessageType = "nc_cancel_room"
+	NCMsgConfigureRoom MessageType = "nc_configure_room"
+	NCMsgRoomCreated   MessageType = "nc_room_created"
+	NCMsgRoomUpdated   MessageType = "nc_room_updated"
+	NCMsgRoomCancelled MessageType = "nc_room_cancelled"
+
+	NCMsgSpectate       MessageType = "nc_spectate"
+	NCMsgStopSpectating MessageType = "nc_stop_spectating"
+	NCMsgGameState      MessageType = "nc_game_state"
+
+	NCMsgReplay        MessageType = "nc_replay"
+	NCMsgReplayControl MessageType = "nc_replay_control"
+	NCMsgStopReplay    MessageType = "nc_stop_replay"
+	NCMsgReplayState   MessageType = "nc_replay_state"
+
+	NCMsgIdentify MessageType = "nc_identify"
+	NCMsgIdentity MessageType = "nc_identity"
+
+	NCMsgCancelQueue    MessageType = "nc_cancel_queue"
+	NCMsgQueueStatus    MessageType = "nc_queue_status"
+	NCMsgQueueCancelled MessageType = "nc_queue_cancelled"
+
+	NCMsgRematchOffer    MessageType = "nc_rematch_offer"
+	NCMsgRematchAccept   MessageType = "nc_rematch_accept"
+	NCMsgRematchDecline  MessageType = "nc_rematch_decline"
+	NCMsgRematchOffered  MessageType = "nc_rematch_offered"
+	NCMsgRematchDeclined MessageType = "nc_rematch_declined"
+
+	NCMsgSeriesUpdate MessageType = "nc_series_update"
+	NCMsgSeriesOver   MessageType = "nc_series_over"
+
+	NCMsgResign       MessageType = "nc_resign"
+	NCMsgOfferDraw    MessageType = "nc_offer_draw"
+	NCMsgAcceptDraw   MessageType = "nc_accept_draw"
+	NCMsgDeclineDraw  MessageType = "nc_decline_draw"
+	NCMsgDrawOffered  MessageType = "nc_draw_offered"
+	NCMsgDrawDeclined MessageType = "nc_draw_declined"
+)
+
 // NCGame 넘버체인지 게임
 type NCGame struct {
-	ID              string
-	Players         map[TeamColor]*NCClient
+	Table
 	CurrentRound    int
 	Team1Score      int
 	Team2Score      int
@@ -150,17 +643,29 @@ type NCGame struct {
 	RoundHistory    []NCRoundHistory
 	CurrentTeam     TeamColor
 	RoundSubmits    map[TeamColor]*NCSubmit
-	Team1UsedHidden bool
-	Team2UsedHidden bool
-	Ready           bool
+	HiddenUsed      map[TeamColor]int // 각 팀이 사용한 히든 찬스 횟수
+	Overtime        bool              // 마지막 라운드 후 동점이라 서든데스 진행 중
+	FirstTeam       TeamColor
+	Submissions     []NCSubmitRecord
+	Rules           NCRuleSet
+	SubmitLimit     time.Duration // 블록 제출 제한 시간
+	SelectLimit     time.Duration // 히든 찬스 블록 선택 제한 시간
+	Deadline        time.Time
+	Phase           NCPhase     // 진행 단계 (transition으로만 바꿈)
+	AwaitingTeams   []TeamColor // awaiting_selection 단계에서 받을 블록 선택이 남은 팀
+	FirstTeamPreset TeamColor   // 지정되면 무작위 대신 이 팀이 선공 (재대결)
+
+	deadlineSeq   int
+	deadlineTimer *time.Timer
 }
 
 // NCSubmit 라운드 제출 정보
 type NCSubmit struct {
-	Block1              int
-	Block2              int
+	Blocks              []int
 	UseHidden           bool
-	SelectedBlockChoice int // 히든 사용 시 선택 (1: 상대 블록1, 2: 상대 블록2)
+	SelectedBlockChoice int // 받을 상대 블록 선택 (1부터 시작하는 제출 순서)
+	SubmittedAt         time.Time
+	Auto                bool // 시간 초과로 서버가 대신 제출
 }
 
 // NCRoundHistory 라운드 히스토리
@@ -177,26 +682,27 @@ type NCRoundHistory struct {
 	Team2Hidden       bool      `json:"team2Hidden"`
 	Team1ReceivedBlock int      `json:"team1ReceivedBlock"`
 	Team2ReceivedBlock int      `json:"team2ReceivedBlock"`
-}
-
-// NCMessage 넘버체인지 메시지
-type NCMessage struct {
-	Type    NCMessageType `json:"type"`
-	Payload interface{}   `json:"payload,omitempty"`
+	Team1Played       []int     `json:"team1Played"`
+	Team2Played       []int     `json:"team2Played"`
 }
 
 // NCJoinGamePayload 게임 참가
 type NCJoinGamePayload struct {
-	PlayerName string    `json:"playerName"`
-	Team       TeamColor `json:"team,omitempty"`
+	PlayerName string     `json:"playerName"`
+	Team       TeamColor  `json:"team,omitempty"`
+	RuleSet    string     `json:"ruleSet,omitempty"` // 프리셋 이름 (classic, quick, triple, draft)
+	Bot        BotLevel   `json:"bot,omitempty"`     // 지정하면 대기열 없이 AI와 대전 (easy, normal, hard)
+	Casual     bool       `json:"casual,omitempty"`  // 레이팅에 반영하지 않는 게임
+	BestOf     int        `json:"bestOf,omitempty"`  // 다전제 판 수 (3, 5, 7)
 }
 
 // NCSubmitBlocksPayload 블록 제출
 type NCSubmitBlocksPayload struct {
-	Block1              int  `json:"block1"`
-	Block2              int  `json:"block2"`
-	UseHidden           bool `json:"useHidden,omitempty"`
-	SelectedBlockChoice int  `json:"selectedBlockChoice,omitempty"` // 히든 사용 시 선택 (1 또는 2)
+	Block1              int   `json:"block1"`
+	Block2              int   `json:"block2"`
+	Blocks              []int `json:"blocks,omitempty"` // 두 개가 아닌 규칙에서 제출하는 블록 (있으면 block1, block2보다 우선)
+	UseHidden           bool  `json:"useHidden,omitempty"`
+	SelectedBlockChoice int   `json:"selectedBlockChoice,omitempty"` // 히든 사용 시 선택 (1 또는 2)
 }
 
 // NCSelectBlockPayload 블록 선택 (이미 제출한 후)
@@ -220,26 +726,460 @@ type NCRoundResultPayload struct {
 	Team2Hidden       bool      `json:"team2Hidden"`
 	Team1ReceivedBlock int      `json:"team1ReceivedBlock"`
 	Team2ReceivedBlock int      `json:"team2ReceivedBlock"`
+	Team1Played       []int     `json:"team1Played"`
+	Team2Played       []int     `json:"team2Played"`
 	NextTeam          TeamColor `json:"nextTeam"`
+	Deadline          int64     `json:"deadline,omitempty"` // 다음 라운드 제출 마감 시각 (unix ms)
+	Phase             NCPhase   `json:"phase"`              // 결과 처리 후 단계
 }
 
 // NCGameOverPayload 게임 종료
 type NCGameOverPayload struct {
-	Winner     TeamColor `json:"winner"`
-	Team1Score int       `json:"team1Score"`
-	Team2Score int       `json:"team2Score"`
-	Reason     string    `json:"reason"` // "score_limit", "rounds_complete", "overtime"
+	Winner     TeamColor                   `json:"winner"`
+	Team1Score int                         `json:"team1Score"`
+	Team2Score int                         `json:"team2Score"`
+	Reason     string                      `json:"reason"`            // score_limit, rounds_complete, overtime, resigned, draw_agreed, abandoned
+	Ratings    map[TeamColor]*RatingChange `json:"ratings,omitempty"` // 레이팅 게임의 레이팅 변화
+	Series     *SeriesScore                `json:"series,omitempty"`  // 재대결 전적 (이번 게임 포함)
+	Phase      NCPhase                     `json:"phase"`
 }
 
 // NCGameStartPayload 게임 시작
 type NCGameStartPayload struct {
-	YourTeam   TeamColor `json:"yourTeam"`
-	FirstTeam  TeamColor `json:"firstTeam"`
-	Team1Name  string    `json:"team1Name"`
-	Team2Name  string    `json:"team2Name"`
+	YourTeam    TeamColor    `json:"yourTeam"`
+	FirstTeam   TeamColor    `json:"firstTeam"`
+	Team1Name   string       `json:"team1Name"`
+	Team2Name   string       `json:"team2Name"`
+	Rules       NCRuleSet    `json:"rules"`
+	Team1Blocks []int        `json:"team1Blocks"`        // 시작 블록
+	Team2Blocks []int        `json:"team2Blocks"`        // 시작 블록
+	Deadline    int64        `json:"deadline,omitempty"` // 첫 라운드 제출 마감 시각 (unix ms)
+	Rated       bool         `json:"rated"`
+	Series      *SeriesScore `json:"series,omitempty"` // 재대결 전적 (이번 게임 전까지)
+	Phase       NCPhase      `json:"phase"`
 }
 
-// NCErrorPayload 에러
-type NCErrorPayload struct {
-	Message string `json:"message"`
+// NCCreateRoomPayload 비공개 방 생성
+type NCCreateRoomPayload struct {
+	PlayerName string     `json:"playerName"`
+	Team       TeamColor  `json:"team,omitempty"`
+	Password   string     `json:"password,omitempty"`
+	RuleSet    string     `json:"ruleSet,omitempty"` // 프리셋 이름
+	Rules      *NCRuleSet `json:"rules,omitempty"`   // 직접 지정한 규칙 (프리셋보다 우선)
+	BestOf     int        `json:"bestOf,omitempty"`  // 다전제 판 수 (3, 5, 7)
+}
+
+// NCConfigureRoomPayload 방 설정 변경 (방장 전용, 비어있는 항목은 유지)
+type NCConfigureRoomPayload struct {
+	Password *string    `json:"password,omitempty"`
+	RuleSet  string     `json:"ruleSet,omitempty"`
+	Rules    *NCRuleSet `json:"rules,omitempty"`
+	BestOf   *int       `json:"bestOf,omitempty"`
+}
+
+// NCRoomPayload 방 상태
+type NCRoomPayload struct {
+	Code        string    `json:"code"`
+	YourTeam    TeamColor `json:"yourTeam"`
+	OwnerTeam   TeamColor `json:"ownerTeam"`
+	Team1Name   string    `json:"team1Name"`
+	Team2Name   string    `json:"team2Name"`
+	HasPassword bool      `json:"hasPassword"`
+	Rules       NCRuleSet `json:"rules"`
+	BestOf      int       `json:"bestOf,omitempty"`
+	ExpiresAt   int64     `json:"expiresAt"` // 방 만료 시각 (unix ms)
+}
+
+// NCGameStatePayload 현재 게임 상태 스냅샷
+type NCGameStatePayload struct {
+	GameID                string                 `json:"gameId"`
+	YourTeam              TeamColor              `json:"yourTeam,omitempty"`
+	SessionToken          string                 `json:"sessionToken,omitempty"`
+	Round                 int                    `json:"round"`
+	Team1Score            int                    `json:"team1Score"`
+	Team2Score            int                    `json:"team2Score"`
+	Team1Name             string                 `json:"team1Name"`
+	Team2Name             string                 `json:"team2Name"`
+	CurrentTeam           TeamColor              `json:"currentTeam"`
+	Team1Blocks           []int                  `json:"team1Blocks"`
+	Team2Blocks           []int                  `json:"team2Blocks"`
+	Team1UsedHidden       bool                   `json:"team1UsedHidden"`
+	Team2UsedHidden       bool                   `json:"team2UsedHidden"`
+	Team1HiddenLeft       int                    `json:"team1HiddenLeft"`
+	Team2HiddenLeft       int                    `json:"team2HiddenLeft"`
+	Team1Submitted        bool                   `json:"team1Submitted"`
+	Team2Submitted        bool                   `json:"team2Submitted"`
+	Team1SelectionPending bool                   `json:"team1SelectionPending"`
+	Team2SelectionPending bool                   `json:"team2SelectionPending"`
+	YourSubmit            *NCSubmitBlocksPayload `json:"yourSubmit,omitempty"`
+	Team1Connected        bool                   `json:"team1Connected"`
+	Team2Connected        bool                   `json:"team2Connected"`
+	RoundHistory          []NCRoundHistory       `json:"roundHistory"`
+	Rules                 NCRuleSet              `json:"rules"`
+	Overtime              bool                   `json:"overtime"`
+	Deadline              int64                  `json:"deadline,omitempty"` // 현재 단계 마감 시각 (unix ms)
+	Phase                 NCPhase                `json:"phase"`
+	AwaitingTeams         []TeamColor            `json:"awaitingTeams,omitempty"` // awaiting_selection 단계에서 선택이 남은 팀
+	DrawOffer             TeamColor              `json:"drawOffer,omitempty"`     // 응답을 기다리는 무승부 제안 (플레이어 시점에만)
+}
+
+// NCOvertimeStartPayload 연장전(서든데스) 시작
+type NCOvertimeStartPayload struct {
+	Round      int     `json:"round"` // 첫 연장 라운드 번호
+	Team1Score int     `json:"team1Score"`
+	Team2Score int     `json:"team2Score"`
+	Deadline   int64   `json:"deadline,omitempty"` // 연장 라운드 제출 마감 시각 (unix ms)
+	Phase      NCPhase `json:"phase"`
+}
+
+// NCSelectionPhasePayload 히든 찬스 블록 선택 단계 시작
+type NCSelectionPhasePayload struct {
+	Round        int         `json:"round"`
+	PendingTeams []TeamColor `json:"pendingTeams"`
+	Deadline     int64       `json:"deadline"` // 선택 마감 시각 (unix ms)
+	Phase        NCPhase     `json:"phase"`
+}
+
+// NCUseHiddenPayload 히든 찬스 사용 알림 (양 팀 제출이 끝난 뒤 보냄)
+type NCUseHiddenPayload struct {
+	Team          TeamColor   `json:"team"`
+	Phase         NCPhase     `json:"phase"`
+	AwaitingTeams []TeamColor `json:"awaitingTeams"` // 받을 블록 선택이 남은 팀
+}
+
+// NCTimeoutPayload 마감 시간 초과로 자동 적용된 내용
+type NCTimeoutPayload struct {
+	Team                TeamColor `json:"team"`
+	Round               int       `json:"round"`
+	Phase               NCPhase   `json:"phase"`                         // 마감이 지난 단계 (submitting, overtime, awaiting_selection)
+	Block1              int       `json:"block1,omitempty"`              // 자동 제출된 블록 (본인에게만 공개)
+	Block2              int       `json:"block2,omitempty"`              // 자동 제출된 블록 (본인에게만 공개)
+	Blocks              []int     `json:"blocks,omitempty"`              // 자동 제출된 전체 블록 (본인에게만 공개)
+	SelectedBlockChoice int       `json:"selectedBlockChoice,omitempty"` // 자동 선택된 블록
+}
+
+// NCOpponentStatusPayload 상대 팀 연결 상태 변경
+type NCOpponentStatusPayload struct {
+	Team         TeamColor `json:"team"`
+	GraceSeconds int       `json:"graceSeconds,omitempty"`
+}
+
+// NCSubmitRecord 기록된 팀 제출
+type NCSubmitRecord struct {
+	Round               int       `json:"round"`
+	Team                TeamColor `json:"team"`
+	Blocks              []int     `json:"blocks"`
+	UseHidden           bool      `json:"useHidden"`
+	SelectedBlockChoice int       `json:"selectedBlockChoice,omitempty"`
+	At                  time.Time `json:"at"`
+	Auto                bool      `json:"auto,omitempty"` // 시간 초과로 서버가 대신 제출
+}
+
+// NCGameRecord 종료된 넘버체인지 게임 기록
+type NCGameRecord struct {
+	ID          string                      `json:"id"`
+	Team1       PlayerRecord                `json:"team1"`
+	Team2       PlayerRecord                `json:"team2"`
+	Rules       NCRuleSet                   `json:"rules"`
+	FirstTeam   TeamColor                   `json:"firstTeam"`
+	StartedAt   time.Time                   `json:"startedAt"`
+	EndedAt     time.Time                   `json:"endedAt"`
+	Submissions []NCSubmitRecord            `json:"submissions"`
+	Rounds      []NCRoundHistory            `json:"rounds"`
+	Winner      TeamColor                   `json:"winner"`
+	Team1Score  int                         `json:"team1Score"`
+	Team2Score  int                         `json:"team2Score"`
+	Overtime    bool                        `json:"overtime"`
+	Reason      string                      `json:"reason"`
+	Rated       bool                        `json:"rated"`
+	Ratings     map[TeamColor]*RatingChange `json:"ratings,omitempty"`
+	SeriesID    string                      `json:"seriesId,omitempty"`
+}
+
+// ==================== Rating Types ====================
+
+// GameKind 레이팅을 따로 관리하는 게임 종류
+type GameKind string
+
+const (
+	GameNineDragons  GameKind = "ninedragons"
+	GameNumberChange GameKind = "numberchange"
+)
+
+// Rating 한 게임 종류의 Glicko 레이팅
+type Rating struct {
+	Rating     float64       `json:"rating"`
+	RD         float64       `json:"rd"` // 레이팅 편차 (클수록 불확실)
+	Games      int           `json:"games"`
+	Wins       int           `json:"wins"`
+	Losses     int           `json:"losses"`
+	Draws      int           `json:"draws"`
+	LastPlayed time.Time                 `json:"lastPlayed"`
+	Streak     Streak                    `json:"streak"`            // 가장 최근 결과가 이어진 횟수
+	Monthly    map[string]*MonthlyRating `json:"monthly,omitempty"` // 월별 집계 (키: 2006-01, UTC)
+	History    []RatingPoint             `json:"history,omitempty"` // 오래된 순 (최근 maxHistory판만 보관)
+}
+
+// 레이팅 게임 결과
+const (
+	resultWin  = "win"
+	resultLoss = "loss"
+	resultDraw = "draw"
+)
+
+// MonthlyRating 한 달 동안의 레이팅 게임 집계
+type MonthlyRating struct {
+	Games       int `json:"games"`
+	Wins        int `json:"wins"`
+	Losses      int `json:"losses"`
+	Draws       int `json:"draws"`
+	StartRating int `json:"startRating"` // 그 달 첫 게임 전 레이팅
+	Rating      int `json:"rating"`      // 그 달 마지막 게임 뒤 레이팅
+}
+
+// RatingPoint 레이팅 게임 한 판이 끝난 뒤의 레이팅
+type RatingPoint struct {
+	GameID string    `json:"gameId"`
+	At     time.Time `json:"at"`
+	Rating int       `json:"rating"`
+	Delta  int       `json:"delta"`
+	Result string    `json:"result"` // win, loss, draw
+}
+
+// PlayerProfile 레이팅이 쌓이는 영구 플레이어 정보
+type PlayerProfile struct {
+	ID        string               `json:"id"`
+	Token     string               `json:"token"` // 본인 확인용 비밀 토큰 (공개 금지)
+	Name      string               `json:"name"`
+	Ratings   map[GameKind]*Rating `json:"ratings"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Abandons  *abandonRecord       `json:"abandons,omitempty"` // 대기열 참가 제한에 쓰는 기권패 기록 (두 게임 공통)
+}
+
+// RatingChange 게임 한 판의 레이팅 변화
+type RatingChange struct {
+	PlayerID    string `json:"playerId"`
+	Before      int    `json:"before"`
+	After       int    `json:"after"`
+	Delta       int    `json:"delta"`
+	Provisional bool   `json:"provisional"` // 변화 후에도 잠정 레이팅인지
+}
+
+// RatingView 클라이언트에 보내는 레이팅 요약
+type RatingView struct {
+	Rating      int  `json:"rating"`
+	RD          int  `json:"rd"`
+	Games       int  `json:"games"`
+	Provisional bool `json:"provisional"`
+}
+
+// IdentifyPayload 레이팅 프로필 확인 (토큰이 없으면 처음 한 번만 새 프로필 발급, 모르는 토큰이면 에러)
+type IdentifyPayload struct {
+	PlayerToken string `json:"playerToken,omitempty"`
+	PlayerName  string `json:"playerName,omitempty"`
+}
+
+// IdentityPayload 확인된 레이팅 프로필 (토큰은 본인에게만 전송)
+type IdentityPayload struct {
+	PlayerID    string     `json:"playerId"`
+	PlayerToken string     `json:"playerToken"`
+	Name        string     `json:"name"`
+	Rating      RatingView `json:"rating"`
+}
+
+// ==================== Matchmaking Types ====================
+
+// QueueEntry 매칭 대기열에 선 플레이어
+type QueueEntry struct {
+	ID       string // 클라이언트 ID
+	RuleSet  string
+	Casual   bool
+	BestOf   int // 다전제 판 수 (단판은 0)
+	Rating   float64
+	Side     string // 선호하는 색/팀 (비어있으면 상관없음)
+	Settings string // 게임 종류별 추가 설정 (같은 값끼리만 매칭, 비어있으면 없음)
+	JoinedAt time.Time
+
+	// 허브가 매칭 후 게임을 만들 때 쓰는 참가 요청
+	Data interface{}
+}
+
+// Match 대기열에서 짝지어진 두 플레이어 (First가 첫 번째 색/팀)
+type Match struct {
+	First  *QueueEntry
+	Second *QueueEntry
+}
+
+// QueueStatus 대기 중인 플레이어에게 보내는 대기열 상태
+type QueueStatus struct {
+	RuleSet       string `json:"ruleSet"`
+	Casual        bool   `json:"casual"`
+	Position      int    `json:"position"` // 1부터 시작하는 대기 순번
+	QueueSize     int    `json:"queueSize"`
+	WaitedSeconds int    `json:"waitedSeconds"`
+	EstimatedWait int    `json:"estimatedWait"` // 예상 남은 대기 시간(초), 아직 알 수 없으면 -1
+	RatingWindow  int    `json:"ratingWindow"`  // 지금 상대를 찾는 레이팅 범위 (±)
+}
+
+// ==================== Stats Types ====================
+
+// LeaderboardEntry 리더보드 한 줄
+type LeaderboardEntry struct {
+	Rank         int    `json:"rank"`
+	PlayerID     string `json:"playerId"`
+	Name         string `json:"name"`
+	Rating       int    `json:"rating"` // 월간 리더보드는 해당 월 마지막 게임 뒤 레이팅
+	RD           int    `json:"rd"`
+	Games        int    `json:"games"` // 월간 리더보드는 해당 월의 기록
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	Draws        int    `json:"draws"`
+	RatingChange int    `json:"ratingChange"` // 해당 월에 오른 레이팅 (전체 기간은 0)
+	Provisional  bool   `json:"provisional"`
+}
+
+// LeaderboardPayload 리더보드 조회 응답
+type LeaderboardPayload struct {
+	Game     GameKind           `json:"game"`
+	Period   string             `json:"period"` // all, monthly
+	Month    string             `json:"month,omitempty"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"pageSize"`
+	Total    int                `json:"total"`
+	Entries  []LeaderboardEntry `json:"entries"`
+}
+
+// Streak 최근 같은 결과가 이어진 횟수
+type Streak struct {
+	Result string `json:"result,omitempty"` // win, loss, draw
+	Count  int    `json:"count"`
+}
+
+// PlayerGameStats 플레이어의 게임 종류별 레이팅 게임 통계
+type PlayerGameStats struct {
+	Rating       RatingView    `json:"rating"`
+	Games        int           `json:"games"`
+	Wins         int           `json:"wins"`
+	Losses       int           `json:"losses"`
+	Draws        int           `json:"draws"`
+	WinRate      float64       `json:"winRate"`
+	Streak       Streak        `json:"streak"`
+	History      []RatingPoint `json:"history"` // 최근 순, 페이지 단위
+	HistoryTotal int           `json:"historyTotal"`
+}
+
+// PlayerStatsPayload 플레이어 프로필 조회 응답
+type PlayerStatsPayload struct {
+	PlayerID  string                        `json:"playerId"`
+	Name      string                        `json:"name"`
+	CreatedAt time.Time                     `json:"createdAt"`
+	Page      int                           `json:"page"`
+	PageSize  int                           `json:"pageSize"`
+	Games     map[GameKind]*PlayerGameStats `json:"games"`
+}
+
+// TileStat 구룡투 타일 하나의 라운드 성적
+type TileStat struct {
+	Tile    int     `json:"tile"`
+	Played  int     `json:"played"`
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	Draws   int     `json:"draws"`
+	WinRate float64 `json:"winRate"`
+}
+
+// UpsetStat 특수 조합(작은 타일이 이기는 짝)이 실제로 맞붙은 결과
+type UpsetStat struct {
+	Low      int     `json:"low"`
+	High     int     `json:"high"`
+	Meetings int     `json:"meetings"`
+	LowWins  int     `json:"lowWins"`
+	Rate     float64 `json:"rate"` // 작은 타일이 이긴 비율
+}
+
+// TileStatsPayload 구룡투 규칙별 타일 통계 응답
+type TileStatsPayload struct {
+	RuleSet string      `json:"ruleSet"`
+	Games   int         `json:"games"`
+	Rounds  int         `json:"rounds"`
+	Tiles   []TileStat  `json:"tiles"`
+	Upsets  []UpsetStat `json:"upsets"`
+}
+
+// ==================== Rematch Types ====================
+
+// Series 같은 두 플레이어가 재대결이나 다전제로 이어서 둔 게임들의 전적
+// 색(팀)은 게임마다 바뀌므로 플레이어를 첫 게임 기준 자리 번호(0, 1)로 구분한다
+type Series struct {
+	ID        string // 다전제 ID (재대결로 이어진 전적은 빈 값)
+	BestOf    int    // 다전제 판 수 (재대결로 이어진 전적은 0)
+	Games     int
+	Wins      [2]int
+	Draws     int
+	GameIDs   []string // 끝난 게임 ID (순서대로)
+	StartedAt time.Time
+	Over      bool // 다전제 승부가 남
+}
+
+// SeriesScore 클라이언트에 보내는 재대결 전적 (현재 게임의 색/팀 기준)
+type SeriesScore struct {
+	ID     string         `json:"id,omitempty"`
+	BestOf int            `json:"bestOf,omitempty"`
+	Games  int            `json:"games"`
+	Wins   map[string]int `json:"wins"`
+	Draws  int            `json:"draws"`
+}
+
+// SeriesUpdatePayload 다전제 중간 전적
+type SeriesUpdatePayload struct {
+	Series     *SeriesScore `json:"series"`
+	NextGameAt int64        `json:"nextGameAt"` // 다음 게임 시작 시각 (unix ms)
+}
+
+// SeriesOverPayload 다전제 종료
+type SeriesOverPayload struct {
+	Series  *SeriesScore           `json:"series"`
+	Winner  Seat                   `json:"winner"` // 마지막 게임의 좌석 기준 (무승부/취소는 빈 값)
+	Reason  string                 `json:"reason"` // completed, forfeit, aborted
+	Ratings map[Seat]*RatingChange `json:"ratings,omitempty"`
+}
+
+// SeriesRecord 다전제 기록 (게임 기록과 따로 한 단위로 저장)
+type SeriesRecord struct {
+	ID        string          `json:"id"`
+	Game      GameKind        `json:"game"`
+	BestOf    int             `json:"bestOf"`
+	Players   [2]PlayerRecord `json:"players"` // 자리 번호 순
+	GameIDs   []string        `json:"gameIds"`
+	Wins      [2]int          `json:"wins"`
+	Draws     int             `json:"draws"`
+	Winner    int             `json:"winner"` // 이긴 자리 번호 (무승부/취소는 -1)
+	Reason    string          `json:"reason"`
+	StartedAt time.Time       `json:"startedAt"`
+	EndedAt   time.Time       `json:"endedAt"`
+	Rated     bool            `json:"rated"`
+	Ratings   []*RatingChange `json:"ratings,omitempty"` // 자리 번호 순
+}
+
+// Rematch 게임이 끝난 두 플레이어의 재대결 제안 상태
+type Rematch struct {
+	Game      hubGame          // 끝난 게임 (같은 설정으로 새 게임 생성)
+	Winner    Seat             // 끝난 게임 승자 (무승부는 빈 값)
+	Players   map[Seat]*Client // 끝난 게임의 좌석
+	Offered   map[Seat]bool
+	ExpiresAt time.Time
+}
+
+// RematchPayload 재대결 제안/거절 알림
+type RematchPayload struct {
+	Color     PlayerColor `json:"color,omitempty"`     // 제안하거나 거절한 플레이어의 끝난 게임 색
+	Reason    string      `json:"reason,omitempty"`    // 거절 사유 (declined, left, expired)
+	ExpiresAt int64       `json:"expiresAt,omitempty"` // 제안 만료 시각 (unix ms)
+}
+
+// NCRematchPayload 재대결 제안/거절 알림
+type NCRematchPayload struct {
+	Team      TeamColor `json:"team,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ExpiresAt int64     `json:"expiresAt,omitempty"`
 }
@@ -0,0 +1,460 @@
+package server
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var updateWire = flag.Bool("update", false, "현재 서버가 보내는 메시지로 testdata/*.golden 다시 기록")
+
+const (
+	wireQuiet   = 300 * time.Millisecond // 기록할 때 이만큼 조용하면 한 단계가 끝난 것으로 봄
+	wireTimeout = 5 * time.Second        // 비교할 때 기록된 메시지를 기다리는 시간
+)
+
+// wireFrame 한 클라이언트가 받은 메시지 한 줄
+type wireFrame struct {
+	client string
+	data   string
+}
+
+// wireHarness 실제 WebSocket으로 시나리오를 돌려 주고받은 메시지를 기록하고 golden 파일과 비교
+type wireHarness struct {
+	t      *testing.T
+	url    string
+	golden string
+
+	clients map[string]*websocket.Conn
+	order   []string // 클라이언트를 처음 연결한 순서 (기록을 이 순서로 묶음)
+	frames  chan wireFrame
+
+	vars     map[string]string // "A.gameId" 처럼 클라이언트가 마지막으로 받은 값
+	expected [][]string        // golden 파일의 단계별 기록
+	out      []string
+	step     int
+
+	ids       map[string]string // 무작위 ID를 처음 본 순서대로 바꿈
+	firstTeam string            // 마지막으로 시작한 넘버체인지 게임의 선공 팀
+}
+
+var (
+	wireUUID     = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	wireCode     = regexp.MustCompile(`"code":"([A-Z0-9]{6})"`)
+	wireClock    = regexp.MustCompile(`"(deadline|expiresAt|nextGameAt|waitedSeconds|estimatedWait|ratingWindow)":-?[0-9]+`)
+	wireTeam     = regexp.MustCompile(`"(firstTeam|currentTeam|nextTeam)":"(team[12])"`)
+	wireNCStart  = regexp.MustCompile(`"type":"nc_game_start".*"firstTeam":"(team[12])"`)
+	wireVar      = regexp.MustCompile(`"(gameId|sessionToken|code)":"([^"]*)"`)
+	wirePlaceVar = regexp.MustCompile(`\{\{([A-Za-z0-9]+\.[A-Za-z]+)\}\}`)
+)
+
+func newWireHarness(t *testing.T, name string, handler http.HandlerFunc) *wireHarness {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	w := &wireHarness{
+		t:       t,
+		url:     "ws" + strings.TrimPrefix(server.URL, "http"),
+		golden:  filepath.Join("testdata", name+".golden"),
+		clients: make(map[string]*websocket.Conn),
+		frames:  make(chan wireFrame, 256),
+		vars:    make(map[string]string),
+		ids:     make(map[string]string),
+	}
+	t.Cleanup(func() {
+		for _, conn := range w.clients {
+			conn.Close()
+		}
+		server.Close()
+	})
+
+	if !*updateWire {
+		data, err := os.ReadFile(w.golden)
+		if err != nil {
+			t.Fatalf("read golden (go test -run %s -update로 생성): %v", t.Name(), err)
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			if strings.HasPrefix(line, "> ") {
+				w.expected = append(w.expected, nil)
+			}
+			if len(w.expected) > 0 {
+				w.expected[len(w.expected)-1] = append(w.expected[len(w.expected)-1], line)
+			}
+		}
+	}
+	return w
+}
+
+// connect 새 클라이언트 연결
+func (w *wireHarness) connect(name string) {
+	w.t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(w.url, nil)
+	if err != nil {
+		w.t.Fatalf("dial %s: %v", name, err)
+	}
+	w.clients[name] = conn
+	w.order = append(w.order, name)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			// 쓰기 펌프가 쌓인 메시지를 줄바꿈으로 이어 보내므로 한 줄씩 나눔
+			for _, line := range bytes.Split(data, []byte{'\n'}) {
+				w.frames <- wireFrame{client: name, data: string(line)}
+			}
+		}
+	}()
+}
+
+// send 클라이언트가 메시지를 보내고 모든 클라이언트가 받은 메시지를 기록
+// {{A.gameId}}는 A가 마지막으로 받은 gameId로 바꿈
+func (w *wireHarness) send(name, message string) {
+	w.t.Helper()
+	message = wirePlaceVar.ReplaceAllStringFunc(message, func(m string) string {
+		key := wirePlaceVar.FindStringSubmatch(m)[1]
+		value, ok := w.vars[key]
+		if !ok {
+			w.t.Fatalf("step %d: %s has not received %s", w.step+1, name, key)
+		}
+		return value
+	})
+	if err := w.clients[name].WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		w.t.Fatalf("write %s: %v", name, err)
+	}
+	w.collect(fmt.Sprintf("> %s %s", name, w.normalize(message)))
+}
+
+// disconnect 클라이언트 연결을 끊고 다른 클라이언트가 받은 메시지를 기록
+func (w *wireHarness) disconnect(name string) {
+	w.t.Helper()
+	w.clients[name].Close()
+	delete(w.clients, name)
+	w.collect(fmt.Sprintf("> %s close", name))
+}
+
+// collect 한 단계에서 받은 메시지를 클라이언트 순서대로 모아 기록하고 golden과 비교
+func (w *wireHarness) collect(input string) {
+	w.t.Helper()
+	received := make(map[string][]string)
+	if *updateWire {
+		for {
+			select {
+			case f := <-w.frames:
+				received[f.client] = append(received[f.client], f.data)
+				continue
+			case <-time.After(wireQuiet):
+			}
+			break
+		}
+	} else {
+		if w.step >= len(w.expected) {
+			w.t.Fatalf("step %d (%s) is not in %s", w.step+1, input, w.golden)
+		}
+		want := make(map[string]int)
+		total := 0
+		for _, line := range w.expected[w.step][1:] {
+			want[strings.Fields(line)[1]]++
+			total++
+		}
+		timeout := time.After(wireTimeout)
+		for got := 0; got < total; {
+			select {
+			case f := <-w.frames:
+				received[f.client] = append(received[f.client], f.data)
+				if len(received[f.client]) <= want[f.client] {
+					got++
+				}
+			case <-timeout:
+				got = total
+			}
+		}
+	}
+
+	lines := []string{input}
+	for _, name := range w.order {
+		for _, data := range received[name] {
+			for _, m := range wireVar.FindAllStringSubmatch(data, -1) {
+				w.vars[name+"."+m[1]] = m[2]
+			}
+			lines = append(lines, fmt.Sprintf("< %s %s", name, w.normalize(data)))
+		}
+	}
+	w.out = append(w.out, lines...)
+
+	if !*updateWire {
+		if want := w.expected[w.step]; strings.Join(lines, "\n") != strings.Join(want, "\n") {
+			w.t.Fatalf("step %d differs\n--- got\n%s\n--- want\n%s", w.step+1, strings.Join(lines, "\n"), strings.Join(want, "\n"))
+		}
+	}
+	w.step++
+}
+
+// normalize 실행마다 달라지는 ID, 방 코드, 시각을 고정된 값으로 바꿈
+func (w *wireHarness) normalize(data string) string {
+	if m := wireNCStart.FindStringSubmatch(data); m != nil {
+		w.firstTeam = m[1]
+	}
+	alias := func(prefix, value string) string {
+		if id, ok := w.ids[value]; ok {
+			return id
+		}
+		id := fmt.Sprintf("<%s%d>", prefix, len(w.ids)+1)
+		w.ids[value] = id
+		return id
+	}
+	data = wireUUID.ReplaceAllStringFunc(data, func(id string) string { return alias("id", id) })
+	data = wireCode.ReplaceAllStringFunc(data, func(m string) string {
+		return `"code":"` + alias("code", wireCode.FindStringSubmatch(m)[1]) + `"`
+	})
+	data = wireClock.ReplaceAllString(data, `"$1":<t>`)
+	// 넘버체인지 선공은 무작위이므로 그 게임의 선공 팀 기준으로 기록
+	return wireTeam.ReplaceAllStringFunc(data, func(m string) string {
+		sub := wireTeam.FindStringSubmatch(m)
+		order := "<second>"
+		if sub[2] == w.firstTeam {
+			order = "<first>"
+		}
+		return `"` + sub[1] + `":"` + order + `"`
+	})
+}
+
+// waitFor 허브 밖에서 일어나는 일(기록 파일 쓰기 등)이 끝날 때까지 기다림
+func (w *wireHarness) waitFor(what string, done func() bool) {
+	w.t.Helper()
+	deadline := time.Now().Add(wireTimeout)
+	for !done() {
+		if time.Now().After(deadline) {
+			w.t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// finish 남은 클라이언트마다 응답이 정해진 메시지를 보내 뒤늦게 온 메시지가 없는지 확인하고 golden 기록 또는 비교
+func (w *wireHarness) finish(cancelQueue string) {
+	w.t.Helper()
+	for _, name := range w.order {
+		if w.clients[name] != nil {
+			w.send(name, `{"type":"`+cancelQueue+`"}`)
+		}
+	}
+
+	got := strings.Join(w.out, "\n") + "\n"
+	if *updateWire {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			w.t.Fatal(err)
+		}
+		if err := os.WriteFile(w.golden, []byte(got), 0o644); err != nil {
+			w.t.Fatal(err)
+		}
+		return
+	}
+	if w.step != len(w.expected) {
+		w.t.Fatalf("ran %d steps, %s has %d", w.step, w.golden, len(w.expected))
+	}
+}
+
+// wireStores 쓰기 고루틴이 끝나기 전에 지워질 수 있으므로 t.TempDir 대신 직접 만들고 정리
+func wireStores(t *testing.T) (*RecordStore, *PlayerStore) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "wire-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	records, err := NewRecordStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, err := NewPlayerStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records, players
+}
+
+// 구룡투 엔드포인트가 보내는 메시지가 기록된 바이트와 같은지 확인 (go test -run TestWireNineDragons -update로 갱신)
+func TestWireNineDragons(t *testing.T) {
+	records, players := wireStores(t)
+	hub := NewHub()
+	hub.SetRecordStore(records)
+	hub.SetPlayerStore(players)
+	go hub.Run()
+
+	w := newWireHarness(t, "ninedragons", func(rw http.ResponseWriter, r *http.Request) {
+		ServeWs(hub, rw, r)
+	})
+	for _, name := range []string{"A", "B", "S", "C"} {
+		w.connect(name)
+	}
+
+	// 대기열
+	w.send("A", `{"type":"identify","payload":{"playerName":"alice"}}`)
+	w.send("B", `{"type":"identify","payload":{"playerName":"bob"}}`)
+	w.send("S", `{"type":"cancel_queue"}`)
+	w.send("S", `{"type":"join_game","payload":{"playerName":"sam"}}`)
+	w.send("S", `{"type":"cancel_queue"}`)
+	w.send("A", `{"type":"join_game","payload":{"playerName":"alice","color":"blue"}}`)
+	w.send("A", `{"type":"join_game","payload":{"playerName":"alice"}}`)
+	w.send("B", `{"type":"join_game","payload":{"playerName":"bob"}}`)
+
+	// 관전과 타일 공개
+	w.send("S", `{"type":"spectate","payload":{"gameId":"{{A.gameId}}"}}`)
+	w.send("A", `{"type":"spectate","payload":{"gameId":"{{A.gameId}}"}}`)
+	w.send("A", `{"type":"join_game","payload":{"playerName":"alice"}}`)
+	w.send("B", `{"type":"play_tile","payload":{"tile":5}}`)
+	w.send("A", `{"type":"play_tile","payload":{"tile":12}}`)
+	w.send("A", `{"type":"play_tile","payload":{"tile":5}}`)
+	w.send("B", `{"type":"play_tile","payload":{"tile":3}}`)
+
+	// 무승부 제안, 연결 끊김과 복귀
+	w.send("A", `{"type":"offer_draw"}`)
+	w.send("B", `{"type":"decline_draw"}`)
+	w.send("A", `{"type":"offer_draw"}`)
+	w.send("B", `{"type":"accept_draw"}`)
+	w.disconnect("B")
+	w.send("A", `{"type":"offer_draw","payload":{}}`)
+	w.connect("B2")
+	w.send("B2", `{"type":"resume","payload":{"sessionToken":"nope"}}`)
+	w.send("B2", `{"type":"resume","payload":{"sessionToken":"{{B.sessionToken}}"}}`)
+	w.send("B2", `{"type":"resign"}`)
+
+	// 다시보기
+	w.waitFor("the first game record", func() bool {
+		_, err := records.LoadGame(w.vars["A.gameId"])
+		return err == nil
+	})
+	w.send("S", `{"type":"replay","payload":{"gameId":"{{A.gameId}}","paused":true}}`)
+	w.send("S", `{"type":"replay_control","payload":{"action":"seek","round":1}}`)
+	w.send("S", `{"type":"replay_control","payload":{"action":"rewind"}}`)
+	w.send("S", `{"type":"stop_replay"}`)
+	w.send("S", `{"type":"replay_control","payload":{"action":"play"}}`)
+	w.send("S", `{"type":"replay","payload":{"gameId":"missing"}}`)
+
+	// 재대결과 합의 무승부
+	w.send("A", `{"type":"rematch_accept"}`)
+	w.send("A", `{"type":"rematch_offer"}`)
+	w.send("B2", `{"type":"rematch_accept"}`)
+	w.send("B2", `{"type":"play_tile","payload":{"tile":9}}`)
+	w.send("A", `{"type":"offer_draw"}`)
+	w.send("B2", `{"type":"accept_draw"}`)
+	w.send("A", `{"type":"rematch_decline"}`)
+	w.send("B2", `{"type":"rematch_accept"}`)
+
+	// 3판 2선승 방
+	w.send("C", `{"type":"start_room"}`)
+	w.send("C", `{"type":"create_room","payload":{"playerName":"carol","password":"pw","bestOf":3,"turnSeconds":20,"tileReveal":"open"}}`)
+	w.send("A", `{"type":"join_room","payload":{"playerName":"alice","code":"{{C.code}}","password":"nope"}}`)
+	w.send("A", `{"type":"join_room","payload":{"playerName":"alice","code":"{{C.code}}","password":"pw"}}`)
+	w.send("B2", `{"type":"join_room","payload":{"playerName":"bob","code":"{{C.code}}","password":"pw"}}`)
+	w.send("A", `{"type":"start_room"}`)
+	w.send("C", `{"type":"configure_room","payload":{"tileReveal":"color","ruleSet":"short"}}`)
+	w.send("C", `{"type":"start_room"}`)
+	w.send("C", `{"type":"play_tile","payload":{"tile":7}}`)
+	w.send("A", `{"type":"resign"}`)
+	w.send("A", `{"type":"rematch_offer"}`)
+	w.send("C", `{"type":"rematch_offer"}`)
+	w.send("A", `{"type":"resign"}`)
+	w.send("A", `{"type":"rematch_decline"}`)
+
+	// 방 취소
+	w.send("C", `{"type":"create_room","payload":{"playerName":"carol"}}`)
+	w.send("A", `{"type":"join_room","payload":{"playerName":"alice","code":"{{C.code}}"}}`)
+	w.send("C", `{"type":"cancel_room"}`)
+	w.send("C", `{"type":"cancel_room"}`)
+
+	w.finish("cancel_queue")
+}
+
+// 넘버체인지 엔드포인트가 보내는 메시지가 기록된 바이트와 같은지 확인 (go test -run TestWireNumberChange -update로 갱신)
+func TestWireNumberChange(t *testing.T) {
+	records, players := wireStores(t)
+	hub := NewNCHub()
+	hub.SetRecordStore(records)
+	hub.SetPlayerStore(players)
+	go hub.Run()
+
+	w := newWireHarness(t, "numberchange", func(rw http.ResponseWriter, r *http.Request) {
+		ServeNCWs(hub, rw, r)
+	})
+	for _, name := range []string{"A", "B", "S", "C"} {
+		w.connect(name)
+	}
+
+	// 대기열
+	w.send("A", `{"type":"nc_identify","payload":{"playerName":"alice"}}`)
+	w.send("B", `{"type":"nc_identify","payload":{"playerName":"bob"}}`)
+	w.send("S", `{"type":"nc_cancel_queue"}`)
+	w.send("S", `{"type":"nc_join_game","payload":{"playerName":"sam"}}`)
+	w.send("S", `{"type":"nc_cancel_queue"}`)
+	w.send("A", `{"type":"nc_join_game","payload":{"playerName":"alice","team":"team1"}}`)
+	w.send("B", `{"type":"nc_join_game","payload":{"playerName":"bob"}}`)
+
+	// 관전, 히든과 블록 선택
+	w.send("S", `{"type":"nc_spectate","payload":{"gameId":"{{A.gameId}}"}}`)
+	w.send("A", `{"type":"nc_join_game","payload":{"playerName":"alice"}}`)
+	w.send("A", `{"type":"nc_select_block","payload":{"selectedBlockChoice":1}}`)
+	w.send("A", `{"type":"nc_submit_blocks","payload":{"blocks":[1,2],"useHidden":true}}`)
+	w.send("A", `{"type":"nc_submit_blocks","payload":{"blocks":[3,4]}}`)
+	w.send("B", `{"type":"nc_submit_blocks","payload":{"blocks":[6,7]}}`)
+	w.send("B", `{"type":"nc_select_block","payload":{"selectedBlockChoice":2}}`)
+
+	// 무승부 제안, 연결 끊김과 복귀
+	w.send("A", `{"type":"nc_offer_draw"}`)
+	w.send("B", `{"type":"nc_decline_draw"}`)
+	w.send("A", `{"type":"nc_offer_draw"}`)
+	w.disconnect("B")
+	w.connect("B2")
+	w.send("B2", `{"type":"nc_resume","payload":{"sessionToken":"nope"}}`)
+	w.send("B2", `{"type":"nc_resume","payload":{"sessionToken":"{{B.sessionToken}}"}}`)
+	w.send("B2", `{"type":"nc_resign"}`)
+
+	// 다시보기
+	w.waitFor("the first game record", func() bool {
+		_, err := records.LoadNCGame(w.vars["A.gameId"])
+		return err == nil
+	})
+	w.send("S", `{"type":"nc_replay","payload":{"gameId":"{{A.gameId}}","paused":true}}`)
+	w.send("S", `{"type":"nc_replay_control","payload":{"action":"seek","round":1}}`)
+	w.send("S", `{"type":"nc_replay_control","payload":{"action":"speed","speed":100}}`)
+	w.send("S", `{"type":"nc_stop_replay"}`)
+	w.send("S", `{"type":"nc_replay_control","payload":{"action":"play"}}`)
+
+	// 재대결과 합의 무승부
+	w.send("A", `{"type":"nc_rematch_offer"}`)
+	w.send("B2", `{"type":"nc_rematch_accept"}`)
+	w.send("A", `{"type":"nc_offer_draw"}`)
+	w.send("B2", `{"type":"nc_accept_draw"}`)
+	w.send("B2", `{"type":"nc_rematch_offer"}`)
+	w.send("A", `{"type":"nc_rematch_decline"}`)
+
+	// 3판 2선승 방
+	w.send("C", `{"type":"nc_create_room","payload":{"playerName":"carol","password":"pw","bestOf":3}}`)
+	w.send("A", `{"type":"nc_join_room","payload":{"playerName":"alice","code":"{{C.code}}","password":"nope"}}`)
+	w.send("A", `{"type":"nc_join_room","payload":{"playerName":"alice","code":"{{C.code}}","password":"pw"}}`)
+	w.send("A", `{"type":"nc_configure_room","payload":{"ruleSet":"quick"}}`)
+	w.send("C", `{"type":"nc_configure_room","payload":{"ruleSet":"quick"}}`)
+	w.send("C", `{"type":"nc_start_room"}`)
+	w.send("A", `{"type":"nc_resign"}`)
+	w.send("C", `{"type":"nc_rematch_offer"}`)
+	w.send("A", `{"type":"nc_rematch_offer"}`)
+	w.send("A", `{"type":"nc_resign"}`)
+
+	// 방 취소
+	w.send("C", `{"type":"nc_create_room","payload":{"playerName":"carol"}}`)
+	w.send("C", `{"type":"nc_cancel_room"}`)
+
+	w.finish("nc_cancel_queue")
+}